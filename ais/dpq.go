@@ -37,6 +37,7 @@ type dpq struct {
 	owt         string // object write transaction { OwtPut, ... }
 	fltPresence string // QparamFltPresence
 	etlName     string // QparamETLName
+	etlArgs     string // QparamETLArgs
 	binfo       string // bucket info, with or without requirement to summarize remote obj-s
 
 	skipVC        bool // QparamSkipVC (skip loading existing object's metadata)
@@ -150,7 +151,13 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 			dpq.binfo = value
 
 		case apc.QparamETLName:
-			dpq.etlName = value
+			if dpq.etlName, err = url.QueryUnescape(value); err != nil {
+				return err
+			}
+		case apc.QparamETLArgs:
+			if dpq.etlArgs, err = url.QueryUnescape(value); err != nil {
+				return err
+			}
 		case apc.QparamSilent:
 			dpq.silent = cos.IsParseBool(value)
 		case apc.QparamLatestVer:
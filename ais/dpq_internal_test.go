@@ -0,0 +1,40 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+)
+
+func TestDpqParseETL(t *testing.T) {
+	tests := []struct {
+		name, args string
+	}{
+		{"md5", ""},
+		{"decode,augment:30s", ""},
+		{"decode:10s,augment", "seed=1&mode=fast"},
+	}
+	for _, test := range tests {
+		q := url.Values{}
+		q.Set(apc.QparamETLName, test.name)
+		if test.args != "" {
+			q.Set(apc.QparamETLArgs, test.args)
+		}
+		dpq := dpqAlloc()
+		if err := dpq.parse(q.Encode()); err != nil {
+			t.Fatalf("%q: %v", test.name, err)
+		}
+		if dpq.etlName != test.name {
+			t.Errorf("etl name: expected %q, got %q", test.name, dpq.etlName)
+		}
+		if dpq.etlArgs != test.args {
+			t.Errorf("etl args: expected %q, got %q", test.args, dpq.etlArgs)
+		}
+		dpqFree(dpq)
+	}
+}
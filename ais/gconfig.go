@@ -42,6 +42,7 @@ type (
 		msg       *apc.ActMsg
 		query     url.Values
 		hdr       http.Header
+		who       string // config history
 		wait      bool
 	}
 )
@@ -75,6 +75,7 @@ type (
 		UUID       string `json:"uuid"` // cluster-wide ID of this action (operation, transaction)
 		BMDVersion int64  `json:"bmdversion,string"`
 		RMDVersion int64  `json:"rmdversion,string"`
+		User       string `json:"user,omitempty"` // originating user or client (see job history)
 	}
 
 	cleanmark struct {
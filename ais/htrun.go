@@ -1119,6 +1119,7 @@ func (h *htrun) statsAndStatus() (ds *stats.NodeStatus) {
 		BuildTime:      daemon.buildTime,
 		K8sPodName:     os.Getenv(env.AIS.K8sPod),
 		Status:         h._status(smap),
+		KeepalivePhi:   h.keepalive.phis(),
 	}
 	return ds
 }
@@ -45,6 +45,7 @@ type (
 		paused() bool
 		cfg(config *cmn.Config) *cmn.KeepaliveTrackerConf
 		cluUptime(int64) time.Duration
+		phis() map[string]float64
 	}
 	talive struct {
 		t *target
@@ -77,7 +78,9 @@ type (
 		TimedOut(id string) bool        // true if 'id` didn't keepalive or called (via "heard") within the interval (above)
 
 		reg(id string)
-		set(interval time.Duration) bool
+		set(cfg *cmn.KeepaliveTrackerConf) bool
+		suspect(id string) (remove bool) // when failing to respond to (retried) pings
+		phis() map[string]float64        // current suspicion levels (phi-accrual only)
 	}
 	heartBeat struct {
 		last     sync.Map
@@ -108,7 +111,7 @@ func newTalive(t *target, statsT stats.Tracker, startedUp *atomic.Bool) *talive
 	tkr.keepalive.k = tkr
 	tkr.statsT = statsT
 	tkr.keepalive.startedUp = startedUp
-	tkr.hb = newHB(config.Keepalive.Target.Interval.D())
+	tkr.hb = newTracker(&config.Keepalive.Target)
 	tkr.controlCh = make(chan controlSignal) // unbuffered on purpose
 	tkr.interval = config.Keepalive.Target.Interval.D()
 	return tkr
@@ -171,7 +174,7 @@ func newPalive(p *proxy, statsT stats.Tracker, startedUp *atomic.Bool) *palive {
 	pkr.keepalive.k = pkr
 	pkr.statsT = statsT
 	pkr.keepalive.startedUp = startedUp
-	pkr.hb = newHB(config.Keepalive.Proxy.Interval.D())
+	pkr.hb = newTracker(&config.Keepalive.Proxy)
 	pkr.controlCh = make(chan controlSignal) // unbuffered on purpose
 	pkr.interval = config.Keepalive.Proxy.Interval.D()
 	return pkr
@@ -297,7 +300,11 @@ func (pkr *palive) ping(si *meta.Snode, wg cos.WG, smap *smapX, config *cmn.Conf
 		pkr.stoppedCh <- struct{}{}
 	}
 	if !ok {
-		pkr.toRemoveCh <- si.ID()
+		if pkr.hb.suspect(si.ID()) {
+			pkr.toRemoveCh <- si.ID()
+		} else {
+			nlog.Warningln(pkr.p.String(), "suspecting", si.StringEx(), "- not removing yet")
+		}
 	}
 	wg.Done()
 }
@@ -520,7 +527,7 @@ func (k *keepalive) _run() {
 }
 
 func (k *keepalive) configUpdate(cfg *cmn.KeepaliveTrackerConf) {
-	if k.hb.set(cfg.Interval.D()) {
+	if k.hb.set(cfg) {
 		k.interval = cfg.Interval.D()
 	}
 }
@@ -616,6 +623,15 @@ func (k *keepalive) ctrl(msg string) {
 
 func (k *keepalive) paused() bool { return k.tickerPaused.Load() }
 
+func (k *keepalive) phis() map[string]float64 { return k.hb.phis() }
+
+func newTracker(cfg *cmn.KeepaliveTrackerConf) hbTracker {
+	if cfg.Name == cmn.KeepalivePhi {
+		return newPhi(cfg)
+	}
+	return newHB(cfg.Interval.D())
+}
+
 ///////////////
 // heartBeat //
 ///////////////
@@ -652,8 +668,12 @@ func (hb *heartBeat) TimedOut(id string) bool {
 
 func (hb *heartBeat) reg(id string) { hb.last.Store(id, new(int64)) }
 
-func (hb *heartBeat) set(interval time.Duration) (changed bool) {
+func (hb *heartBeat) set(cfg *cmn.KeepaliveTrackerConf) (changed bool) {
+	interval := cfg.Interval.D()
 	changed = hb.interval != interval
 	hb.interval = interval
 	return
 }
+
+func (*heartBeat) suspect(string) bool      { return true } // remove right away
+func (*heartBeat) phis() map[string]float64 { return nil }
@@ -7,6 +7,10 @@ package ais
 import (
 	"testing"
 	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
 )
 
 func TestHB(t *testing.T) {
@@ -49,3 +53,56 @@ func TestHB(t *testing.T) {
 		t.Fatal("Expecting timeout")
 	}
 }
+
+func TestPhi(t *testing.T) {
+	const interval = 10 * time.Millisecond
+	cfg := &cmn.KeepaliveTrackerConf{
+		Name:        cmn.KeepalivePhi,
+		Interval:    cos.Duration(interval),
+		Factor:      3,
+		SuspectTime: cos.Duration(time.Hour),
+	}
+	pt := newPhi(cfg)
+
+	if !pt.TimedOut("unknown server") {
+		t.Fatal("None existing server should return timed out")
+	}
+
+	// regular arrivals
+	id := "1"
+	now := mono.NanoTime()
+	for range phiWindow {
+		pt.HeardFrom(id, now)
+		now += int64(interval)
+	}
+	pp := pt.peer(id)
+	if phi := pp.phi(pp.last+int64(interval), interval); phi >= 1 {
+		t.Fatalf("expecting low phi on schedule, got %.2f", phi)
+	}
+	if phi := pp.phi(pp.last+int64(4*interval), interval); phi < cfg.Phi() {
+		t.Fatalf("expecting phi >= %.0f when overdue, got %.2f", cfg.Phi(), phi)
+	}
+	if pp.phi(pp.last+int64(3*interval), interval) >= pp.phi(pp.last+int64(4*interval), interval) {
+		t.Fatal("expecting phi to increase over time")
+	}
+
+	// suspect (and remain so) until heard from
+	if pt.suspect(id) {
+		t.Fatal("expecting suspect, not removal")
+	}
+	pt.HeardFrom(id, 0)
+	if pp.suspect != 0 {
+		t.Fatal("expecting suspicion cleared")
+	}
+	pt.set(&cmn.KeepaliveTrackerConf{Name: cmn.KeepalivePhi, Interval: cos.Duration(interval), Factor: 1})
+	if pt.suspect(id) {
+		t.Fatal("expecting suspect, not removal")
+	}
+	time.Sleep(interval)
+	if !pt.suspect(id) {
+		t.Fatal("expecting removal upon suspect time")
+	}
+	if phis := pt.phis(); len(phis) != 1 {
+		t.Fatalf("expecting phi for a single peer, got %v", phis)
+	}
+}
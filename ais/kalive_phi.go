@@ -0,0 +1,197 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Phi-accrual failure detector ------------------------------------------------------
+// (Hayashibara et al., "The phi accrual failure detector")
+// - instead of a binary timed-out/alive verdict, computes the suspicion level phi
+//   from the (sliding window of) inter-arrival times observed for each peer;
+// - phi = -log10(probability that the next keepalive is still coming), so that
+//   phi = 8 translates as roughly 1e-8 chance of the peer being alive;
+// - a peer whose phi exceeds 'keepalivetracker.<proxy|target>.phi_threshold' is pinged;
+//   failing that, it becomes "suspect" and gets removed from the cluster map only
+//   after remaining suspect for 'suspect_time'.
+// ------------------------------------------------------------------------------------
+
+const (
+	phiWindow     = 100 // inter-arrival samples per peer
+	phiMinSamples = 3   // otherwise, assume mean = interval
+
+	// JSON cannot encode +Inf
+	phiMax = 1000
+)
+
+type (
+	phiPeer struct {
+		samples [phiWindow]float64 // inter-arrival times (ns)
+		last    int64              // mono time of the last arrival (0 - never)
+		suspect int64              // mono time when first suspected (0 - not suspect)
+		sum     float64
+		sumsq   float64
+		n, idx  int
+		mu      sync.Mutex
+	}
+	phiTracker struct {
+		peers     sync.Map // id => *phiPeer
+		interval  time.Duration
+		threshold float64
+		grace     time.Duration // suspect time
+	}
+)
+
+// interface guard
+var _ hbTracker = (*phiTracker)(nil)
+
+func newPhi(cfg *cmn.KeepaliveTrackerConf) *phiTracker {
+	return &phiTracker{interval: cfg.Interval.D(), threshold: cfg.Phi(), grace: cfg.Suspect()}
+}
+
+func (pt *phiTracker) peer(id string) *phiPeer {
+	if v, ok := pt.peers.Load(id); ok {
+		return v.(*phiPeer) // almost always
+	}
+	v, _ := pt.peers.LoadOrStore(id, &phiPeer{})
+	return v.(*phiPeer)
+}
+
+func (pt *phiTracker) HeardFrom(id string, now int64) {
+	if now == 0 {
+		now = mono.NanoTime()
+	}
+	pp := pt.peer(id)
+	pp.mu.Lock()
+	pp.arrived(now, pt.interval)
+	pp.mu.Unlock()
+}
+
+func (pt *phiTracker) TimedOut(id string) bool {
+	v, ok := pt.peers.Load(id)
+	if !ok {
+		return true
+	}
+	pp := v.(*phiPeer)
+	pp.mu.Lock()
+	phi := pp.phi(mono.NanoTime(), pt.interval)
+	pp.mu.Unlock()
+	return phi >= pt.threshold
+}
+
+func (pt *phiTracker) reg(id string) { pt.peers.Store(id, &phiPeer{}) }
+
+func (pt *phiTracker) set(cfg *cmn.KeepaliveTrackerConf) (changed bool) {
+	changed = pt.interval != cfg.Interval.D()
+	pt.interval = cfg.Interval.D()
+	pt.threshold = cfg.Phi()
+	pt.grace = cfg.Suspect()
+	return
+}
+
+// returns true when the peer has been suspect for (at least) the configured time
+func (pt *phiTracker) suspect(id string) (remove bool) {
+	var (
+		pp  = pt.peer(id)
+		now = mono.NanoTime()
+	)
+	pp.mu.Lock()
+	if pp.suspect == 0 {
+		pp.suspect = now
+	}
+	remove = time.Duration(now-pp.suspect) >= pt.grace
+	pp.mu.Unlock()
+	return
+}
+
+func (pt *phiTracker) phis() map[string]float64 {
+	var (
+		out = make(map[string]float64, 8)
+		now = mono.NanoTime()
+	)
+	pt.peers.Range(func(k, v any) bool {
+		pp := v.(*phiPeer)
+		pp.mu.Lock()
+		if pp.last != 0 {
+			out[k.(string)] = math.Round(pp.phi(now, pt.interval)*100) / 100
+		}
+		pp.mu.Unlock()
+		return true
+	})
+	return out
+}
+
+/////////////
+// phiPeer //
+/////////////
+
+// (under lock)
+func (pp *phiPeer) arrived(now int64, interval time.Duration) {
+	pp.suspect = 0
+	if pp.last == 0 {
+		pp.last = now
+		return
+	}
+	delta := float64(now - pp.last)
+	if delta < float64(interval/2) {
+		// (intra-cluster calls in addition to keepalives) - not a sample
+		pp.last = max(pp.last, now)
+		return
+	}
+	pp.last = now
+	if pp.n == phiWindow {
+		old := pp.samples[pp.idx]
+		pp.sum -= old
+		pp.sumsq -= old * old
+	} else {
+		pp.n++
+	}
+	pp.samples[pp.idx] = delta
+	pp.sum += delta
+	pp.sumsq += delta * delta
+	pp.idx = (pp.idx + 1) % phiWindow
+}
+
+// mean and standard deviation of the inter-arrival times (with a lower bound
+// on the latter to tolerate a perfectly regular peer that suddenly hiccups)
+func (pp *phiPeer) stats(interval time.Duration) (mean, std float64) {
+	if pp.n < phiMinSamples {
+		mean = float64(interval)
+	} else {
+		mean = pp.sum / float64(pp.n)
+		std = math.Sqrt(max(pp.sumsq/float64(pp.n)-mean*mean, 0))
+	}
+	std = max(std, mean/4)
+	return
+}
+
+// logistic approximation of the normal CDF (as in Akka and Cassandra)
+func (pp *phiPeer) phi(now int64, interval time.Duration) float64 {
+	if pp.last == 0 {
+		return phiMax
+	}
+	var (
+		mean, std = pp.stats(interval)
+		elapsed   = float64(now - pp.last)
+		y         = (elapsed - mean) / std
+		e         = math.Exp(-y * (1.5976 + 0.070566*y*y))
+		phi       float64
+	)
+	if elapsed > mean {
+		phi = -math.Log10(e / (1 + e))
+	} else {
+		phi = -math.Log10(1 - 1/(1+e))
+	}
+	if math.IsNaN(phi) || phi > phiMax {
+		return phiMax
+	}
+	return max(phi, 0)
+}
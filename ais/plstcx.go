@@ -33,6 +33,7 @@ type (
 		bckFrom *meta.Bck
 		bckTo   *meta.Bck
 		amsg    *apc.ActMsg // orig
+		who     string      // originating user (see reqWho)
 		config  *cmn.Config
 		smap    *smapX
 		hdr     http.Header
@@ -139,7 +140,7 @@ func (c *lstcx) do() (string, error) {
 		c.altmsg.Action = apc.ActETLObjects
 	}
 
-	if c.xid, err = c.p.tcobjs(c.bckFrom, c.bckTo, c.config, &c.altmsg, &c.tcomsg); err != nil {
+	if c.xid, err = c.p.tcobjs(c.bckFrom, c.bckTo, c.config, &c.altmsg, &c.tcomsg, c.who); err != nil {
 		return "", err
 	}
 
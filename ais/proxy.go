@@ -62,6 +62,9 @@ type (
 		rproxy     reverseProxy
 		notifs     notifs
 		lstca      lstca
+		invs       invSched
+		cfgh       cfgHist
+		jobq       jobq
 		reg        struct {
 			pool nodeRegPool
 			mu   sync.RWMutex
@@ -203,6 +206,9 @@ func (p *proxy) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.invs.init(p)
+	p.cfgh.init(p, config)
+	p.jobq.init(p, config)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -211,7 +217,7 @@ func (p *proxy) Run() error {
 		{r: apc.Reverse, h: p.reverseHandler, net: accessNetPublic},
 
 		// pubnet handlers: cluster must be started
-		{r: apc.Buckets, h: p.bucketHandler, net: accessNetPublic},
+		{r: apc.Buckets, h: p.jobq.wrap(p.bucketHandler), net: accessNetPublic},
 		{r: apc.Objects, h: p.objectHandler, net: accessNetPublic},
 		{r: apc.Download, h: p.downloadHandler, net: accessNetPublic},
 		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
@@ -219,7 +225,7 @@ func (p *proxy) Run() error {
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
 		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
-		{r: apc.Cluster, h: p.clusterHandler, net: accessNetPublicControl},
+		{r: apc.Cluster, h: p.jobq.wrap(p.clusterHandler), net: accessNetPublicControl},
 		{r: apc.Tokens, h: p.tokenHandler, net: accessNetPublic},
 
 		{r: apc.Metasync, h: p.metasyncHandler, net: accessNetIntraControl},
@@ -949,7 +955,7 @@ func (p *proxy) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *ap
 				return
 			}
 		}
-		xid, err := p.listrange(r.Method, bck.Name, msg, apireq.query)
+		xid, err := p.listrange(r.Method, bck.Name, msg, apireq.query, p.reqWho(r))
 		if err != nil {
 			p.writeErr(w, r, err)
 			return
@@ -1180,7 +1186,7 @@ func (p *proxy) httpbckput(w http.ResponseWriter, r *http.Request) {
 			p.writeErr(w, r, err)
 			return
 		}
-		xid, err := p.createArchMultiObj(bckFrom, bckTo, msg)
+		xid, err := p.createArchMultiObj(bckFrom, bckTo, msg, p.reqWho(r))
 		if err == nil {
 			w.Header().Set(cos.HdrContentLength, strconv.Itoa(len(xid)))
 			w.Write([]byte(xid))
@@ -1284,7 +1290,7 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			return
 		}
 		nlog.Infof("%s bucket %s => %s", msg.Action, bckFrom, bckTo)
-		if xid, err = p.renameBucket(bckFrom, bckTo, msg); err != nil {
+		if xid, err = p.renameBucket(bckFrom, bckTo, msg, p.reqWho(r)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
@@ -1302,16 +1308,16 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 				p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 				return
 			}
-			if err := tcbmsg.Validate(true); err != nil {
-				p.writeErr(w, r, err)
-				return
-			}
 		case apc.ActCopyBck:
 			if err = cos.MorphMarshal(msg.Value, &tcbmsg.CopyBckMsg); err != nil {
 				p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
 				return
 			}
 		}
+		if err := tcbmsg.Validate(msg.Action == apc.ActETLBck); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
 		if tcbmsg.Sync && tcbmsg.Prepend != "" {
 			p.writeErrf(w, r, errPrependSync, tcbmsg.Prepend)
 			return
@@ -1354,13 +1360,14 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 				bckFrom: bckFrom,
 				bckTo:   bckTo,
 				amsg:    msg,
+				who:     p.reqWho(r),
 				config:  cmn.GCO.Get(),
 			}
 			lstcx.tcomsg.TCBMsg = *tcbmsg
 			xid, err = lstcx.do()
 		} else {
 			nlog.Infoln("x-tcb:", bckFrom.String(), "=>", bckTo.String())
-			xid, err = p.tcb(bckFrom, bckTo, msg, tcbmsg.DryRun)
+			xid, err = p.tcb(bckFrom, bckTo, msg, tcbmsg.DryRun, p.reqWho(r))
 		}
 		if err != nil {
 			p.writeErr(w, r, err)
@@ -1381,6 +1388,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErrf(w, r, errPrependSync, tcomsg.Prepend)
 			return
 		}
+		if err := tcomsg.CopyBckMsg.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
 		bckTo = meta.CloneBck(&tcomsg.ToBck)
 
 		if bck.Equal(bckTo, true, true) {
@@ -1407,7 +1418,7 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			}
 		}
 
-		xid, err = p.tcobjs(bck, bckTo, cmn.GCO.Get(), msg, tcomsg)
+		xid, err = p.tcobjs(bck, bckTo, cmn.GCO.Get(), msg, tcomsg, p.reqWho(r))
 		if err != nil {
 			p.writeErr(w, r, err)
 			return
@@ -1426,20 +1437,24 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErr(w, r, err)
 			return
 		}
-		if xid, err = p.listrange(r.Method, bucket, msg, query); err != nil {
+		if xid, err = p.listrange(r.Method, bucket, msg, query, p.reqWho(r)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
+	case apc.ActCreateInventory:
+		if xid, err = p.createInventory(w, r, bck, bucket, msg, query); err != nil {
+			return
+		}
 	case apc.ActInvalListCache:
 		p.qm.c.invalidate(bck.Bucket())
 		return
 	case apc.ActMakeNCopies:
-		if xid, err = p.makeNCopies(msg, bck); err != nil {
+		if xid, err = p.makeNCopies(msg, bck, p.reqWho(r)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
 	case apc.ActECEncode:
-		if xid, err = p.ecEncode(bck, msg); err != nil {
+		if xid, err = p.ecEncode(bck, msg, p.reqWho(r)); err != nil {
 			p.writeErr(w, r, err)
 			return
 		}
@@ -1453,6 +1468,44 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 	w.Write([]byte(xid))
 }
 
+// generate ais:// bucket inventory (see xact/xs/inventory.go)
+func (p *proxy) createInventory(w http.ResponseWriter, r *http.Request, bck *meta.Bck, bucket string, msg *apc.ActMsg,
+	query url.Values) (string, error) {
+	invMsg := &cmn.InvMsg{}
+	if err := cos.MorphMarshal(msg.Value, invMsg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return "", err
+	}
+	if !bck.IsAIS() {
+		err := cmn.NewErrUnsupp("generate inventory of", bck.Cname("")+" (expecting ais:// bucket)")
+		p.writeErr(w, r, err)
+		return "", err
+	}
+	if err := invMsg.Validate(); err != nil {
+		p.writeErr(w, r, err)
+		return "", err
+	}
+	tobck := invMsg.ToBck
+	if tobck.IsEmpty() && bck.Props.Inventory.Dest != "" {
+		tobck, _ = bck.Props.Inventory.DestBck() // validated
+	}
+	if !tobck.IsEmpty() {
+		bckTo := meta.CloneBck(&tobck)
+		bckToArgs := bctx{p: p, w: w, r: r, bck: bckTo, perms: apc.AcePUT, query: query}
+		if _, err := bckToArgs.initAndTry(); err != nil {
+			return "", err
+		}
+	} else if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+		return "", err
+	}
+	msg.Value = invMsg
+	xid, err := p.listrange(r.Method, bucket, msg, query, p.reqWho(r))
+	if err != nil {
+		p.writeErr(w, r, err)
+	}
+	return xid, err
+}
+
 // init existing or create remote
 // not calling `initAndTry` - delegating ais:from// props cloning to the separate method
 func (p *proxy) initBckTo(w http.ResponseWriter, r *http.Request, query url.Values, bckTo *meta.Bck) (*meta.Bck, int, error) {
@@ -1669,7 +1722,7 @@ func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr h
 		lsmsg.UUID = cos.GenUUID()
 		newls = true
 	}
-	tsi, listRemote, wantOnlyRemote, err = p._lsofc(bck, lsmsg, smap)
+	tsi, listRemote, wantOnlyRemote, err = p._lsofc(bck, lsmsg, hdr, smap)
 	if err != nil {
 		return nil, err
 	}
@@ -1718,12 +1771,17 @@ func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr h
 }
 
 // list-objects flow control helper
-func (p *proxy) _lsofc(bck *meta.Bck, lsmsg *apc.LsoMsg, smap *smapX) (tsi *meta.Snode, listRemote, wantOnlyRemote bool, err error) {
-	listRemote = bck.IsRemote() && !lsmsg.IsFlagSet(apc.LsObjCached)
+func (p *proxy) _lsofc(bck *meta.Bck, lsmsg *apc.LsoMsg, hdr http.Header, smap *smapX) (tsi *meta.Snode,
+	listRemote, wantOnlyRemote bool, err error) {
+	// ais:// bucket inventory (see xact/xs/inventory.go) is listed by a single target - same as remote
+	inv := bck.IsAIS() && cos.IsParseBool(hdr.Get(apc.HdrInventory))
+	listRemote = (bck.IsRemote() || inv) && !lsmsg.IsFlagSet(apc.LsObjCached)
 	if !listRemote {
 		return
 	}
-	if bck.Props.BID == 0 {
+	if inv {
+		wantOnlyRemote = true
+	} else if bck.Props.BID == 0 {
 		// remote bucket outside cluster (not in BMD) that hasn't been added ("on the fly") by the caller
 		// (lsmsg flag below)
 		debug.Assert(bck.IsRemote())
@@ -1835,7 +1893,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 				return
 			}
 		}
-		xid, err := p.promote(bck, msg, tsi)
+		xid, err := p.promote(bck, msg, tsi, p.reqWho(r))
 		if err != nil {
 			p.writeErr(w, r, err)
 			return
@@ -2022,6 +2080,10 @@ func (p *proxy) httpbckpatch(w http.ResponseWriter, r *http.Request, apireq *api
 		p.writeErr(w, r, err)
 		return
 	}
+	if err = fencedBprops(bck, msg, &propsToUpdate); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
 	// make and validate new props
 	if nprops, err = p.makeNewBckProps(bck, &propsToUpdate); err != nil {
 		p.writeErr(w, r, err)
@@ -2043,7 +2105,19 @@ func (p *proxy) httpbckpatch(w http.ResponseWriter, r *http.Request, apireq *api
 			return
 		}
 	}
-	if xid, err = p.setBprops(msg, bck, nprops); err != nil {
+	if nprops.Replication.Enabled {
+		// replication destination must exist and is added to BMD (if need be),
+		// to be then referenced by its fully-qualified name
+		dst, _ := nprops.Replication.DestBck() // (validated)
+		dstBck := meta.CloneBck(&dst)
+		args := bctx{p: p, w: w, r: r, bck: dstBck, msg: msg, dpq: apireq.dpq, query: apireq.query}
+		args.createAIS = false
+		if dstBck, err = args.initAndTry(); err != nil {
+			return
+		}
+		nprops.Replication.Dest = dstBck.Cname("")
+	}
+	if xid, err = p.setBprops(msg, bck, nprops, p.reqWho(r)); err != nil {
 		p.writeErr(w, r, err)
 		return
 	}
@@ -2324,8 +2398,8 @@ func (p *proxy) lsObjsR(bck *meta.Bck, lsmsg *apc.LsoMsg, hdr http.Header, smap
 	)
 	if cos.IsParseBool(hdr.Get(apc.HdrInventory)) {
 		// TODO: extend to other Clouds or, more precisely, other list-objects supporting backends
-		if !bck.IsRemoteS3() {
-			return nil, cmn.NewErrUnsupp("list (via bucket inventory) non-S3 bucket", bck.Cname(""))
+		if !bck.IsRemoteS3() && !bck.IsAIS() {
+			return nil, cmn.NewErrUnsupp("list (via bucket inventory) non-S3 remote bucket", bck.Cname(""))
 		}
 		if lsmsg.ContinuationToken == "" /*first page*/ {
 			// override _lsofc selection (see above)
@@ -2414,13 +2488,14 @@ func (p *proxy) redirectObjAction(w http.ResponseWriter, r *http.Request, bck *m
 	p.statsT.Inc(stats.RenameCount)
 }
 
-func (p *proxy) listrange(method, bucket string, msg *apc.ActMsg, query url.Values) (xid string, err error) {
+func (p *proxy) listrange(method, bucket string, msg *apc.ActMsg, query url.Values, who string) (xid string, err error) {
 	var (
 		smap   = p.owner.smap.get()
 		aisMsg = p.newAmsg(msg, nil, cos.GenUUID())
-		body   = cos.MustMarshal(aisMsg)
 		path   = apc.URLPathBuckets.Join(bucket)
 	)
+	aisMsg.User = who
+	body := cos.MustMarshal(aisMsg)
 	nlb := xact.NewXactNL(aisMsg.UUID, aisMsg.Action, &smap.Smap, nil)
 	nlb.SetOwner(equalIC)
 	p.ic.registerEqual(regIC{smap: smap, query: query, nl: nlb})
@@ -2684,6 +2759,28 @@ func (p *proxy) httpdaeput(w http.ResponseWriter, r *http.Request) {
 		}
 		p.termKalive(msg.Action)
 		p.shutdown(msg.Action)
+	case apc.ActSyncJobQueue:
+		if !p.ensureIntraControl(w, r, true /* from primary */) {
+			return
+		}
+		var jobs []*jobqEntry
+		if err := cos.MorphMarshal(msg.Value, &jobs); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		p.jobq.set(jobs)
+	case apc.ActSyncCfgHistory:
+		if !p.ensureIntraControl(w, r, true /* from primary */) {
+			return
+		}
+		hist := &cfgHist{}
+		if err := cos.MorphMarshal(msg.Value, hist); err != nil {
+			p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+			return
+		}
+		if !p.cfgh.set(hist) && cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Infoln(p.String(), "ignoring stale config history")
+		}
 	case apc.ActShutdownCluster:
 		smap := p.owner.smap.get()
 		isPrimary := smap.isPrimary(p.si)
@@ -3060,6 +3157,7 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 				nlog.Warningf(warnfmt, p, "", bckTo, bck)
 			}
 		}
+		parsc.User = p.reqWho(r)
 		dsort.PstartHandler(w, r, parsc)
 	case http.MethodGet:
 		dsort.PgetHandler(w, r)
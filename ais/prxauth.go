@@ -229,6 +229,9 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 //	Exceptions:
 //	- read-only access to a bucket is always granted
 //	- PATCH cannot be forbidden
+//
+// Either way, data-modifying ops are rejected (503) when the bucket or the entire
+// cluster is read-only (fenced) - see apc.AccessMut.
 func (p *proxy) checkAccess(w http.ResponseWriter, r *http.Request, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
 	if err = p.access(r.Header, bck, ace); err != nil {
 		p.writeErr(w, r, err, aceErrToCode(err))
@@ -237,10 +240,12 @@ func (p *proxy) checkAccess(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 }
 
 func aceErrToCode(err error) (status int) {
-	switch err {
-	case nil:
-	case tok.ErrNoToken, tok.ErrInvalidToken:
+	switch {
+	case err == nil:
+	case err == tok.ErrNoToken, err == tok.ErrInvalidToken:
 		status = http.StatusUnauthorized
+	case cmn.IsErrReadOnly(err):
+		status = http.StatusServiceUnavailable
 	default:
 		status = http.StatusForbidden
 	}
@@ -274,6 +279,9 @@ func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err
 	}
 	if bck == nil {
 		// cluster ACL: create/list buckets, node management, etc.
+		if mut := ace & apc.AccessMut; mut != 0 && cmn.Rom.ReadOnly() {
+			return cmn.NewErrReadOnly("cluster", apc.AccessOp(mut&-mut))
+		}
 		return nil
 	}
 
@@ -177,6 +177,28 @@ rerr:
 	return cmn.NewErrUnsupp(op, bctx.bck.Cname(""))
 }
 
+// read-only (fenced) bucket or cluster: the only permitted props change is
+// the one that toggles the bucket's 'read_only' itself
+func fencedBprops(bck *meta.Bck, msg *apc.ActMsg, propsToUpdate *cmn.BpropsToSet) error {
+	var what string
+	switch {
+	case cmn.Rom.ReadOnly():
+		what = "cluster"
+	case bck.Props.ReadOnly:
+		what = "bucket " + bck.Cname("")
+	default:
+		return nil
+	}
+	if msg.Action == apc.ActSetBprops && propsToUpdate.ReadOnly != nil {
+		other := *propsToUpdate
+		other.ReadOnly, other.Force = nil, false
+		if other == (cmn.BpropsToSet{}) {
+			return nil
+		}
+	}
+	return cmn.NewErrReadOnly(what, "updating bucket properties (other than 'read_only')")
+}
+
 // (compare w/ accessSupported)
 func (bctx *bctx) accessAllowed(bck *meta.Bck) (ecode int, err error) {
 	err = bctx.p.access(bctx.r.Header, bck, bctx.perms)
@@ -0,0 +1,377 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// cluster config and bucket props change history:
+// - recorded and retained by the primary (the last cfgHistMax versions of each kind);
+// - each entry: who, when, and the delta (changed leaf fields);
+// - cluster config entries additionally keep full snapshots, to support diff and rollback
+//   (snapshots are persisted locally and never returned via API);
+// - persisted and replicated to all proxies, to survive primary change
+//   (asynchronously - see set() below for how the receivers handle reordering);
+// - rollback (apc.ActRollbackConfig) is a regular config update (see setCluCfgPersistent)
+//   of the sections that differ from the retained version
+
+const (
+	cfgHistMax   = 32
+	cfgHistProxy = "proxy" // config section that is never rolled back
+)
+
+type (
+	cfgSnap struct {
+		cmn.CfgHistEntry
+		Snap cmn.ClusterConfig `json:"snap"`
+	}
+	cfgHist struct {
+		p      *proxy
+		fpath  string
+		Config []*cfgSnap          `json:"config"`
+		Bprops []*cmn.CfgHistEntry `json:"bprops"`
+		mu     sync.Mutex
+	}
+)
+
+func (h *cfgHist) init(p *proxy, config *cmn.Config) {
+	h.p = p
+	h.fpath = filepath.Join(config.ConfigDir, fname.CfgHistory)
+	if _, err := jsp.Load(h.fpath, h, jsp.Plain()); err != nil && !os.IsNotExist(err) {
+		nlog.Errorln("failed to load config history:", err)
+		h.Config, h.Bprops = nil, nil
+	}
+}
+
+// is called by the primary upon successful cluster config update (see _syncConfFinal)
+func (h *cfgHist) addConfig(ctx *configModifier, clone *globalConfig) {
+	var (
+		now  = time.Now().UnixNano()
+		snap = &cfgSnap{}
+	)
+	if err := _deepCopyConfig(&clone.ClusterConfig, &snap.Snap); err != nil {
+		nlog.Errorln("failed to record config history:", err)
+		return
+	}
+	snap.Who, snap.Action, snap.Version, snap.Time = ctx.who, ctx.msg.Action, clone.Version, now
+
+	h.mu.Lock()
+	if l := len(h.Config); l > 0 && h.Config[l-1].Version == clone.Version-1 {
+		snap.Delta = cmn.DiffProps(&h.Config[l-1].Snap, &clone.ClusterConfig)
+	} else if ctx.oldConfig != nil {
+		snap.Delta = cmn.DiffProps(&ctx.oldConfig.ClusterConfig, &clone.ClusterConfig)
+		if l == 0 {
+			// baseline (the version prior to the very first recorded change)
+			base := &cfgSnap{}
+			if err := _deepCopyConfig(&ctx.oldConfig.ClusterConfig, &base.Snap); err == nil {
+				base.Version = ctx.oldConfig.Version
+				h.Config = append(h.Config, base)
+			}
+		}
+	}
+	h.Config = append(h.Config, snap)
+	if l := len(h.Config); l > cfgHistMax {
+		h.Config = h.Config[l-cfgHistMax:]
+	}
+	h._persist()
+	hist := h._clone()
+	h.mu.Unlock()
+
+	go h.bcast(hist)
+}
+
+// is called by the primary upon successful set (or reset) bucket props (see setBprops)
+func (h *cfgHist) addBprops(bck *meta.Bck, action, who string, from, to *cmn.Bprops, ver int64) {
+	entry := &cmn.CfgHistEntry{
+		Bck:     bck.Bucket(),
+		Who:     who,
+		Action:  action,
+		Delta:   cmn.DiffProps(from, to),
+		Version: ver,
+		Time:    time.Now().UnixNano(),
+	}
+	h.mu.Lock()
+	h.Bprops = append(h.Bprops, entry)
+	if l := len(h.Bprops); l > cfgHistMax {
+		h.Bprops = h.Bprops[l-cfgHistMax:]
+	}
+	h._persist()
+	hist := h._clone()
+	h.mu.Unlock()
+
+	go h.bcast(hist)
+}
+
+// (non-primary) replace local history with the primary's
+// given asynchronous bcast, histories may arrive out of order - ignore the one that's not newer;
+// (note that each change increments exactly one of the two cluster-wide versions, config and BMD)
+func (h *cfgHist) set(hist *cfgHist) (ok bool) {
+	cfgVer, bpVer := hist._version()
+	h.mu.Lock()
+	if lcfg, lbp := h._version(); cfgVer > lcfg || bpVer > lbp {
+		h.Config, h.Bprops = hist.Config, hist.Bprops
+		h._persist()
+		ok = true
+	}
+	h.mu.Unlock()
+	return ok
+}
+
+// versions of the most recent config and bprops entries
+func (h *cfgHist) _version() (cfgVer, bpVer int64) {
+	if l := len(h.Config); l > 0 {
+		cfgVer = h.Config[l-1].Version
+	}
+	if l := len(h.Bprops); l > 0 {
+		bpVer = h.Bprops[l-1].Version
+	}
+	return
+}
+
+func (h *cfgHist) bcast(hist *cfgHist) {
+	p := h.p
+	msg := p.newAmsgActVal(apc.ActSyncCfgHistory, hist)
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathDae.S, Body: cos.MustMarshal(msg)}
+	args.to = core.Proxies
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			nlog.Errorln(p.String(), "failed to sync config history with", res.si.StringEx(), "err:", res.err)
+		}
+	}
+	freeBcastRes(results)
+}
+
+// (entries are immutable - shallow copy)
+func (h *cfgHist) _clone() *cfgHist {
+	return &cfgHist{
+		Config: append([]*cfgSnap(nil), h.Config...),
+		Bprops: append([]*cmn.CfgHistEntry(nil), h.Bprops...),
+	}
+}
+
+// under lock
+func (h *cfgHist) _persist() {
+	if err := jsp.Save(h.fpath, h, jsp.Plain(), nil); err != nil {
+		nlog.Errorln("failed to persist config history:", err)
+	}
+}
+
+// returns entries w/o snapshots
+func (h *cfgHist) get() *cmn.CfgHistory {
+	h.mu.Lock()
+	hist := &cmn.CfgHistory{
+		Config: make([]cmn.CfgHistEntry, 0, len(h.Config)),
+		Bprops: make([]cmn.CfgHistEntry, 0, len(h.Bprops)),
+	}
+	for _, snap := range h.Config {
+		hist.Config = append(hist.Config, snap.CfgHistEntry)
+	}
+	for _, entry := range h.Bprops {
+		hist.Bprops = append(hist.Bprops, *entry)
+	}
+	h.mu.Unlock()
+	return hist
+}
+
+// returns a (deep) copy of the retained config version
+func (h *cfgHist) snap(ver int64) (*cmn.ClusterConfig, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, snap := range h.Config {
+		if snap.Version == ver {
+			config := &cmn.ClusterConfig{}
+			err := _deepCopyConfig(&snap.Snap, config)
+			return config, err
+		}
+	}
+	return nil, cos.NewErrNotFound(nil, "cluster config version "+strconv.FormatInt(ver, 10)+
+		" (not retained)")
+}
+
+func _deepCopyConfig(from, to *cmn.ClusterConfig) error {
+	b, err := jsoniter.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal(b, to)
+}
+
+// user ID (when authenticated), or the client's address
+// (X-Forwarded-For is client-supplied and is not trusted, except for the last hop appended by the
+// reverse proxy of another proxy in the cluster - see forwardCP)
+func (p *proxy) reqWho(r *http.Request) string {
+	if cmn.Rom.AuthEnabled() {
+		if tk, err := p.validateToken(r.Header); err == nil && tk.UserID != "" {
+			return tk.UserID
+		}
+	}
+	if fwd := r.Header.Values(cos.HdrForwardedFor); len(fwd) > 0 && p.fromProxy(r) {
+		hops := strings.Split(fwd[len(fwd)-1], ",")
+		if hop := strings.TrimSpace(hops[len(hops)-1]); hop != "" {
+			return hop
+		}
+	}
+	return r.RemoteAddr
+}
+
+// whether the request comes from another proxy in the cluster (by host)
+func (p *proxy) fromProxy(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	smap := p.owner.smap.get()
+	for _, psi := range smap.Pmap {
+		if psi.ID() == p.SID() {
+			continue
+		}
+		if psi.PubNet.Hostname == host || psi.ControlNet.Hostname == host {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// GET /v1/cluster?what=(config_history | config_diff)
+//
+
+func (p *proxy) qcluCfgHistory(w http.ResponseWriter, r *http.Request, what string) {
+	if p.forwardCP(w, r, nil, what) {
+		return
+	}
+	p.writeJSON(w, r, p.cfgh.get(), what)
+}
+
+func (p *proxy) qcluCfgDiff(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	if p.forwardCP(w, r, nil, what) {
+		return
+	}
+	var (
+		configs [2]*cmn.ClusterConfig
+		vers    = [2]string{query.Get(apc.QparamFromVer), query.Get(apc.QparamToVer)}
+	)
+	for i, s := range vers {
+		ver, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			p.writeErrf(w, r, "%s: invalid config version %q", what, s)
+			return
+		}
+		if config := cmn.GCO.Get(); ver == config.Version {
+			configs[i] = &config.ClusterConfig
+			continue
+		}
+		if configs[i], err = p.cfgh.snap(ver); err != nil {
+			p.writeErr(w, r, err, http.StatusNotFound)
+			return
+		}
+	}
+	delta := cmn.DiffProps(configs[0], configs[1])
+	if delta == nil {
+		delta = []cmn.PropDelta{}
+	}
+	p.writeJSON(w, r, delta, what)
+}
+
+//
+// PUT /v1/cluster {apc.ActRollbackConfig}
+//
+
+func (p *proxy) rollbackCluCfg(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
+	var ver int64
+	if err := cos.MorphMarshal(msg.Value, &ver); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if ver == cmn.GCO.Get().Version {
+		p.writeErrf(w, r, "cluster config v%d is the current version (nothing to do)", ver)
+		return
+	}
+	snap, err := p.cfgh.snap(ver)
+	if err != nil {
+		p.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	toUpdate, err := rollbackToSet(&cmn.GCO.Get().ClusterConfig, snap)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	p.setCluCfgPersistent(w, r, toUpdate, msg)
+}
+
+// returns the config sections (except proxy) that differ from the retained version
+// as a regular config update - to be validated and applied as such
+func rollbackToSet(cur, snap *cmn.ClusterConfig) (*cmn.ConfigToSet, error) {
+	if snap.UUID != cur.UUID {
+		return nil, fmt.Errorf("rollback: cluster UUID mismatch (%q vs %q)", snap.UUID, cur.UUID)
+	}
+	sections := make(cos.StrSet, 4)
+	for _, d := range cmn.DiffProps(cur, snap) {
+		if section, _, _ := strings.Cut(d.Name, "."); section != cfgHistProxy {
+			sections.Add(section)
+		}
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("rollback: cluster config v%d does not differ from the current v%d (nothing to do)",
+			snap.Version, cur.Version)
+	}
+
+	var all map[string]jsoniter.RawMessage
+	if err := jsoniter.Unmarshal(cos.MustMarshal(snap), &all); err != nil {
+		return nil, err
+	}
+	for name := range all {
+		if !sections.Contains(name) {
+			delete(all, name)
+		}
+	}
+	toUpdate := &cmn.ConfigToSet{}
+	if err := jsoniter.Unmarshal(cos.MustMarshal(all), toUpdate); err != nil {
+		return nil, err
+	}
+
+	// check that all changes can be rolled back
+	check := &cmn.ClusterConfig{}
+	if err := _deepCopyConfig(cur, check); err != nil {
+		return nil, err
+	}
+	if err := check.Apply(toUpdate, apc.Cluster); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, d := range cmn.DiffProps(check, snap) {
+		if !strings.HasPrefix(d.Name, cfgHistProxy+".") {
+			names = append(names, d.Name)
+		}
+	}
+	if len(names) > 0 {
+		return nil, fmt.Errorf("rollback: cannot update %s (not configurable)", strings.Join(names, ", "))
+	}
+	return toUpdate, nil
+}
@@ -0,0 +1,139 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+func TestRollbackToSet(t *testing.T) {
+	cur := &cmn.ClusterConfig{UUID: "uuid", Version: 12}
+	cur.Proxy.PrimaryURL = "http://primary:8080"
+	cur.Mirror.Copies = 2
+
+	snap := &cmn.ClusterConfig{}
+	if err := _deepCopyConfig(cur, snap); err != nil {
+		t.Fatal(err)
+	}
+	snap.Version = 10
+	snap.EC.Enabled = true
+	snap.Timeout.MaxKeepalive = cos.Duration(7)
+	snap.Proxy.PrimaryURL = "http://old-primary:8080"
+
+	toUpdate, err := rollbackToSet(cur, snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toUpdate.EC == nil || toUpdate.EC.Enabled == nil || !*toUpdate.EC.Enabled {
+		t.Fatalf("expected ec.enabled=true, got %+v", toUpdate.EC)
+	}
+	if toUpdate.Timeout == nil || toUpdate.Timeout.MaxKeepalive == nil || *toUpdate.Timeout.MaxKeepalive != 7 {
+		t.Fatalf("expected timeout.max_keepalive=7, got %+v", toUpdate.Timeout)
+	}
+	if toUpdate.Proxy != nil || toUpdate.Mirror != nil || toUpdate.Net != nil {
+		t.Fatalf("expected only the changed sections (except proxy), got %+v", toUpdate)
+	}
+
+	// nothing to do
+	snap.EC.Enabled, snap.Timeout.MaxKeepalive = cur.EC.Enabled, cur.Timeout.MaxKeepalive
+	if _, err := rollbackToSet(cur, snap); err == nil {
+		t.Fatal("expected error (no changes other than proxy)")
+	}
+
+	// not configurable
+	snap.Net.L4.Proto = "udp"
+	if _, err := rollbackToSet(cur, snap); err == nil || !strings.Contains(err.Error(), "net.l4.proto") {
+		t.Fatalf("expected net.l4.proto to be rejected, got %v", err)
+	}
+
+	snap.Net.L4.Proto, snap.UUID = cur.Net.L4.Proto, "other"
+	snap.EC.Enabled = true
+	if _, err := rollbackToSet(cur, snap); err == nil {
+		t.Fatal("expected UUID mismatch")
+	}
+}
+
+func TestCfgHistSet(t *testing.T) {
+	var (
+		h    = &cfgHist{fpath: filepath.Join(t.TempDir(), fname.CfgHistory)}
+		hist = func(cfgVers, bpVers []int64) *cfgHist {
+			hist := &cfgHist{}
+			for _, ver := range cfgVers {
+				hist.Config = append(hist.Config, &cfgSnap{CfgHistEntry: cmn.CfgHistEntry{Version: ver}})
+			}
+			for _, ver := range bpVers {
+				hist.Bprops = append(hist.Bprops, &cmn.CfgHistEntry{Version: ver})
+			}
+			return hist
+		}
+	)
+	tests := []struct {
+		hist *cfgHist
+		ok   bool
+	}{
+		{hist([]int64{1, 2}, nil), true},
+		{hist([]int64{1, 2}, []int64{5}), true},
+		{hist([]int64{1, 2, 3}, []int64{5}), true},
+		{hist([]int64{1, 2}, []int64{5}), false},    // arrived late
+		{hist([]int64{1, 2, 3}, []int64{5}), false}, // duplicate
+		{hist([]int64{1, 2, 3}, []int64{5, 6}), true},
+		{hist([]int64{1, 2, 3, 4}, nil), true}, // new primary (that lost bprops history)
+	}
+	for i, test := range tests {
+		if ok := h.set(test.hist); ok != test.ok {
+			t.Fatalf("%d: expected %t, got %t", i, test.ok, ok)
+		}
+		if cfgVer, bpVer := h._version(); test.ok {
+			if xcfg, xbp := test.hist._version(); cfgVer != xcfg || bpVer != xbp {
+				t.Fatalf("%d: expected (%d, %d), got (%d, %d)", i, xcfg, xbp, cfgVer, bpVer)
+			}
+		}
+	}
+}
+
+func TestReqWho(t *testing.T) {
+	p := &proxy{}
+	p.owner.smap = newSmapOwner(cmn.GCO.Get())
+	p.si = newSnode("p1", apc.Proxy, meta.NetInfo{Hostname: "10.0.0.1"}, meta.NetInfo{}, meta.NetInfo{})
+	other := newSnode("p2", apc.Proxy, meta.NetInfo{Hostname: "10.0.0.2"}, meta.NetInfo{}, meta.NetInfo{})
+	smap := newSmap()
+	smap.addProxy(p.si)
+	smap.addProxy(other)
+	smap.Primary = p.si
+	p.owner.smap.put(smap)
+
+	tests := []struct {
+		remote string
+		fwd    []string
+		who    string
+	}{
+		{remote: "1.2.3.4:5555", who: "1.2.3.4:5555"},
+		{remote: "1.2.3.4:5555", fwd: []string{"admin"}, who: "1.2.3.4:5555"},        // forged
+		{remote: "10.0.0.1:5555", fwd: []string{"admin"}, who: "10.0.0.1:5555"},      // self
+		{remote: "10.0.0.2:5555", fwd: []string{"admin, 5.6.7.8"}, who: "5.6.7.8"},   // forwarded by p2
+		{remote: "10.0.0.2:5555", fwd: []string{"admin", "5.6.7.8"}, who: "5.6.7.8"}, // ditto
+		{remote: "10.0.0.2:5555", who: "10.0.0.2:5555"},                              // p2 itself
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPut, "/v1/cluster", http.NoBody)
+		r.RemoteAddr = test.remote
+		for _, v := range test.fwd {
+			r.Header.Add(cos.HdrForwardedFor, v)
+		}
+		if who := p.reqWho(r); who != test.who {
+			t.Errorf("%s %v: expected %q, got %q", test.remote, test.fwd, test.who, who)
+		}
+	}
+}
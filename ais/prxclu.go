@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/reb"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 	jsoniter "github.com/json-iterator/go"
@@ -113,6 +114,17 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		c := config.ClusterConfig
 		c.Auth.Secret = "**********"
 		p.writeJSON(w, r, &c, what)
+	case apc.WhatConfigHistory:
+		p.qcluCfgHistory(w, r, what)
+	case apc.WhatConfigDiff:
+		p.qcluCfgDiff(w, r, what, query)
+	case apc.WhatDrainStatus:
+		p.qcluDrain(w, r, what, query)
+	case apc.WhatJobQueue:
+		if p.forwardCP(w, r, nil, what) {
+			return
+		}
+		p.writeJSON(w, r, p.jobq.get(), what)
 	case apc.WhatBMD, apc.WhatSmapVote, apc.WhatSnode, apc.WhatSmap:
 		p.htrun.httpdaeget(w, r, query, nil /*htext*/)
 	default:
@@ -999,6 +1011,8 @@ func (p *proxy) cluputActMsg(w http.ResponseWriter, r *http.Request) {
 		}
 	case apc.ActResetConfig:
 		p.resetCluCfgPersistent(w, r, msg)
+	case apc.ActRollbackConfig:
+		p.rollbackCluCfg(w, r, msg)
 	case apc.ActRotateLogs:
 		p.rotateLogs(w, r, msg)
 
@@ -1056,6 +1070,7 @@ func (p *proxy) setCluCfgPersistent(w http.ResponseWriter, r *http.Request, toUp
 		final:    p._syncConfFinal,
 		msg:      msg,
 		toUpdate: toUpdate,
+		who:      p.reqWho(r),
 		wait:     true,
 	}
 	// NOTE: critical cluster-wide config updates requiring restart (of the cluster)
@@ -1167,6 +1182,7 @@ func _setConfPre(ctx *configModifier, clone *globalConfig) (updated bool, err er
 }
 
 func (p *proxy) _syncConfFinal(ctx *configModifier, clone *globalConfig) {
+	p.cfgh.addConfig(ctx, clone)
 	wg := p.metasyncer.sync(revsPair{clone, p.newAmsg(ctx.msg, nil)})
 	if ctx.wait {
 		wg.Wait()
@@ -1188,7 +1204,15 @@ func (p *proxy) xstart(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 		return
 	}
 
-	args := allocBcArgs()
+	if err := p.xstartFenced(&xargs); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+
+	var (
+		who  = p.reqWho(r)
+		args = allocBcArgs()
+	)
 	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S}
 
 	switch {
@@ -1202,7 +1226,7 @@ func (p *proxy) xstart(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 			return
 		}
 		args._selected(tsi)
-		args.req.Body = cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs, Name: msg.Name})
+		args.req.Body = cos.MustMarshal(aisMsg{ActMsg: apc.ActMsg{Action: msg.Action, Value: xargs, Name: msg.Name}, User: who})
 	case xargs.Kind == apc.ActResilver && xargs.DaemonID != "":
 		args.smap = p.owner.smap.get()
 		tsi := args.smap.GetTarget(xargs.DaemonID)
@@ -1212,12 +1236,12 @@ func (p *proxy) xstart(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 			return
 		}
 		args._selected(tsi)
-		args.req.Body = cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs})
+		args.req.Body = cos.MustMarshal(aisMsg{ActMsg: apc.ActMsg{Action: msg.Action, Value: xargs}, User: who})
 	default:
 		// all targets, one common UUID for all
 		args.to = core.Targets
 		xargs.ID = cos.GenUUID()
-		args.req.Body = cos.MustMarshal(apc.ActMsg{Action: msg.Action, Value: xargs})
+		args.req.Body = cos.MustMarshal(aisMsg{ActMsg: apc.ActMsg{Action: msg.Action, Value: xargs}, User: who})
 	}
 
 	results := p.bcastGroup(args)
@@ -1248,6 +1272,50 @@ func (p *proxy) xstart(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg)
 	}
 }
 
+// read-only cluster or bucket: reject xactions that write or delete
+// (rebalance and resilver are still permitted - they only move data around)
+func (p *proxy) xstartFenced(xargs *xact.ArgsMsg) error {
+	dtor, ok := xact.Table[xargs.Kind]
+	if !ok {
+		return nil
+	}
+	if dtor.Access&apc.AccessMut == 0 && xargs.Kind != apc.ActLRU && xargs.Kind != apc.ActStoreCleanup {
+		return nil
+	}
+	action := "starting " + xargs.Kind
+	if cmn.Rom.ReadOnly() {
+		return cmn.NewErrReadOnly("cluster", action)
+	}
+	bmd := p.owner.bmd.get()
+	fenced := func(b *cmn.Bck) bool {
+		if b.IsEmpty() {
+			return false
+		}
+		props, present := bmd.Get(meta.CloneBck(b))
+		return present && props.ReadOnly
+	}
+	if fenced(&xargs.Bck) {
+		return cmn.NewErrReadOnly("bucket "+xargs.Bck.Cname(""), action)
+	}
+	for i := range xargs.Buckets {
+		if fenced(&xargs.Buckets[i]) {
+			return cmn.NewErrReadOnly("bucket "+xargs.Buckets[i].Cname(""), action)
+		}
+	}
+	if !xargs.Bck.IsEmpty() || len(xargs.Buckets) > 0 {
+		return nil
+	}
+	// all buckets
+	var err error
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if bck.Props.ReadOnly {
+			err = cmn.NewErrReadOnly("bucket "+bck.Cname(""), action)
+		}
+		return err != nil
+	})
+	return err
+}
+
 func (a *bcastArgs) _selected(tsi *meta.Snode) {
 	nmap := make(meta.NodeMap, 1)
 	nmap[tsi.ID()] = tsi
@@ -1278,6 +1346,16 @@ func (p *proxy) xstop(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg) {
 	}
 	xargs.Kind, _ = xact.GetKindName(xargs.Kind) // display name => kind
 
+	// queued job: cancel or, if already started, abort the resulting xaction
+	if xargs.ID != "" {
+		if found, xid := p.jobq.abort(xargs.ID); found {
+			if xid == "" {
+				return
+			}
+			xargs.ID = xid
+		}
+	}
+
 	// (lso + tco) special
 	p.lstca.abort(&xargs)
 
@@ -1634,6 +1712,47 @@ func (p *proxy) stopMaintenance(w http.ResponseWriter, r *http.Request, msg *apc
 	}
 }
 
+// GET /v1/cluster?what=drain_status&node-id=<target ID>
+// - the target reports its progress (see reb/drain.go);
+// - proxy then determines whether it is safe to power off the node
+func (p *proxy) qcluDrain(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	var (
+		tid  = query.Get(apc.QparamNodeID)
+		smap = p.owner.smap.get()
+		tsi  = smap.GetTarget(tid)
+	)
+	if tsi == nil {
+		// (includes decommissioned targets - no longer in the cluster map)
+		p.writeErr(w, r, cos.NewErrNotFound(p, "target "+tid), http.StatusNotFound)
+		return
+	}
+	if !smap.InMaintOrDecomm(tsi) {
+		p.writeErrf(w, r, "%s is not being drained (not in maintenance and not being decommissioned)", tsi.StringEx())
+		return
+	}
+	query = url.Values{apc.QparamRebStatus: []string{"true"}}
+	body, ecode, err := p.reqHealth(tsi, cmn.Rom.MaxKeepalive(), query, smap)
+	if err != nil {
+		p.writeErr(w, r, err, ecode)
+		return
+	}
+	status := &reb.Status{}
+	if err := jsoniter.Unmarshal(body, status); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrUnmarshal, p, what, cos.BHead(body), err)
+		return
+	}
+	var (
+		ds    = status.Drain
+		rebID = xact.RebID2S(p.owner.rmd.get().Version)
+	)
+	if ds == nil || (ds.RebID != rebID && !tsi.Flags.IsSet(meta.SnodeMaintPostReb)) {
+		// not started yet
+		ds = &cmn.DrainStatus{DaemonID: tid, RebID: rebID, Stage: cmn.DrainCounting}
+	}
+	ds.Safe = ds.Stage == cmn.DrainDone && ds.Unverified == 0 && tsi.Flags.IsSet(meta.SnodeMaintPostReb)
+	p.writeJSON(w, r, ds, what)
+}
+
 func (p *proxy) cluputItems(w http.ResponseWriter, r *http.Request, items []string) {
 	action := items[0]
 	if p.forwardCP(w, r, &apc.ActMsg{Action: action}, "") {
@@ -1751,6 +1870,7 @@ func (p *proxy) attachDetachRemAis(w http.ResponseWriter, r *http.Request, actio
 		msg:   &apc.ActMsg{Action: action},
 		query: query,
 		hdr:   r.Header,
+		who:   p.reqWho(r),
 		wait:  true,
 	}
 	newConfig, err := p.owner.config.modify(ctx)
@@ -0,0 +1,75 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// scheduled generation of ais:// bucket inventories
+// (bucket props: inventory.enabled, inventory.interval; see also xact/xs/inventory.go)
+// - primary only;
+// - the first run takes place upon the first housekeeping tick after the bucket
+//   gets inventory-enabled (or after the primary (re)starts)
+
+const invSchedIval = time.Minute
+
+type invSched struct {
+	p    *proxy
+	last map[uint64]int64 // BID => mono-time of the last scheduled run
+}
+
+func (s *invSched) init(p *proxy) {
+	s.p = p
+	s.last = make(map[uint64]int64, 4)
+	hk.Reg("inventory"+hk.NameSuffix, s.housekeep, invSchedIval)
+}
+
+func (s *invSched) housekeep() time.Duration {
+	smap := s.p.owner.smap.get()
+	if !smap.IsPrimary(s.p.si) || !s.p.ClusterStarted() || cmn.Rom.ReadOnly() {
+		return invSchedIval
+	}
+	var (
+		bmd  = s.p.owner.bmd.get()
+		now  = mono.NanoTime()
+		bids = make(map[uint64]struct{}, len(s.last))
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		inv := &bck.Props.Inventory
+		if !bck.IsAIS() || !inv.Enabled || bck.Props.ReadOnly {
+			return false
+		}
+		bid := bck.Props.BID
+		bids[bid] = struct{}{}
+		if last, ok := s.last[bid]; ok && time.Duration(now-last) < inv.Interval.D() {
+			return false
+		}
+		s.last[bid] = now
+		msg := &apc.ActMsg{Action: apc.ActCreateInventory, Value: &cmn.InvMsg{Prefix: inv.Prefix, Format: inv.Format}}
+		xid, err := s.p.listrange(http.MethodPost, bck.Name, msg, bck.NewQuery(), "" /*scheduled*/)
+		if err != nil {
+			nlog.Errorln(s.p.String(), "failed to start scheduled", apc.ActCreateInventory, bck.Cname(""), "err:", err)
+		} else {
+			nlog.Infoln(s.p.String(), "scheduled", apc.ActCreateInventory+"["+xid+"]", bck.Cname(""))
+		}
+		return false
+	})
+	// forget buckets that are gone or no longer inventory-enabled
+	for bid := range s.last {
+		if _, ok := bids[bid]; !ok {
+			delete(s.last, bid)
+		}
+	}
+	return invSchedIval
+}
@@ -0,0 +1,440 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xact"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// job admission queue (apc.QparamQueue):
+// - a job (bucket or cluster request) that conflicts with a running xaction - that is, fails with
+//   cmn.ErrLimitedCoexistence - gets accepted in the "queued" state (http.StatusAccepted + queued job ID);
+// - the primary periodically replays queued requests in the order of priority (and submission);
+//   a job that still conflicts remains queued without holding up the others;
+// - the queue is persisted and replicated to all proxies, to survive primary change;
+// - credentials are neither persisted nor replicated: the primary that accepted the job keeps
+//   the submitter's token in memory (to re-check access upon replay); an authenticated job that
+//   outlives its token (primary restart or change) fails and must be resubmitted;
+// - cancel via apc.ActXactStop (job ID); show via apc.WhatJobQueue
+
+const (
+	jobqIval    = 10 * time.Second
+	jobqMaxDone = 64 // finished (started, failed, aborted) entries to retain
+)
+
+type (
+	// queued job, including the original request to replay
+	jobqEntry struct {
+		cmn.QueuedJob
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		Query  string `json:"query"` // less apc.QparamQueue
+		Body   []byte `json:"body,omitempty"`
+		Authn  bool   `json:"authn,omitempty"` // submitted with credentials (see `auth`)
+		auth   string // original apc.HdrAuthorization (in memory only)
+	}
+	jobq struct {
+		p       *proxy
+		fpath   string
+		jobs    []*jobqEntry
+		mu      sync.Mutex
+		running atomic.Bool
+	}
+	// buffers handler's response to find out whether it's a conflict
+	jobqWriter struct {
+		hdr    http.Header
+		buf    bytes.Buffer
+		status int
+	}
+)
+
+func (q *jobq) init(p *proxy, config *cmn.Config) {
+	q.p = p
+	q.fpath = filepath.Join(config.ConfigDir, fname.JobQueue)
+	if _, err := jsp.Load(q.fpath, &q.jobs, jsp.Plain()); err != nil && !os.IsNotExist(err) {
+		nlog.Errorln("failed to load job queue:", err)
+		q.jobs = nil
+	}
+	hk.Reg("job-queue"+hk.NameSuffix, q.housekeep, jobqIval)
+}
+
+// wraps (bucket and cluster) handlers to support apc.QparamQueue
+func (q *jobq) wrap(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method == http.MethodPost || r.Method == http.MethodPut) && r.URL.Query().Has(apc.QparamQueue) {
+			q.submit(w, r, h)
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (q *jobq) submit(w http.ResponseWriter, r *http.Request, h http.HandlerFunc) {
+	var (
+		p     = q.p
+		query = r.URL.Query()
+		sprio = query.Get(apc.QparamQueue)
+	)
+	prio, err := strconv.Atoi(sprio)
+	if err != nil {
+		p.writeErrf(w, r, "invalid %s=%q (expecting integer priority)", apc.QparamQueue, sprio)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if p.forwardCP(w, r, nil, "queue job", body) {
+		return
+	}
+
+	// primary: try right away
+	query.Del(apc.QparamQueue)
+	r.URL.RawQuery = query.Encode()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	rw := newJobqWriter()
+	h(rw, r)
+	herr := rw.err()
+	if herr == nil || !cmn.IsErrLimitedCoexistence(herr) {
+		rw.flush(w)
+		return
+	}
+
+	e := &jobqEntry{
+		QueuedJob: cmn.QueuedJob{
+			ID:      cos.GenUUID(),
+			Who:     p.reqWho(r),
+			State:   cmn.JobQueued,
+			Blocker: herr.Message,
+			Prio:    prio,
+			Time:    time.Now().UnixNano(),
+		},
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Body:   body,
+		auth:   r.Header.Get(apc.HdrAuthorization),
+	}
+	e.Authn = e.auth != ""
+	e.describe(query)
+	nlog.Infoln(p.String(), "queued", e.Action, e.Bck, "job", e.ID, "prio", prio, "[", herr.Message, "]")
+
+	q.mu.Lock()
+	q.jobs = append(q.jobs, e)
+	q._persist()
+	jobs := q._clone()
+	q.mu.Unlock()
+	q.bcast(jobs)
+
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(cos.UnsafeB(e.ID))
+}
+
+func (q *jobq) housekeep() time.Duration {
+	smap := q.p.owner.smap.get()
+	if !smap.IsPrimary(q.p.si) || !q.p.ClusterStarted() {
+		return jobqIval
+	}
+	if len(q.queued()) > 0 && q.running.CAS(false, true) {
+		go q.dispatch()
+	}
+	return jobqIval
+}
+
+// queued only, in the order of priority and then submission
+func (q *jobq) queued() (jobs []*jobqEntry) {
+	q.mu.Lock()
+	for _, e := range q.jobs {
+		if !e.Finished() {
+			jobs = append(jobs, e)
+		}
+	}
+	q.mu.Unlock()
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].Prio > jobs[j].Prio })
+	return jobs
+}
+
+func (q *jobq) dispatch() {
+	var changed bool
+	for _, e := range q.queued() {
+		if err := q.p.pready(nil, true); err != nil {
+			break
+		}
+		xid, herr := q.replay(e)
+		q.mu.Lock()
+		stop := q._update(e, xid, herr)
+		changed = changed || e.Finished()
+		q.mu.Unlock()
+		if stop {
+			q.stop(e, xid)
+		}
+	}
+	if changed {
+		q.mu.Lock()
+		q._trim()
+		q._persist()
+		jobs := q._clone()
+		q.mu.Unlock()
+		q.bcast(jobs)
+	}
+	q.running.Store(false)
+}
+
+// under lock: update the entry with the replay result;
+// returns true when the job got aborted while being replayed - in which case
+// the resulting xaction (that the abort did not know about) must be stopped
+func (q *jobq) _update(e *jobqEntry, xid string, herr *cmn.ErrHTTP) (stop bool) {
+	defer func() {
+		if e.Finished() {
+			e.auth = ""
+		}
+	}()
+	switch {
+	case e.Finished():
+		nlog.Warningln(q.p.String(), "queued", e.Action, e.Bck, "job", e.ID, "is", e.State, "- xid", xid)
+		if herr == nil && xid != "" && e.Xid == "" {
+			e.Xid, stop = xid, true
+		}
+	case herr == nil:
+		e.State, e.Xid, e.Blocker = cmn.JobStarted, xid, ""
+		nlog.Infoln(q.p.String(), "started queued", e.Action, e.Bck, "job", e.ID, "xid", xid)
+	case cmn.IsErrLimitedCoexistence(herr):
+		e.Blocker = herr.Message // still conflicting
+	default:
+		e.State, e.Err, e.Blocker = cmn.JobFailed, herr.Error(), ""
+		nlog.Errorln(q.p.String(), "failed to start queued", e.Action, e.Bck, "job", e.ID, "err:", herr)
+	}
+	return stop
+}
+
+// execute the original request (in-process) on behalf of the original user - not as
+// an intra-cluster call - to perform access (ACL) and read-only (fenced) checks all over
+// again, given that both may have changed while the job was waiting
+// (an expired or revoked token fails the job, and so does a token that is no longer available)
+func (q *jobq) replay(e *jobqEntry) (string, *cmn.ErrHTTP) {
+	p := q.p
+	if e.Authn && e.auth == "" {
+		err := errors.New("credentials are no longer available (primary restarted or changed) - please resubmit")
+		return "", cmn.NewErrHTTP(nil, err, http.StatusUnauthorized)
+	}
+	r, err := http.NewRequest(e.Method, e.Path+"?"+e.Query, bytes.NewReader(e.Body))
+	if err != nil {
+		return "", cmn.NewErrHTTP(nil, err, 0)
+	}
+	r.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	if e.auth != "" {
+		r.Header.Set(apc.HdrAuthorization, e.auth)
+	}
+	r.RemoteAddr = e.Who // (see reqWho)
+
+	rw := newJobqWriter()
+	if strings.HasPrefix(e.Path, apc.URLPathBuckets.S) {
+		p.bucketHandler(rw, r)
+	} else {
+		p.clusterHandler(rw, r)
+	}
+	if herr := rw.err(); herr != nil {
+		return "", herr
+	}
+	return strings.TrimSpace(rw.buf.String()), nil
+}
+
+// stop the xaction started by a job that was aborted while being replayed
+func (q *jobq) stop(e *jobqEntry, xid string) {
+	p := q.p
+	nlog.Infoln(p.String(), "aborting", e.Action, e.Bck, "job", e.ID, "xid", xid)
+	msg := apc.ActMsg{Action: apc.ActXactStop, Value: xact.ArgsMsg{ID: xid}}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathXactions.S, Body: cos.MustMarshal(msg)}
+	args.to = core.Targets
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			nlog.Errorln(p.String(), "failed to abort", xid, "at", res.si.StringEx(), "err:", res.err)
+		}
+	}
+	freeBcastRes(results)
+}
+
+// cancel a queued job; returns the started xaction ID, if any
+func (q *jobq) abort(id string) (found bool, xid string) {
+	q.mu.Lock()
+	for _, e := range q.jobs {
+		if e.ID != id {
+			continue
+		}
+		found, xid = true, e.Xid
+		if e.Finished() {
+			q.mu.Unlock()
+			return
+		}
+		e.State, e.Blocker, e.auth = cmn.JobAborted, "", ""
+		q._persist()
+		jobs := q._clone()
+		q.mu.Unlock()
+		nlog.Infoln(q.p.String(), "aborted queued", e.Action, e.Bck, "job", e.ID)
+		q.bcast(jobs)
+		return
+	}
+	q.mu.Unlock()
+	return
+}
+
+func (q *jobq) get() []cmn.QueuedJob {
+	q.mu.Lock()
+	jobs := make([]cmn.QueuedJob, 0, len(q.jobs))
+	for _, e := range q.jobs {
+		jobs = append(jobs, e.QueuedJob)
+	}
+	q.mu.Unlock()
+	return jobs
+}
+
+// non-primary: replace the local copy
+func (q *jobq) set(jobs []*jobqEntry) {
+	q.mu.Lock()
+	q.jobs = jobs
+	q._persist()
+	q.mu.Unlock()
+}
+
+// primary => all other proxies
+func (q *jobq) bcast(jobs []*jobqEntry) {
+	p := q.p
+	msg := p.newAmsgActVal(apc.ActSyncJobQueue, jobs)
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPut, Path: apc.URLPathDae.S, Body: cos.MustMarshal(msg)}
+	args.to = core.Proxies
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			nlog.Errorln(p.String(), "failed to sync job queue with", res.si.StringEx(), "err:", res.err)
+		}
+	}
+	freeBcastRes(results)
+}
+
+// under lock: retain the most recent finished entries
+func (q *jobq) _trim() {
+	var n int
+	for i := len(q.jobs) - 1; i >= 0; i-- {
+		if q.jobs[i].Finished() {
+			n++
+		}
+		if n > jobqMaxDone {
+			q.jobs = append(q.jobs[:i:i], q.jobs[i+1:]...)
+			n--
+		}
+	}
+}
+
+// under lock
+func (q *jobq) _persist() {
+	if err := jsp.Save(q.fpath, q.jobs, jsp.Plain(), nil); err != nil {
+		nlog.Errorln("failed to persist job queue:", err)
+	}
+}
+
+// under lock
+func (q *jobq) _clone() []*jobqEntry {
+	jobs := make([]*jobqEntry, len(q.jobs))
+	for i, e := range q.jobs {
+		c := *e
+		jobs[i] = &c
+	}
+	return jobs
+}
+
+// (for display purposes)
+func (e *jobqEntry) describe(query url.Values) {
+	var msg apc.ActMsg
+	if err := jsoniter.Unmarshal(e.Body, &msg); err == nil {
+		e.Action = msg.Action
+	}
+	if e.Action == apc.ActXactStart {
+		xargs := xact.ArgsMsg{}
+		if err := cos.MorphMarshal(msg.Value, &xargs); err == nil {
+			e.Action = xargs.Kind
+			if !xargs.Bck.IsEmpty() {
+				e.Bck = xargs.Bck.Cname("")
+			}
+		}
+		return
+	}
+	if name := strings.TrimPrefix(e.Path, apc.URLPathBuckets.S+"/"); name != e.Path && name != "" {
+		bck := cmn.Bck{Name: name, Provider: apc.NormalizeProvider(query.Get(apc.QparamProvider))}
+		e.Bck = bck.Cname("")
+	}
+}
+
+////////////////
+// jobqWriter //
+////////////////
+
+func newJobqWriter() *jobqWriter { return &jobqWriter{hdr: make(http.Header, 4)} }
+
+func (rw *jobqWriter) Header() http.Header { return rw.hdr }
+
+func (rw *jobqWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+}
+
+func (rw *jobqWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	return rw.buf.Write(b)
+}
+
+func (rw *jobqWriter) err() *cmn.ErrHTTP {
+	if rw.status < http.StatusBadRequest {
+		return nil
+	}
+	herr := &cmn.ErrHTTP{}
+	if err := jsoniter.Unmarshal(rw.buf.Bytes(), herr); err != nil || herr.Message == "" {
+		herr.Message = rw.buf.String()
+	}
+	herr.Status = rw.status
+	return herr
+}
+
+func (rw *jobqWriter) flush(w http.ResponseWriter) {
+	hdr := w.Header()
+	for k, v := range rw.hdr {
+		hdr[k] = v
+	}
+	if rw.status != 0 {
+		w.WriteHeader(rw.status)
+	}
+	w.Write(rw.buf.Bytes())
+}
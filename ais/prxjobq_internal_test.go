@@ -0,0 +1,149 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestJobqOrder(t *testing.T) {
+	q := &jobq{}
+	for i, prio := range []int{1, 5, 1, 5, 3} {
+		e := &jobqEntry{QueuedJob: cmn.QueuedJob{ID: strconv.Itoa(i), Prio: prio, State: cmn.JobQueued}}
+		q.jobs = append(q.jobs, e)
+	}
+	q.jobs[2].State = cmn.JobAborted
+
+	var ids string
+	for _, e := range q.queued() {
+		ids += e.ID
+	}
+	if ids != "1340" {
+		t.Fatalf("expected priority (and then submission) order %q, got %q", "1340", ids)
+	}
+}
+
+func TestJobqTrim(t *testing.T) {
+	q := &jobq{}
+	for i := range jobqMaxDone + 10 {
+		state := cmn.JobStarted
+		if i%10 == 0 {
+			state = cmn.JobQueued
+		}
+		q.jobs = append(q.jobs, &jobqEntry{QueuedJob: cmn.QueuedJob{ID: strconv.Itoa(i), State: state}})
+	}
+	q._trim()
+
+	var queued, done int
+	for _, e := range q.jobs {
+		if e.Finished() {
+			done++
+		} else {
+			queued++
+		}
+	}
+	if queued != (jobqMaxDone+10+9)/10 || done != jobqMaxDone {
+		t.Fatalf("expected all queued and %d finished, got %d queued and %d finished", jobqMaxDone, queued, done)
+	}
+	if last := q.jobs[len(q.jobs)-1].ID; last != strconv.Itoa(jobqMaxDone+9) {
+		t.Fatalf("expected the most recent entries to be retained, got %q", last)
+	}
+}
+
+func TestJobqWriterConflict(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/v1/buckets/abc", http.NoBody)
+
+	rw := newJobqWriter()
+	cmn.WriteErr(rw, r, cmn.NewErrLimitedCoexistence("t[abc]", "rebalance[g1]", "copy-bck", "ais://abc"))
+	herr := rw.err()
+	if herr == nil || !cmn.IsErrLimitedCoexistence(herr) {
+		t.Fatalf("expected limited-coexistence conflict, got %v", herr)
+	}
+
+	rw = newJobqWriter()
+	cmn.WriteErr(rw, r, errors.New("bucket does not exist"), http.StatusNotFound)
+	if herr := rw.err(); herr == nil || cmn.IsErrLimitedCoexistence(herr) || herr.Status != http.StatusNotFound {
+		t.Fatalf("expected (non-conflict) 404, got %v", herr)
+	}
+
+	rw = newJobqWriter()
+	rw.Write([]byte("xid"))
+	if herr := rw.err(); herr != nil {
+		t.Fatalf("expected success, got %v", herr)
+	}
+}
+
+func TestJobqUpdate(t *testing.T) {
+	p := &proxy{}
+	p.si = &meta.Snode{}
+	q := &jobq{p: p}
+
+	// started
+	e := &jobqEntry{QueuedJob: cmn.QueuedJob{ID: "1", State: cmn.JobQueued}}
+	if stop := q._update(e, "xid1", nil); stop || e.State != cmn.JobStarted || e.Xid != "xid1" {
+		t.Fatalf("expected started (xid1), got %s (%q), stop=%t", e.State, e.Xid, stop)
+	}
+
+	// still conflicting
+	e = &jobqEntry{QueuedJob: cmn.QueuedJob{ID: "2", State: cmn.JobQueued}}
+	herr := cmn.NewErrHTTP(nil, cmn.NewErrLimitedCoexistence("t[abc]", "rebalance[g1]", "copy-bck", "ais://abc"), 0)
+	if stop := q._update(e, "", herr); stop || e.Finished() || e.Blocker == "" {
+		t.Fatalf("expected queued (blocked), got %s, stop=%t", e.State, stop)
+	}
+
+	// aborted while being replayed: record the xid and stop the xaction
+	e = &jobqEntry{QueuedJob: cmn.QueuedJob{ID: "3", State: cmn.JobAborted}}
+	if stop := q._update(e, "xid3", nil); !stop || e.State != cmn.JobAborted || e.Xid != "xid3" {
+		t.Fatalf("expected aborted (xid3) to stop, got %s (%q), stop=%t", e.State, e.Xid, stop)
+	}
+
+	// aborted while being replayed that failed to start: nothing to stop
+	e = &jobqEntry{QueuedJob: cmn.QueuedJob{ID: "4", State: cmn.JobAborted}}
+	if stop := q._update(e, "", herr); stop || e.Xid != "" {
+		t.Fatalf("expected aborted with nothing to stop, got %q, stop=%t", e.Xid, stop)
+	}
+}
+
+func TestJobqCredentials(t *testing.T) {
+	p := &proxy{}
+	p.si = &meta.Snode{}
+	q := &jobq{p: p}
+
+	// never persisted or replicated
+	e := &jobqEntry{QueuedJob: cmn.QueuedJob{ID: "1", State: cmn.JobQueued}, Authn: true, auth: "Bearer secret-token"}
+	if b := cos.MustMarshal([]*jobqEntry{e}); strings.Contains(string(b), "secret-token") {
+		t.Fatalf("credentials must not be serialized: %s", b)
+	}
+
+	// upon primary restart (or change): fail
+	var jobs []*jobqEntry
+	if err := jsoniter.Unmarshal(cos.MustMarshal([]*jobqEntry{e}), &jobs); err != nil {
+		t.Fatal(err)
+	}
+	e = jobs[0]
+	_, herr := q.replay(e)
+	if herr == nil || herr.Status != http.StatusUnauthorized {
+		t.Fatalf("expected %d, got %v", http.StatusUnauthorized, herr)
+	}
+	if q._update(e, "", herr); e.State != cmn.JobFailed {
+		t.Fatalf("expected failed, got %s", e.State)
+	}
+
+	// finished: forget
+	e = &jobqEntry{QueuedJob: cmn.QueuedJob{ID: "2", State: cmn.JobQueued}, Authn: true, auth: "Bearer secret-token"}
+	if q._update(e, "xid2", nil); e.State != cmn.JobStarted || e.auth != "" {
+		t.Fatalf("expected started with no credentials retained, got %s (%q)", e.State, e.auth)
+	}
+}
@@ -26,10 +26,12 @@ import (
 
 type nopHB struct{}
 
-func (*nopHB) HeardFrom(string, int64) {}
-func (*nopHB) TimedOut(string) bool    { return false }
-func (*nopHB) reg(string)              {}
-func (*nopHB) set(time.Duration) bool  { return false }
+func (*nopHB) HeardFrom(string, int64)            {}
+func (*nopHB) TimedOut(string) bool               { return false }
+func (*nopHB) reg(string)                         {}
+func (*nopHB) set(*cmn.KeepaliveTrackerConf) bool { return false }
+func (*nopHB) suspect(string) bool                { return true }
+func (*nopHB) phis() map[string]float64           { return nil }
 
 var _ hbTracker = (*nopHB)(nil)
 
@@ -268,7 +268,7 @@ func (p *proxy) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket s
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	if _, err := p.listrange(http.MethodDelete, bucket, &msg2, query); err != nil {
+	if _, err := p.listrange(http.MethodDelete, bucket, &msg2, query, p.reqWho(r)); err != nil {
 		s3.WriteErr(w, r, err, 0)
 	}
 	// TODO: The client wants the response containing two lists:
@@ -720,7 +720,7 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+	if _, err := p.setBprops(msg, bck, nprops, p.reqWho(r)); err != nil {
 		s3.WriteErr(w, r, err, 0)
 	}
 }
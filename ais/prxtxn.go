@@ -49,7 +49,7 @@ type txnCln struct {
 // txnCln //
 ////////////
 
-func (c *txnCln) init(msg *apc.ActMsg, bck *meta.Bck, config *cmn.Config, waitmsync bool) *txnCln {
+func (c *txnCln) init(msg *apc.ActMsg, bck *meta.Bck, config *cmn.Config, waitmsync bool, who string) *txnCln {
 	query := make(url.Values, 3)
 	if bck == nil {
 		c.path = apc.URLPathTxn.S
@@ -65,6 +65,7 @@ func (c *txnCln) init(msg *apc.ActMsg, bck *meta.Bck, config *cmn.Config, waitms
 	query.Set(apc.QparamHostTimeout, cos.UnixNano2S(int64(c.timeout.host)))
 
 	c.msg = c.p.newAmsg(msg, nil, c.uuid)
+	c.msg.User = who
 	body := cos.MustMarshal(c.msg)
 	c.req = cmn.HreqArgs{Method: http.MethodPost, Query: query, Body: body}
 	return c
@@ -239,7 +240,7 @@ func (p *proxy) _createBucketWithProps(msg *apc.ActMsg, bck *meta.Bck, bprops *c
 	// 2. begin
 	var (
 		waitmsync = true // commit blocks behind metasync
-		c         = p.prepTxnClient(msg, bck, waitmsync)
+		c         = p.prepTxnClient(msg, bck, waitmsync, "")
 	)
 	if err := c.begin(bck); err != nil {
 		return err
@@ -288,7 +289,7 @@ func bmodRm(ctx *bmdModifier, clone *bucketMD) error {
 }
 
 // make-n-copies: { confirm existence -- begin -- update locally -- metasync -- commit }
-func (p *proxy) makeNCopies(msg *apc.ActMsg, bck *meta.Bck) (xid string, err error) {
+func (p *proxy) makeNCopies(msg *apc.ActMsg, bck *meta.Bck, who string) (xid string, err error) {
 	copies, err := _parseNCopies(msg.Value)
 	if err != nil {
 		return
@@ -304,7 +305,7 @@ func (p *proxy) makeNCopies(msg *apc.ActMsg, bck *meta.Bck) (xid string, err err
 	// 2. begin
 	var (
 		waitmsync = true
-		c         = p.prepTxnClient(msg, bck, waitmsync)
+		c         = p.prepTxnClient(msg, bck, waitmsync, who)
 	)
 	if err = c.begin(bck); err != nil {
 		return
@@ -368,7 +369,7 @@ func bmodMirror(ctx *bmdModifier, clone *bucketMD) error {
 }
 
 // set-bucket-props: { confirm existence -- begin -- apply props -- metasync -- commit }
-func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops) (string /*xid*/, error) {
+func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops, who string) (string /*xid*/, error) {
 	// 1. confirm existence
 	bprops, present := p.owner.bmd.get().Get(bck)
 	if !present {
@@ -403,7 +404,7 @@ func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops) (s
 	nmsg.Value = nprops
 	var (
 		waitmsync = true
-		c         = p.prepTxnClient(&nmsg, bck, waitmsync)
+		c         = p.prepTxnClient(&nmsg, bck, waitmsync, who)
 	)
 	if err := c.begin(bck); err != nil {
 		return "", err
@@ -425,6 +426,7 @@ func (p *proxy) setBprops(msg *apc.ActMsg, bck *meta.Bck, nprops *cmn.Bprops) (s
 		return "", err
 	}
 	c.msg.BMDVersion = bmd.version()
+	p.cfgh.addBprops(bck, msg.Action, who, bprops, nprops, bmd.version())
 
 	// 4. if remirror|re-EC|TBD-storage-svc
 	// NOTE: setting up IC listening prior to committing (and confirming xid) here and elsewhere
@@ -465,7 +467,7 @@ func (p *proxy) bmodSetProps(ctx *bmdModifier, clone *bucketMD) (err error) {
 }
 
 // rename-bucket: { confirm existence -- begin -- RebID -- metasync -- commit -- wait for rebalance and unlock }
-func (p *proxy) renameBucket(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg) (xid string, err error) {
+func (p *proxy) renameBucket(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg, who string) (xid string, err error) {
 	if err = p.canRebalance(); err != nil {
 		err = cmn.NewErrFailedTo(p, "rename", bckFrom, err)
 		return
@@ -484,7 +486,7 @@ func (p *proxy) renameBucket(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg) (xid str
 	// 2. begin
 	var (
 		waitmsync = true
-		c         = p.prepTxnClient(msg, bckFrom, waitmsync)
+		c         = p.prepTxnClient(msg, bckFrom, waitmsync, who)
 	)
 	_ = bckTo.AddUnameToQuery(c.req.Query, apc.QparamBckTo)
 	if err = c.begin(bckFrom); err != nil {
@@ -572,7 +574,7 @@ func bmodMv(ctx *bmdModifier, clone *bucketMD) error {
 
 // transform (or simply copy) bucket to another bucket
 // { confirm existence -- begin -- conditional metasync -- start waiting for operation done -- commit }
-func (p *proxy) tcb(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg, dryRun bool) (xid string, err error) {
+func (p *proxy) tcb(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg, dryRun bool, who string) (xid string, err error) {
 	// 1. confirm existence
 	bmd := p.owner.bmd.get()
 	if _, existsFrom := bmd.Get(bckFrom); !existsFrom {
@@ -585,7 +587,7 @@ func (p *proxy) tcb(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg, dryRun bool) (xid
 	// 2. begin
 	var (
 		waitmsync = !dryRun && !existsTo
-		c         = p.prepTxnClient(msg, bckFrom, waitmsync)
+		c         = p.prepTxnClient(msg, bckFrom, waitmsync, who)
 	)
 	_ = bckTo.AddUnameToQuery(c.req.Query, apc.QparamBckTo)
 	if err = c.begin(bckFrom); err != nil {
@@ -639,7 +641,8 @@ func (p *proxy) tcb(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg, dryRun bool) (xid
 }
 
 // transform or copy a list or a range of objects
-func (p *proxy) tcobjs(bckFrom, bckTo *meta.Bck, config *cmn.Config, msg *apc.ActMsg, tcomsg *cmn.TCObjsMsg) (string, error) {
+func (p *proxy) tcobjs(bckFrom, bckTo *meta.Bck, config *cmn.Config, msg *apc.ActMsg, tcomsg *cmn.TCObjsMsg,
+	who string) (string, error) {
 	// 1. prep
 	var (
 		_, existsTo = p.owner.bmd.get().Get(bckTo) // cleanup on fail: destroy if created
@@ -653,7 +656,7 @@ func (p *proxy) tcobjs(bckFrom, bckTo *meta.Bck, config *cmn.Config, msg *apc.Ac
 	if c.uuid == "" {
 		c.uuid = cos.GenUUID()
 	}
-	c.init(msg, bckFrom, config, waitmsync)
+	c.init(msg, bckFrom, config, waitmsync, who)
 
 	_ = bckTo.AddUnameToQuery(c.req.Query, apc.QparamBckTo)
 
@@ -719,7 +722,7 @@ func parseECConf(value any) (*cmn.ECConfToSet, error) {
 }
 
 // ec-encode: { confirm existence -- begin -- update locally -- metasync -- commit }
-func (p *proxy) ecEncode(bck *meta.Bck, msg *apc.ActMsg) (xid string, err error) {
+func (p *proxy) ecEncode(bck *meta.Bck, msg *apc.ActMsg, who string) (xid string, err error) {
 	nlp := newBckNLP(bck)
 	confToSet, errV := parseECConf(msg.Value)
 	if errV != nil {
@@ -755,7 +758,7 @@ func (p *proxy) ecEncode(bck *meta.Bck, msg *apc.ActMsg) (xid string, err error)
 	// 2. begin
 	var (
 		waitmsync = true
-		c         = p.prepTxnClient(msg, bck, waitmsync)
+		c         = p.prepTxnClient(msg, bck, waitmsync, who)
 	)
 	if err = c.begin(bck); err != nil {
 		return
@@ -833,11 +836,11 @@ func bmodUpdateProps(ctx *bmdModifier, clone *bucketMD) error {
 
 // NOTE: returning a single global UUID or, in a concurrent batch-executing operation,
 // a comma-separated list
-func (p *proxy) createArchMultiObj(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg) (xid string, err error) {
+func (p *proxy) createArchMultiObj(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg, who string) (xid string, err error) {
 	var all []string // all xaction UUIDs
 
 	// begin
-	c := p.prepTxnClient(msg, bckFrom, false /*waitmsync*/)
+	c := p.prepTxnClient(msg, bckFrom, false /*waitmsync*/, who)
 	_ = bckTo.AddUnameToQuery(c.req.Query, apc.QparamBckTo)
 	if err = c.begin(bckFrom); err != nil {
 		return
@@ -852,7 +855,7 @@ func (p *proxy) createArchMultiObj(bckFrom, bckTo *meta.Bck, msg *apc.ActMsg) (x
 
 func (p *proxy) beginRmTarget(si *meta.Snode, msg *apc.ActMsg) error {
 	debug.Assert(si.IsTarget(), si.StringEx())
-	c := p.prepTxnClient(msg, nil, false /*waitmsync*/)
+	c := p.prepTxnClient(msg, nil, false /*waitmsync*/, "")
 	return c.begin(si)
 }
 
@@ -868,7 +871,7 @@ func (p *proxy) destroyBucket(msg *apc.ActMsg, bck *meta.Bck) error {
 	// 1. begin
 	var (
 		waitmsync = true
-		c         = p.prepTxnClient(actMsg, bck, waitmsync)
+		c         = p.prepTxnClient(actMsg, bck, waitmsync, "")
 		config    = cmn.GCO.Get()
 	)
 	// NOTE: testing only: to avoid premature aborts when loopback devices get 100% utilized
@@ -928,14 +931,14 @@ func (p *proxy) destroyBucketData(msg *apc.ActMsg, bck *meta.Bck) error {
 // promote synchronously if the number of files (to promote) is less or equal
 const promoteNumSync = 16
 
-func (p *proxy) promote(bck *meta.Bck, msg *apc.ActMsg, tsi *meta.Snode) (xid string, err error) {
+func (p *proxy) promote(bck *meta.Bck, msg *apc.ActMsg, tsi *meta.Snode, who string) (xid string, err error) {
 	var (
 		totalN           int64
 		waitmsync        bool
 		allAgree, noXact bool
 		singleT          bool
 	)
-	c := p.prepTxnClient(msg, bck, waitmsync)
+	c := p.prepTxnClient(msg, bck, waitmsync, who)
 	if c.smap.CountActiveTs() == 1 {
 		singleT = true
 	} else if tsi != nil {
@@ -1011,9 +1014,10 @@ func prmBegin(c *txnCln, bck *meta.Bck, singleT bool) (num int64, allAgree bool,
 // misc
 ///
 
-func (p *proxy) prepTxnClient(msg *apc.ActMsg, bck *meta.Bck, waitmsync bool) *txnCln {
+// `who` (see reqWho) is the user that originated the request - to be recorded in the job history
+func (p *proxy) prepTxnClient(msg *apc.ActMsg, bck *meta.Bck, waitmsync bool, who string) *txnCln {
 	c := &txnCln{p: p, uuid: cos.GenUUID(), smap: p.owner.smap.get()}
-	c.init(msg, bck, cmn.GCO.Get(), waitmsync)
+	c.init(msg, bck, cmn.GCO.Get(), waitmsync, who)
 	return c
 }
 
@@ -1133,6 +1137,9 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 		return
 	}
 	err = nprops.Validate(targetCnt)
+	if err == nil {
+		err = nprops.Replication.ValidateAsProps(&nprops.BackendBck, bck.Bucket()) // (not onto itself)
+	}
 	if cmn.IsErrSoft(err) && propsToUpdate.Force {
 		nlog.Warningln("Ignoring soft error:", err)
 		err = nil
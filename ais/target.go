@@ -71,6 +71,10 @@ type (
 		res          *res.Res
 		transactions transactions
 		regstate     regstate
+		ra           readahead
+		wb           writeback
+		repl         replicator
+		aidx         archIndexer
 	}
 )
 
@@ -313,9 +317,10 @@ func (t *target) Run() error {
 		nlog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object type, workfile type, and archive index
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.Reg(fs.ArchIdxType, &fs.ArchIdxContentResolver{})
 
 	// Init meta-owners and load local instances
 	if prev := t.owner.bmd.init(); prev {
@@ -359,6 +364,9 @@ func (t *target) Run() error {
 	regDiskMetrics(t.si, tstats, disabled)
 
 	tstats.RegMetrics(t.si)
+	tstats.RegGauge(t.si, stats.WritebackPending, t.wb.npending)
+	tstats.RegGauge(t.si, stats.ReplPending, t.repl.npending)
+	tstats.RegGauge(t.si, stats.ReplLag, t.repl.lag)
 
 	t.initBackends(tstats) // (+ reg backend metrics)
 
@@ -383,7 +391,9 @@ func (t *target) Run() error {
 	ec.Init()
 	mirror.Init()
 
+	xreg.InitHist(config)
 	xreg.RegWithHK()
+	t.ra.init(t)
 
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
@@ -392,6 +402,12 @@ func (t *target) Run() error {
 
 	dsort.Tinit(t.statsT, db, config)
 	dload.Init(t.statsT, db, &config.Client)
+	go t.goresumedl()
+	t.wb.init(t, db)
+	go t.wb.resume()
+	t.repl.init(t, db)
+	go t.repl.resume()
+	t.aidx.init(t)
 
 	err = t.htrun.run(config)
 
@@ -696,7 +712,7 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 
 	// two special flows
 	if dpq.etlName != "" {
-		t.getETL(w, r, dpq.etlName, lom)
+		t.getETL(w, r, dpq.etlName, dpq.etlArgs, lom)
 		return lom, nil
 	}
 	if cos.IsParseBool(r.Header.Get(apc.HdrBlobDownload)) {
@@ -766,6 +782,7 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		}
 
 		t.statsT.IncErr(stats.GetCount)
+		t.statsT.IncBckErr(bck.Bucket(), stats.GetCount)
 
 		// handle right here, return nil
 		if err != errSendingResp {
@@ -778,6 +795,8 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 				t._erris(w, r, dpq.silent, err, ecode)
 			}
 		}
+	} else if bck.IsRemote() && bck.Props.Readahead.Window > 0 {
+		t.ra.onGet(r, goi.lom, goi.cold)
 	}
 	lom = goi.lom
 	freeGOI(goi)
@@ -920,8 +939,8 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request, apireq *a
 	if !t.isValidObjname(w, r, objName) {
 		return
 	}
-	if isRedirect(apireq.query) == "" {
-		t.writeErrf(w, r, "%s: %s(obj) is expected to be redirected", t.si, r.Method)
+	if isRedirect(apireq.query) == "" && t.isIntraCall(r.Header, false /*from primary*/) != nil {
+		t.writeErrf(w, r, "%s: %s(obj) is expected to be redirected", t.si, r.Method) // or, t2t (see delt2t)
 		return
 	}
 
@@ -1353,8 +1372,10 @@ func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 	}
 	if err == nil {
 		t.statsT.Inc(stats.DeleteCount)
+		t.statsT.AddBck(lom.Bucket(), cos.NamedVal64{Name: stats.DeleteCount, Value: 1})
 	} else {
 		t.statsT.IncErr(stats.DeleteCount) // TODO: count GET/PUT/DELETE remote errors separately..
+		t.statsT.IncBckErr(lom.Bucket(), stats.DeleteCount)
 	}
 	return
 }
@@ -1381,6 +1402,11 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 	// do
 	if delFromBackend {
 		backendErrCode, backendErr = t.Backend(lom.Bck()).DeleteObj(lom)
+		if backendErrCode == http.StatusNotFound && delFromAIS {
+			if _, ok := lom.GetCustomKey(cmn.WritebackObjMD); ok {
+				backendErrCode, backendErr = 0, nil // pending write-back (never uploaded)
+			}
+		}
 	}
 	if delFromAIS {
 		size := lom.Lsize()
@@ -1400,6 +1426,8 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
 				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
 			)
+		} else if backendErr == nil {
+			t.repl.onDel(lom)
 		}
 	}
 	if backendErr != nil {
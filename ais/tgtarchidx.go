@@ -0,0 +1,81 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Archive index (see feat.ArchIndex and cmn/archive/index.go) ----------------------------
+// With the feature flag set, TAR shards get indexed upon PUT, APPEND, etc.:
+// - asynchronously, off the PUT (APPEND) path, by archIdxWorkers workers (see objq);
+// - a shard written again while being indexed gets re-indexed once done;
+// - when more than archIdxMaxPending shards are waiting, the newly written one is skipped
+//   (stats.ArchIdxSkipCount) - to be indexed later by `index-shards`, if need be.
+// ---------------------------------------------------------------------------------------
+
+const (
+	archIdxWorkers    = 4
+	archIdxMaxPending = 4096
+)
+
+type (
+	archIdxRec struct {
+		bck     cmn.Bck
+		objName string
+	}
+	archIdxTask = objqTask[archIdxRec]
+
+	archIndexer struct {
+		t *target
+		q objq[archIdxRec]
+	}
+)
+
+func (ai *archIndexer) init(t *target) {
+	ai.t = t
+	ai.q.exec = ai.index
+	ai.q.init(archIdxWorkers)
+}
+
+func (t *target) putArchIdx(lom *core.LOM) {
+	if !lom.IsFeatureSet(feat.ArchIndex) {
+		return
+	}
+	if mime, err := archive.Mime("", lom.ObjName); err != nil || !archive.Indexable(mime) {
+		return
+	}
+	if t.aidx.q.npending() >= archIdxMaxPending {
+		t.statsT.Inc(stats.ArchIdxSkipCount)
+		if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Infoln(t.String()+": too many shards pending indexing - skipping", lom.Cname())
+		}
+		return
+	}
+	uname := lom.Uname()
+	t.aidx.q.worker(uname).push(&archIdxTask{rec: archIdxRec{bck: *lom.Bucket(), objName: lom.ObjName}, uname: uname})
+}
+
+// never retries
+func (ai *archIndexer) index(task *archIdxTask) bool {
+	lom := core.AllocLOM(task.rec.objName)
+	err := lom.InitBck(&task.rec.bck)
+	if err == nil {
+		lom.Lock(false)
+		err = lom.GenArchIdx()
+		lom.Unlock(false)
+	}
+	if err != nil && !cos.IsNotExist(err, 0) {
+		nlog.Warningln(ai.t.String()+": failed to index", lom.Cname(), "err:", err)
+	}
+	core.FreeLOM(lom)
+	return false
+}
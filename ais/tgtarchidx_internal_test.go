@@ -0,0 +1,61 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+func TestArchIdxQueue(tt *testing.T) {
+	bck := meta.NewBck("aidx-bck", apc.AIS, cmn.NsGlobal)
+	bmd := t.owner.bmd.get().clone()
+	if _, present := bmd.Get(bck); !present {
+		bmd.add(bck, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, Features: feat.ArchIndex})
+		t.owner.bmd.putPersist(bmd, nil)
+	}
+	w := newTestObjq(&t.aidx.q)
+	put := func(objName string) {
+		lom := core.AllocLOM(objName)
+		defer core.FreeLOM(lom)
+		if err := lom.InitBck(bck.Bucket()); err != nil {
+			tt.Fatal(err)
+		}
+		t.putArchIdx(lom)
+	}
+
+	// not indexable; same shard twice: one task
+	put("a.txt")
+	put("a.tgz")
+	put("a.tar")
+	put("a.tar")
+	if n := t.aidx.q.npending(); n != 1 {
+		tt.Fatalf("expected 1 pending, got %d", n)
+	}
+
+	// queued rather than skipped - up to the limit
+	for i := 1; i < archIdxMaxPending; i++ {
+		put(strconv.Itoa(i) + ".tar")
+	}
+	if n := t.aidx.q.npending(); n != archIdxMaxPending {
+		tt.Fatalf("expected %d pending, got %d", archIdxMaxPending, n)
+	}
+	put("skipped.tar")
+	if n := t.aidx.q.npending(); n != archIdxMaxPending {
+		tt.Fatalf("expected %d pending, got %d", archIdxMaxPending, n)
+	}
+	for uname := range w.tasks {
+		if _, objName := cmn.ParseUname(uname); objName == "skipped.tar" {
+			tt.Fatal("expected skipped.tar to be skipped")
+		}
+	}
+}
@@ -406,6 +406,7 @@ func (t *target) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *a
 			Xact: xctn,
 		}
 		xctn.AddNotif(notif)
+		xctn.SetOrigin(&msg.ActMsg, msg.User)
 		xact.GoRunW(xctn)
 	default:
 		t.writeErrAct(w, r, msg.Action)
@@ -418,7 +419,7 @@ func (t *target) httpbckpost(w http.ResponseWriter, r *http.Request, apireq *api
 	if err != nil {
 		return
 	}
-	if msg.Action != apc.ActPrefetchObjects {
+	if msg.Action != apc.ActPrefetchObjects && msg.Action != apc.ActCreateInventory {
 		t.writeErrAct(w, r, msg.Action)
 		return
 	}
@@ -432,6 +433,20 @@ func (t *target) httpbckpost(w http.ResponseWriter, r *http.Request, apireq *api
 		return
 	}
 
+	if msg.Action == apc.ActCreateInventory {
+		invMsg := &cmn.InvMsg{}
+		if err := cos.MorphMarshal(msg.Value, invMsg); err != nil {
+			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+			return
+		}
+		if err := t.runInventory(msg.UUID, apireq.bck, invMsg); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		xreg.SetOrigin(msg.UUID, &msg.ActMsg, msg.User)
+		return
+	}
+
 	prfMsg := &apc.PrefetchMsg{}
 	if err := cos.MorphMarshal(msg.Value, prfMsg); err != nil {
 		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
@@ -439,7 +454,9 @@ func (t *target) httpbckpost(w http.ResponseWriter, r *http.Request, apireq *api
 	}
 	if ecode, err := t.runPrefetch(msg.UUID, apireq.bck, prfMsg); err != nil {
 		t.writeErr(w, r, err, ecode)
+		return
 	}
+	xreg.SetOrigin(msg.UUID, &msg.ActMsg, msg.User)
 }
 
 // handle apc.ActPrefetchObjects <-- via api.Prefetch* and api.StartX*
@@ -464,6 +481,26 @@ func (t *target) runPrefetch(xactID string, bck *meta.Bck, prfMsg *apc.PrefetchM
 	return 0, nil
 }
 
+// handle apc.ActCreateInventory <-- via api.CreateInventory and api.StartX*
+func (t *target) runInventory(xactID string, bck *meta.Bck, invMsg *cmn.InvMsg) error {
+	rns := xreg.RenewCreateInventory(xactID, bck, invMsg)
+	if rns.Err != nil {
+		return rns.Err
+	}
+	if rns.IsRunning() {
+		return nil
+	}
+	xctn := rns.Entry.Get()
+	notif := &xact.NotifXact{
+		Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
+		Xact: xctn,
+	}
+	xctn.AddNotif(notif)
+
+	xact.GoRunW(xctn)
+	return nil
+}
+
 // HEAD /v1/buckets/bucket-name
 func (t *target) httpbckhead(w http.ResponseWriter, r *http.Request, apireq *apiRequest) {
 	var (
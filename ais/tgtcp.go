@@ -1278,6 +1278,30 @@ func (t *target) headt2t(lom *core.LOM, tsi *meta.Snode, smap *smapX) (ok bool)
 	return
 }
 
+func (t *target) delt2t(lom *core.LOM, tsi *meta.Snode, smap *smapX) error {
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{
+			Method: http.MethodDelete,
+			Header: http.Header{
+				apc.HdrCallerID:   []string{t.SID()},
+				apc.HdrCallerName: []string{t.callerName()},
+			},
+			Base:  tsi.URL(cmn.NetIntraControl),
+			Path:  apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName),
+			Query: lom.Bck().NewQuery(),
+			Body:  cos.MustMarshal(apc.ActMsg{Action: apc.ActDeleteObjects}),
+		}
+		cargs.timeout = cmn.Rom.CplaneOperation()
+	}
+	res := t.call(cargs, smap)
+	err := res.err
+	freeCargs(cargs)
+	freeCR(res)
+	return err
+}
+
 // headObjBcast broadcasts to all targets to find out if anyone has the specified object.
 // NOTE: 1) apc.QparamCheckExistsAny to make an extra effort, 2) `ignoreMaintenance`
 func (t *target) headObjBcast(lom *core.LOM, smap *smapX) *meta.Snode {
@@ -1373,4 +1397,6 @@ func (t *target) Stop(err error) {
 	xreg.AbortAll(err)
 
 	t.htrun.stop(wg, g.netServ.pub.s != nil && !isErrNoUnregister(err) /*rm from Smap*/)
+
+	xreg.FlushHist()
 }
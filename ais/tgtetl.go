@@ -10,25 +10,25 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
 )
 
 // [METHOD] /v1/etl
+// (K8s is required for all ETLs other than in-process - see etl.InitCodeMsg.InProcess)
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if !k8s.IsK8s() {
-		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
-		return
-	}
 	switch {
 	case r.Method == http.MethodPut:
 		t.handleETLPut(w, r)
@@ -68,6 +68,10 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if msg, ok := initMsg.(*etl.InitCodeMsg); (!ok || !msg.InProcess()) && !k8s.IsK8s() {
+		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
+		return
+	}
 	xid := r.URL.Query().Get(apc.QparamUUID)
 
 	switch msg := initMsg.(type) {
@@ -152,11 +156,21 @@ func (t *target) stopETL(w http.ResponseWriter, r *http.Request, etlName string)
 	}
 }
 
-func (t *target) getETL(w http.ResponseWriter, r *http.Request, etlName string, lom *core.LOM) {
+func (t *target) getETL(w http.ResponseWriter, r *http.Request, etlName, etlArgs string, lom *core.LOM) {
 	var (
 		comm etl.Communicator
 		err  error
 	)
+	// pipeline and/or per-stage timeout (e.g. "decode,augment:30s,encode")
+	if strings.ContainsAny(etlName, ",:") {
+		stages, err := apc.ParseETLPipeline(etlName)
+		if err != nil {
+			t.writeErr(w, r, err, http.StatusBadRequest)
+			return
+		}
+		t.getETLPipeline(w, r, stages, etlArgs, lom)
+		return
+	}
 	comm, err = etl.GetCommunicator(etlName)
 	if err != nil {
 		if cos.IsErrNotFound(err) {
@@ -169,7 +183,10 @@ func (t *target) getETL(w http.ResponseWriter, r *http.Request, etlName string,
 		t.writeErr(w, r, err)
 		return
 	}
-	if err := comm.InlineTransform(w, r, lom); err != nil {
+	started := mono.NanoTime()
+	if err := comm.InlineTransform(w, r, lom, etlArgs); err == nil {
+		t.statsT.Add(stats.ETLLatency, mono.SinceNano(started))
+	} else {
 		errV := cmn.NewErrETL(&cmn.ETLErrCtx{ETLName: etlName, PodName: comm.PodName(), SvcName: comm.SvcName()},
 			err.Error())
 		xetl := comm.Xact()
@@ -178,6 +195,37 @@ func (t *target) getETL(w http.ResponseWriter, r *http.Request, etlName string,
 	}
 }
 
+// inline transformation via ETL pipeline (see apc.ParseETLPipeline)
+// - etlArgs, if any, are forwarded to each stage
+func (t *target) getETLPipeline(w http.ResponseWriter, r *http.Request, stages []apc.ETLStage, etlArgs string, lom *core.LOM) {
+	for i := range stages {
+		stages[i].Args = etlArgs
+	}
+	pipeline, err := etl.NewPipeline(stages)
+	if err != nil {
+		t.writeErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	started := mono.NanoTime()
+	reader, failed, err := pipeline.Transform(lom)
+	if err != nil {
+		t.writeErr(w, r, pipeline.Err(failed, err))
+		return
+	}
+	if size := reader.Size(); size >= 0 {
+		w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	}
+	buf, slab := t.gmm.Alloc()
+	_, err = io.CopyBuffer(w, reader, buf)
+	slab.Free(buf)
+	reader.Close()
+	if err != nil {
+		nlog.Errorln(t.String(), "failed to transform", lom.Cname(), "via ETL pipeline [", pipeline.String(), "] err:", err)
+		return
+	}
+	t.statsT.Add(stats.ETLLatency, mono.SinceNano(started))
+}
+
 func (t *target) logsETL(w http.ResponseWriter, r *http.Request, etlName string) {
 	logs, err := etl.PodLogs(etlName)
 	if err != nil {
@@ -249,6 +297,10 @@ func etlParseObjectReq(_ http.ResponseWriter, r *http.Request) (secret string, b
 // NOTE: this is an internal URL with "_objects" in its path intended to avoid
 // conflicts with ETL name in `/v1/elts/<etl-name>/...`
 func (t *target) getObjectETL(w http.ResponseWriter, r *http.Request) {
+	if !k8s.IsK8s() {
+		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
+		return
+	}
 	secret, bck, objName, err := etlParseObjectReq(w, r)
 	if err != nil {
 		t.writeErr(w, r, err)
@@ -279,6 +331,10 @@ func (t *target) getObjectETL(w http.ResponseWriter, r *http.Request) {
 // Handles HEAD requests from ETL containers (K8s Pods).
 // Validates the secret that was injected into a Pod during its initialization.
 func (t *target) headObjectETL(w http.ResponseWriter, r *http.Request) {
+	if !k8s.IsK8s() {
+		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
+		return
+	}
 	secret, bck, objName, err := etlParseObjectReq(w, r)
 	if err != nil {
 		t.writeErr(w, r, err)
@@ -14,6 +14,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 	"sync"
@@ -217,6 +218,7 @@ rerr:
 			poi.t.statsT.IncNonIOErr()
 		}
 		poi.t.statsT.IncErr(stats.PutCount)
+		poi.t.statsT.IncBckErr(poi.lom.Bucket(), stats.PutCount)
 	}
 	return
 }
@@ -234,6 +236,11 @@ func (poi *putOI) stats() {
 		cos.NamedVal64{Name: stats.PutLatency, Value: delta},
 		cos.NamedVal64{Name: stats.PutLatencyTotal, Value: delta},
 	)
+	poi.t.statsT.AddBck(bck.Bucket(),
+		cos.NamedVal64{Name: stats.PutCount, Value: 1},
+		cos.NamedVal64{Name: stats.PutSize, Value: size},
+		cos.NamedVal64{Name: stats.PutLatencyTotal, Value: delta},
+	)
 	if poi.rltime > 0 {
 		debug.Assert(bck.IsRemote())
 		backend := poi.t.Backend(bck)
@@ -295,6 +302,7 @@ func (poi *putOI) finalize() (ecode int, err error) {
 		}
 	}
 	poi.t.putMirror(poi.lom)
+	poi.t.putArchIdx(poi.lom)
 	return 0, nil
 }
 
@@ -304,8 +312,9 @@ func (poi *putOI) fini() (ecode int, err error) {
 		lom = poi.lom
 		bck = lom.Bck()
 	)
-	// put remote
-	if bck.IsRemote() && poi.owt < cmn.OwtRebalance {
+	// put remote (synchronously, unless write-back)
+	wback := poi.owt == cmn.OwtPut && wbEnabled(lom, poi.oreq)
+	if bck.IsRemote() && poi.owt < cmn.OwtRebalance && !wback {
 		ecode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
@@ -358,6 +367,28 @@ func (poi *putOI) fini() (ecode int, err error) {
 		}
 	}
 
+	// write-back: mark pending and enqueue (persistently) prior to committing;
+	// rebalance migrates pending state along with the object's custom metadata
+	switch {
+	case wback:
+		lom.ObjAttrs().DelCustomKeys(cmn.SourceObjMD, cmn.CRC32CObjMD, cmn.ETag, cmn.MD5ObjMD, cmn.VersionObjMD,
+			cmn.WritebackErrObjMD)
+		lom.SetCustomKey(cmn.WritebackObjMD, wbToken())
+	case poi.owt != cmn.OwtRebalance:
+		lom.ObjAttrs().DelCustomKeys(cmn.WritebackObjMD, cmn.WritebackErrObjMD)
+	}
+	if _, ok := lom.GetCustomKey(cmn.WritebackObjMD); ok {
+		if err = poi.t.wb.add(lom); err != nil {
+			return
+		}
+	}
+	// replication: ditto (the "replicated" mark, if any, migrates as well)
+	if poi.owt < cmn.OwtRebalance {
+		if err = poi.t.repl.onPut(lom); err != nil {
+			return
+		}
+	}
+
 	// done
 	if err = lom.RenameFinalize(poi.workFQN); err != nil {
 		return
@@ -785,6 +816,9 @@ validate:
 	if _, ok := err.(*cos.ErrBadCksum); !ok {
 		return
 	}
+	if _, ok := lom.GetCustomKey(cmn.WritebackObjMD); ok {
+		return // pending write-back: neither cold-GET (stale remote) nor remove
+	}
 	if !lom.Bck().IsAIS() && !goi.lom.IsFeatureSet(feat.DisableColdGET) {
 		coldGet = true
 		return
@@ -1106,6 +1140,16 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	if err != nil {
 		return err
 	}
+	// single and indexed: seek directly to the archived file
+	if dpq.arch.path != "" && archive.Indexable(mime) {
+		idx, erl := core.LoadArchIdx(fqn, lom.ArchIdxFQN())
+		if erl != nil {
+			nlog.Warningln(goi.t.String()+": failed to load archive index of", lom.Cname(), "err:", erl)
+		}
+		if idx != nil {
+			return goi._txidx(fqn, lmfh, idx, whdr)
+		}
+	}
 	ar, err = archive.NewReader(mime, lmfh, lom.Lsize())
 	if err != nil {
 		return fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
@@ -1147,6 +1191,26 @@ func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
 	return err
 }
 
+func (goi *getOI) _txidx(fqn string, lmfh *os.File, idx *archive.Index, whdr http.Header) error {
+	var (
+		dpq = goi.dpq
+		lom = goi.lom
+	)
+	csl, err := idx.ReadOne(lmfh, dpq.arch.path)
+	if err != nil {
+		return cmn.NewErrFailedTo(goi.t, "extract "+dpq._archstr()+" from", lom.Cname(), err)
+	}
+	if csl == nil {
+		return cos.NewErrNotFound(goi.t, dpq._archstr()+" in "+lom.Cname())
+	}
+	whdr.Set(cos.HdrContentType, cos.ContentBinary)
+	buf, slab := goi.t.gmm.AllocSize(min(csl.Size(), memsys.DefaultBuf2Size))
+	err = goi.transmit(csl, buf, fqn)
+	slab.Free(buf)
+	csl.Close()
+	return err
+}
+
 func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 	written, err := cos.CopyBuffer(goi.w, r, buf)
 	if err != nil {
@@ -1193,6 +1257,11 @@ func (goi *getOI) stats(written int64) {
 		cos.NamedVal64{Name: stats.GetLatency, Value: delta},      // see also: stats.GetColdRwLatency
 		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: delta}, // see also: stats.GetColdRwLatency
 	)
+	goi.t.statsT.AddBck(goi.lom.Bucket(),
+		cos.NamedVal64{Name: stats.GetCount, Value: 1},
+		cos.NamedVal64{Name: stats.GetSize, Value: written},
+		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: delta},
+	)
 	if goi.verchanged {
 		goi.t.statsT.AddMany(
 			cos.NamedVal64{Name: stats.VerChangeCount, Value: 1},
@@ -1405,6 +1474,9 @@ func (a *apndOI) pack(workFQN string) string {
 
 // main method
 func (coi *copyOI) do(t *target, dm *bundle.DataMover, lom *core.LOM) (size int64, err error) {
+	if fdp, ok := coi.DP.(core.FanOutDP); ok && fdp.FanOut() {
+		return coi.fanOut(t, dm, lom) // (including dry-run)
+	}
 	if coi.DryRun {
 		return coi._dryRun(lom, coi.ObjnameTo)
 	}
@@ -1507,6 +1579,184 @@ func (coi *copyOI) _reader(t *target, dm *bundle.DataMover, lom, dst *core.LOM)
 	return size, ecode, err
 }
 
+// one source => many destination objects (see core.FanOutDP)
+//   - stage all outputs locally, and only then (having received the entire response) commit them;
+//   - that is, a failed transformation produces no outputs;
+//   - failure to commit is different: outputs committed so far - locally and by other targets -
+//     are removed on a best-effort basis (see _uncommit)
+func (coi *copyOI) fanOut(t *target, dm *bundle.DataMover, lom *core.LOM) (size int64, err error) {
+	reader, oah, err := coi.DP.Reader(lom, coi.LatestVer, coi.Sync)
+	if err != nil {
+		return 0, err
+	}
+	outs, err := coi._stage(t, lom, reader)
+	reader.Close()
+	if err == nil {
+		size, err = coi._commit(t, dm, lom, oah, outs)
+	}
+	for i := range outs {
+		if outs[i].fqn != "" {
+			cos.RemoveFile(outs[i].fqn)
+		}
+	}
+	return size, err
+}
+
+type fanOutput struct {
+	tsi  *meta.Snode // committed remotely (sent to another target)
+	name string      // destination object name
+	fqn  string      // staged
+	size int64
+}
+
+func (coi *copyOI) _stage(t *target, lom *core.LOM, reader io.Reader) (outs []fanOutput, _ error) {
+	var (
+		buf, slab = t.gmm.Alloc()
+		tr        = tar.NewReader(reader)
+		names     = make(cos.StrSet, 8)
+	)
+	defer slab.Free(buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return outs, nil
+		}
+		if err != nil {
+			return outs, fmt.Errorf("%s: invalid fan-out response (expecting TAR): %w", lom.Cname(), err)
+		}
+		if !hdr.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		// e.g. "./a/b" => "a/b", "/a" => "a"; reject "../a"
+		member := strings.TrimPrefix(path.Clean(hdr.Name), "/")
+		name := coi.ObjnameTo + "/" + member
+		if member == "." || member == ".." || strings.HasPrefix(member, "../") || cmn.ValidateObjName(name) != nil {
+			return outs, fmt.Errorf("%s: invalid fan-out member name %q", lom.Cname(), hdr.Name)
+		}
+		if names.Contains(member) {
+			return outs, fmt.Errorf("%s: duplicate fan-out member name %q", lom.Cname(), hdr.Name)
+		}
+		names.Add(member)
+		if coi.DryRun {
+			outs = append(outs, fanOutput{name: name, size: hdr.Size})
+			continue
+		}
+		out := fanOutput{name: name, fqn: fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileFanOut)}
+		outs = append(outs, out)
+		fh, err := cos.CreateFile(out.fqn)
+		if err != nil {
+			return outs, err
+		}
+		outs[len(outs)-1].size, err = cos.CopyBuffer(fh, tr, buf)
+		cos.Close(fh)
+		if err != nil {
+			return outs, err
+		}
+	}
+}
+
+func (coi *copyOI) _commit(t *target, dm *bundle.DataMover, lom *core.LOM, oah cos.OAH, outs []fanOutput) (size int64, _ error) {
+	smap := t.owner.smap.get()
+	for i := range outs {
+		out := &outs[i]
+		if coi.DryRun {
+			size += out.size
+			continue
+		}
+		if err := coi._commitOne(t, dm, lom, oah, out, smap); err != nil {
+			coi._uncommit(t, outs[:i], smap)
+			return size, err
+		}
+		size += out.size
+	}
+	return size, nil
+}
+
+func (coi *copyOI) _commitOne(t *target, dm *bundle.DataMover, lom *core.LOM, oah cos.OAH, out *fanOutput, smap *smapX) error {
+	tsi, err := smap.HrwName2T(coi.BckTo.MakeUname(out.name))
+	if err != nil {
+		return err
+	}
+	fh, err := cos.NewFileHandle(out.fqn)
+	if err != nil {
+		return err
+	}
+	oa := &cmn.ObjAttrs{Size: out.size, Cksum: cos.NoneCksum, Atime: oah.AtimeUnix()}
+	if tsi.ID() == t.SID() {
+		return coi._putLocal(t, dm, out.name, fh, oa)
+	}
+	sargs := allocSnda()
+	{
+		sargs.objNameTo = out.name
+		sargs.tsi = tsi
+		sargs.dm = dm
+		sargs.owt = coi.OWT
+		sargs.reader, sargs.objAttrs = fh, oa
+		sargs.bckTo = coi.BckTo
+	}
+	if dm != nil {
+		sargs.owt = dm.OWT()
+		err = coi._dm(lom.CloneMD(lom.FQN), sargs)
+	} else {
+		err = coi.put(t, sargs)
+	}
+	freeSnda(sargs)
+	if err == nil {
+		out.tsi = tsi
+	}
+	return err
+}
+
+// best-effort: remove committed outputs, local and remote
+// (note that an output sent via data mover may still be in flight)
+func (coi *copyOI) _uncommit(t *target, outs []fanOutput, smap *smapX) {
+	for i := range outs {
+		dst := core.AllocLOM(outs[i].name)
+		if err := dst.InitBck(coi.BckTo.Bucket()); err != nil {
+			core.FreeLOM(dst)
+			continue
+		}
+		var err error
+		if tsi := outs[i].tsi; tsi != nil {
+			err = t.delt2t(dst, tsi, smap)
+		} else {
+			_, err = t.DeleteObject(dst, false /*evict*/)
+		}
+		if err != nil && cmn.Rom.FastV(4, cos.SmoduleAIS) {
+			nlog.Warningln(t.String(), "failed to remove fan-out output", dst.Cname(), "err:", err)
+		}
+		core.FreeLOM(dst)
+	}
+}
+
+// (compare with _reader above)
+func (coi *copyOI) _putLocal(t *target, dm *bundle.DataMover, objName string, r cos.ReadOpenCloser, oa *cmn.ObjAttrs) error {
+	dst := core.AllocLOM(objName)
+	defer core.FreeLOM(dst)
+	if err := dst.InitBck(coi.BckTo.Bucket()); err != nil {
+		r.Close()
+		return err
+	}
+	poi := allocPOI()
+	{
+		poi.t = t
+		poi.lom = dst
+		poi.config = coi.Config
+		poi.r = r
+		poi.owt = coi.OWT
+		poi.xctn = coi.Xact // on behalf of
+		poi.workFQN = fs.CSM.Gen(dst, fs.WorkfileType, "copy-dp")
+		poi.atime = oa.Atime
+		poi.cksumToUse = oa.Cksum
+	}
+	if dm != nil {
+		poi.owt = dm.OWT()
+	}
+	_, err := poi.putObject()
+	freePOI(poi)
+	return err
+}
+
 func (coi *copyOI) _regular(t *target, lom, dst *core.LOM) (size int64, _ error) {
 	if lom.FQN == dst.FQN { // resilvering with a single mountpath?
 		return
@@ -1830,6 +2080,9 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 	if err := a.lom.RenameFinalize(fqn); err != nil {
 		return err
 	}
+	if err := a.t.repl.onPut(a.lom); err != nil {
+		return err
+	}
 	a.lom.SetSize(size)
 	a.lom.SetCksum(cksum)
 	a.lom.SetAtimeUnix(a.started)
@@ -1842,6 +2095,7 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 		}
 	}
 	a.t.putMirror(a.lom)
+	a.t.putArchIdx(a.lom)
 	return nil
 }
 
@@ -5,11 +5,14 @@
 package ais
 
 import (
+	"archive/tar"
+	"bytes"
 	"flag"
 	"io"
 	"net/http"
 	"os"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -265,3 +268,54 @@ func BenchmarkObjGetDiscard(b *testing.B) {
 		})
 	}
 }
+
+func TestFanOutStage(tt *testing.T) {
+	lom := core.AllocLOM("src")
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}); err != nil {
+		tt.Fatal(err)
+	}
+	coi := &copyOI{ObjnameTo: "dst", DryRun: true}
+
+	tests := []struct {
+		members []string
+		names   []string // expected destination names (nil when expecting error)
+	}{
+		{members: []string{"a", "./b", "/c/d", ".hidden", "x/../y"}, names: []string{"dst/a", "dst/b", "dst/c/d", "dst/.hidden", "dst/y"}},
+		{members: []string{"../a"}},
+		{members: []string{"a/../.."}},
+		{members: []string{"."}},
+		{members: []string{"a", "./a"}},
+	}
+	for _, test := range tests {
+		var (
+			buf bytes.Buffer
+			tw  = tar.NewWriter(&buf)
+		)
+		for _, name := range test.members {
+			tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 1, Typeflag: tar.TypeReg})
+			tw.Write([]byte{'x'})
+		}
+		tw.Close()
+
+		outs, err := coi._stage(t, lom, &buf)
+		desc := strings.Join(test.members, ", ")
+		if test.names == nil {
+			if err == nil {
+				tt.Errorf("[%s]: expected error, got %+v", desc, outs)
+			}
+			continue
+		}
+		if err != nil {
+			tt.Fatalf("[%s]: %v", desc, err)
+		}
+		if len(outs) != len(test.names) {
+			tt.Fatalf("[%s]: expected %v, got %+v", desc, test.names, outs)
+		}
+		for i := range outs {
+			if outs[i].name != test.names[i] || outs[i].size != 1 {
+				tt.Errorf("[%s]: expected %q (size 1), got %+v", desc, test.names[i], outs[i])
+			}
+		}
+	}
+}
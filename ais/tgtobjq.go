@@ -0,0 +1,172 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Per-object asynchronous work queue (used by write-back and replication) ----------------
+// - a fixed number of workers, with any given object (uname) always handled by the same
+//   worker - one task per object at a time;
+// - re-adding an object that is already queued (or being executed, or waiting to retry)
+//   does not create another task: the existing one gets (optionally) merged and flagged
+//   to execute again once done;
+// - failed tasks are retried with exponential backoff.
+// ---------------------------------------------------------------------------------------
+
+type (
+	objqTask[R any] struct {
+		rec   R
+		uname string
+		tries int
+		again bool // re-added while being executed (or waiting to retry)
+	}
+	objqWorker[R any] struct {
+		q      *objq[R]
+		tasks  map[string]*objqTask[R] // uname => task
+		queue  []*objqTask[R]
+		mu     sync.Mutex
+		workCh chan struct{}
+	}
+	objq[R any] struct {
+		// returns true to retry
+		exec func(task *objqTask[R]) bool
+		// (optional, under lock) re-adding: merge the new record into the existing one
+		merge func(prev, curr *R)
+		// (optional, under lock) new task added
+		added func(task *objqTask[R])
+		// (optional, under lock) the task is done
+		fini func(task *objqTask[R])
+
+		workers  []objqWorker[R]
+		retryMin time.Duration
+		retryMax time.Duration
+		pending  atomic.Int64
+	}
+)
+
+func (q *objq[R]) init(num int) {
+	q.workers = make([]objqWorker[R], num)
+	for i := range q.workers {
+		w := &q.workers[i]
+		w.q = q
+		w.tasks = make(map[string]*objqTask[R], 64)
+		w.workCh = make(chan struct{}, 1)
+		go w.run()
+	}
+}
+
+// num objects queued, being executed, or waiting to retry
+func (q *objq[R]) npending() int64 { return q.pending.Load() }
+
+func (q *objq[R]) worker(uname string) *objqWorker[R] {
+	digest := xxhash.Checksum64S(cos.UnsafeB(uname), cos.MLCG32)
+	return &q.workers[digest%uint64(len(q.workers))]
+}
+
+// consistent copy of the task's record (given concurrent merge)
+func (q *objq[R]) snap(task *objqTask[R]) (rec R) {
+	w := q.worker(task.uname)
+	w.mu.Lock()
+	rec = task.rec
+	w.mu.Unlock()
+	return
+}
+
+////////////////
+// objqWorker //
+////////////////
+
+func (w *objqWorker[R]) push(task *objqTask[R]) {
+	w.mu.Lock()
+	w._push(task)
+	w.mu.Unlock()
+	w.wakeup()
+}
+
+// (under lock)
+func (w *objqWorker[R]) _push(task *objqTask[R]) {
+	if prev, ok := w.tasks[task.uname]; ok {
+		if w.q.merge != nil {
+			w.q.merge(&prev.rec, &task.rec)
+		}
+		prev.again = true
+		return
+	}
+	w.tasks[task.uname] = task
+	w.queue = append(w.queue, task)
+	w.q.pending.Inc()
+	if w.q.added != nil {
+		w.q.added(task)
+	}
+}
+
+func (w *objqWorker[R]) wakeup() {
+	select {
+	case w.workCh <- struct{}{}:
+	default:
+	}
+}
+
+func (w *objqWorker[R]) pop() (task *objqTask[R]) {
+	w.mu.Lock()
+	if len(w.queue) > 0 {
+		task = w.queue[0]
+		w.queue[0] = nil
+		w.queue = w.queue[1:]
+		task.again = false
+	}
+	w.mu.Unlock()
+	return
+}
+
+func (w *objqWorker[R]) run() {
+	for range w.workCh {
+		for task := w.pop(); task != nil; task = w.pop() {
+			if nlog.Stopping() {
+				return // (persisted records, if any, get resumed upon restart)
+			}
+			retry := w.q.exec(task)
+			w.done(task, retry)
+		}
+	}
+}
+
+func (w *objqWorker[R]) done(task *objqTask[R], retry bool) {
+	if retry {
+		task.tries++
+		d := min(w.q.retryMin<<min(task.tries-1, 16), w.q.retryMax)
+		time.AfterFunc(d, func() { w.requeue(task) })
+		return
+	}
+	w.mu.Lock()
+	if task.again {
+		task.again, task.tries = false, 0
+		w.queue = append(w.queue, task)
+		w.mu.Unlock()
+		w.wakeup()
+		return
+	}
+	delete(w.tasks, task.uname)
+	if w.q.fini != nil {
+		w.q.fini(task)
+	}
+	w.mu.Unlock()
+	w.q.pending.Dec()
+}
+
+func (w *objqWorker[R]) requeue(task *objqTask[R]) {
+	w.mu.Lock()
+	w.queue = append(w.queue, task)
+	w.mu.Unlock()
+	w.wakeup()
+}
@@ -0,0 +1,66 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+)
+
+// (single worker, no goroutines)
+func newTestObjq[R any](q *objq[R]) *objqWorker[R] {
+	q.workers = make([]objqWorker[R], 1)
+	w := &q.workers[0]
+	w.q = q
+	w.tasks = make(map[string]*objqTask[R])
+	w.workCh = make(chan struct{}, 1)
+	return w
+}
+
+func TestObjq(t *testing.T) {
+	var (
+		done []string
+		q    = &objq[replRec]{
+			merge: replMerge,
+			fini:  func(task *replTask) { done = append(done, task.uname) },
+		}
+		w = newTestObjq(q)
+	)
+
+	// same object added twice: one task, merged (the last op wins, the first time stays)
+	w.push(&replTask{uname: "a", rec: replRec{Op: replPut, Time: 1}})
+	w.push(&replTask{uname: "a", rec: replRec{Op: replDel, Time: 2}})
+	w.push(&replTask{uname: "b", rec: replRec{Op: replPut, Time: 3}})
+	if n := q.npending(); n != 2 {
+		t.Fatalf("expected 2 pending, got %d", n)
+	}
+	task := w.pop()
+	if rec := q.snap(task); task.uname != "a" || rec.Op != replDel || rec.Time != 1 {
+		t.Fatalf("expected (%q, %q, 1), got %+v", "a", replDel, rec)
+	}
+
+	// re-added while being executed: executed again
+	w.push(&replTask{uname: "a", rec: replRec{Op: replPut, Time: 4}})
+	w.done(task, false /*retry*/)
+	if n := q.npending(); n != 2 || len(done) != 0 {
+		t.Fatalf("expected 2 pending (none done), got %d (%v)", n, done)
+	}
+	if task = w.pop(); task == nil || task.uname != "b" {
+		t.Fatalf("expected task %q, got %+v", "b", task)
+	}
+	w.done(task, false)
+	if task = w.pop(); task == nil || task.uname != "a" || task.rec.Op != replPut {
+		t.Fatalf("expected (%q, %q), got %+v", "a", replPut, task)
+	}
+	w.done(task, false)
+	if n := q.npending(); n != 0 {
+		t.Fatalf("expected none pending, got %d", n)
+	}
+	if len(done) != 2 || done[0] != "b" || done[1] != "a" {
+		t.Fatalf("expected (b, a) done, got %v", done)
+	}
+	if task = w.pop(); task != nil {
+		t.Fatalf("expected empty queue, got %+v", task)
+	}
+}
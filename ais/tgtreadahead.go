@@ -0,0 +1,318 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Readahead (a.k.a. predictive prefetch) ------------------------------------------------
+// Target tracks GET requests per (client, bucket, object name pattern) where the pattern
+// is the object name with its last sequence of digits factored out, e.g.:
+// "train-000123.tar" => ("train-", 123, ".tar").
+//
+// Given uniform HRW distribution, a target "sees" only every (approx.) N-th object
+// of a sequentially read dataset, where N is the number of targets. Sequential access
+// is, therefore, detected as a number of consecutive GETs with monotonically increasing
+// sequence numbers and bounded gaps.
+//
+// Once detected, the target prefetches the next (up to) `readahead.window` objects
+// that it owns, via the regular prefetch xaction (xs/prefetch.go) - one xaction per
+// bucket at a time, with objects selected while it is running batched for the next one.
+//
+// The total number of objects being read-ahead at any point in time is bounded
+// by raMaxInflight (the budget).
+// ---------------------------------------------------------------------------------------
+
+const (
+	raMinSeq      = 2                // consecutive sequential GETs to trigger readahead
+	raMaxStreams  = 4096             // max number of tracked streams (older ones get evicted)
+	raMaxInflight = 512              // max number of objects being read-ahead at any time (budget)
+	raTTL         = 10 * time.Minute // read-ahead objects not read within are counted as waste
+)
+
+type (
+	raStream struct {
+		last  int64 // last accessed sequence number
+		next  int64 // next sequence number to consider for readahead
+		seq   int   // number of consecutive sequential GETs
+		atime int64 // mono time of the last access
+	}
+	// per bucket: names to read ahead once the running prefetch is done
+	raBatch struct {
+		bck     *meta.Bck
+		names   []string
+		running bool
+	}
+	readahead struct {
+		t        *target
+		streams  map[string]*raStream
+		ahead    map[string]int64    // uname => mono time when read-ahead
+		bcks     map[string]*raBatch // bucket uname => batch
+		mu       sync.Mutex
+		inflight int // num objects being read-ahead
+	}
+)
+
+func (ra *readahead) init(t *target) {
+	ra.t = t
+	ra.streams = make(map[string]*raStream, 64)
+	ra.ahead = make(map[string]int64, 256)
+	ra.bcks = make(map[string]*raBatch, 4)
+	hk.Reg("readahead"+hk.NameSuffix, ra.housekeep, raTTL)
+}
+
+// parse object name into (prefix, sequence number, number of digits, suffix)
+func raParse(objName string) (prefix string, num int64, width int, suffix string, ok bool) {
+	end := -1
+	for i := len(objName) - 1; i >= 0; i-- {
+		if c := objName[i]; c >= '0' && c <= '9' {
+			end = i + 1
+			break
+		}
+	}
+	if end < 0 {
+		return
+	}
+	start := end - 1
+	for start > 0 && objName[start-1] >= '0' && objName[start-1] <= '9' {
+		start--
+	}
+	if end-start > 18 { // not a sequence number
+		return
+	}
+	n, err := strconv.ParseInt(objName[start:end], 10, 64)
+	if err != nil {
+		return
+	}
+	return objName[:start], n, end - start, objName[end:], true
+}
+
+func raName(prefix string, num int64, width int, suffix string) string {
+	s := strconv.FormatInt(num, 10)
+	for len(s) < width {
+		s = "0" + s
+	}
+	return prefix + s + suffix
+}
+
+func raClient(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// is called upon successful GET from a remote bucket with readahead enabled
+func (ra *readahead) onGet(r *http.Request, lom *core.LOM, cold bool) {
+	var (
+		bck   = lom.Bck()
+		uname = lom.Uname()
+		smap  = ra.t.owner.smap.get()
+	)
+	prefix, num, width, suffix, ok := raParse(lom.ObjName)
+
+	ra.mu.Lock()
+	// 1. accounting
+	_, prefetched := ra.ahead[uname]
+	if prefetched {
+		delete(ra.ahead, uname)
+	}
+	if !ok {
+		ra.mu.Unlock()
+		ra.stats(prefetched, cold, false)
+		return
+	}
+
+	// 2. detect and select
+	key := raClient(r) + "|" + string(bck.MakeUname(prefix)) + "|" + strconv.Itoa(width) + "|" + suffix
+	names, sequential := ra._detect(key, bck, smap, prefix, num, width, suffix)
+
+	// 3. one prefetch xaction per bucket at a time; the rest gets batched
+	var pbck *meta.Bck
+	if len(names) > 0 {
+		bkey := string(bck.MakeUname(""))
+		b, ok := ra.bcks[bkey]
+		if !ok {
+			b = &raBatch{bck: meta.CloneBck(bck.Bucket())} // (lom's bucket does not outlive the GET)
+			ra.bcks[bkey] = b
+		}
+		b.names = append(b.names, names...)
+		if !b.running {
+			b.running, pbck = true, b.bck
+			names, b.names = b.names, nil
+		}
+	}
+	ra.mu.Unlock()
+
+	ra.stats(prefetched, cold, sequential)
+	if pbck != nil {
+		ra.prefetch(pbck, names)
+	}
+}
+
+// (under lock) returns the next (up to) window objects owned by this target,
+// and whether the access (prior to this one) was sequential
+func (ra *readahead) _detect(key string, bck *meta.Bck, smap *smapX, prefix string, num int64, width int, suffix string) ([]string, bool) {
+	var (
+		window    = bck.Props.Readahead.Window
+		maxGap    = int64(window * max(smap.CountActiveTs(), 1))
+		now       = mono.NanoTime()
+		s, exists = ra.streams[key]
+	)
+	if !exists {
+		if len(ra.streams) >= raMaxStreams {
+			ra._evict()
+		}
+		ra.streams[key] = &raStream{last: num, next: num + 1, atime: now}
+		return nil, false
+	}
+	sequential := s.seq >= raMinSeq
+	if num > s.last && num-s.last <= maxGap {
+		s.seq++
+	} else {
+		s.seq, s.next = 0, num+1
+	}
+	s.last, s.atime = num, now
+	if s.seq < raMinSeq {
+		return nil, sequential
+	}
+
+	var (
+		names []string
+		limit = min(window, raMaxInflight-ra.inflight)
+		from  = max(s.next, num+1)
+		n     int64
+	)
+	for n = from; n <= num+maxGap && len(names) < limit; n++ {
+		var (
+			name  = raName(prefix, n, width, suffix)
+			uname = bck.MakeUname(name)
+		)
+		tsi, err := smap.HrwName2T(uname)
+		if err != nil {
+			break
+		}
+		if tsi.ID() != ra.t.SID() {
+			continue
+		}
+		if _, ok := ra.ahead[string(uname)]; ok {
+			continue
+		}
+		names = append(names, name)
+		ra.ahead[string(uname)] = now
+	}
+	s.next = n
+	ra.inflight += len(names)
+	return names, true
+}
+
+func (ra *readahead) stats(prefetched, cold, sequential bool) {
+	switch {
+	case prefetched && !cold:
+		ra.t.statsT.Inc(stats.ReadaheadHitCount)
+	case cold && (prefetched || sequential):
+		ra.t.statsT.Inc(stats.ReadaheadMissCount)
+	}
+}
+
+func (ra *readahead) prefetch(bck *meta.Bck, names []string) {
+	msg := &apc.PrefetchMsg{ListRange: apc.ListRange{ObjNames: names}}
+	rns := xreg.RenewPrefetch(cos.GenUUID(), bck, msg)
+	if rns.Err != nil {
+		nlog.Warningln(ra.t.String(), "readahead:", rns.Err)
+		ra.done(bck, len(names))
+		return
+	}
+	xctn := rns.Entry.Get()
+	notif := &xact.NotifXact{
+		Base: nl.Base{
+			When: core.UponTerm,
+			F:    func(core.Notif, error, bool) { ra.done(bck, len(names)) },
+		},
+		Xact: xctn,
+	}
+	xctn.AddNotif(notif)
+	xact.GoRunW(xctn)
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(ra.t.String(), "readahead:", xctn.Name(), names[0], "...", len(names))
+	}
+}
+
+// prefetch done: start the next one with all the names batched in the meantime, if any
+func (ra *readahead) done(bck *meta.Bck, n int) {
+	var (
+		names []string
+		bkey  = string(bck.MakeUname(""))
+	)
+	ra.mu.Lock()
+	ra.inflight -= n
+	if b, ok := ra.bcks[bkey]; ok {
+		if len(b.names) > 0 {
+			names, b.names = b.names, nil
+		} else {
+			delete(ra.bcks, bkey)
+		}
+	}
+	ra.mu.Unlock()
+	if len(names) > 0 {
+		ra.prefetch(bck, names)
+	}
+}
+
+// evict the least recently accessed stream (under lock)
+func (ra *readahead) _evict() {
+	var (
+		oldest string
+		atime  int64
+	)
+	for key, s := range ra.streams {
+		if oldest == "" || s.atime < atime {
+			oldest, atime = key, s.atime
+		}
+	}
+	delete(ra.streams, oldest)
+}
+
+func (ra *readahead) housekeep() time.Duration {
+	var (
+		waste int64
+		now   = mono.NanoTime()
+	)
+	ra.mu.Lock()
+	for uname, atime := range ra.ahead {
+		if time.Duration(now-atime) > raTTL {
+			delete(ra.ahead, uname)
+			waste++
+		}
+	}
+	for key, s := range ra.streams {
+		if time.Duration(now-s.atime) > raTTL {
+			delete(ra.streams, key)
+		}
+	}
+	ra.mu.Unlock()
+	if waste > 0 {
+		ra.t.statsT.Add(stats.ReadaheadWasteCount, waste)
+	}
+	return raTTL
+}
@@ -0,0 +1,99 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+func TestReadaheadParse(t *testing.T) {
+	tests := []struct {
+		objName string
+		prefix  string
+		num     int64
+		width   int
+		suffix  string
+		ok      bool
+	}{
+		{"train-000123.tar", "train-", 123, 6, ".tar", true},
+		{"shards/v2/train-000000.tar", "shards/v2/train-", 0, 6, ".tar", true},
+		{"img99", "img", 99, 2, "", true},
+		{"42", "", 42, 2, "", true},
+		{"a1b2c3.jpg", "a1b2c", 3, 1, ".jpg", true},
+		{"no-digits.tar", "", 0, 0, "", false},
+		{"x1234567890123456789.bin", "", 0, 0, "", false},
+	}
+	for _, test := range tests {
+		prefix, num, width, suffix, ok := raParse(test.objName)
+		if ok != test.ok {
+			t.Fatalf("%q: expected ok=%t", test.objName, test.ok)
+		}
+		if !ok {
+			continue
+		}
+		if prefix != test.prefix || num != test.num || width != test.width || suffix != test.suffix {
+			t.Errorf("%q: got (%q, %d, %d, %q)", test.objName, prefix, num, width, suffix)
+		}
+		if name := raName(prefix, num, width, suffix); name != test.objName {
+			t.Errorf("%q: round trip failed: %q", test.objName, name)
+		}
+	}
+	if name := raName("train-", 1000000, 6, ".tar"); name != "train-1000000.tar" {
+		t.Errorf("overflow: got %q", name)
+	}
+}
+
+func TestReadaheadDetect(tt *testing.T) {
+	var (
+		ra   = &readahead{t: t}
+		smap = newSmap()
+		bck  = meta.NewBck("ra-bck", apc.AWS, cmn.NsGlobal)
+	)
+	ra.streams = make(map[string]*raStream)
+	ra.ahead = make(map[string]int64)
+	smap.addTarget(t.si)
+	bck.Props = &cmn.Bprops{Readahead: cmn.ReadaheadConf{Window: 4}}
+
+	detect := func(key string, num int64) ([]string, bool) {
+		return ra._detect(key, bck, smap, "train-", num, 6, ".tar")
+	}
+
+	// first raMinSeq sequential GETs: nothing to read ahead
+	for num := int64(10); num < 10+raMinSeq; num++ {
+		if names, _ := detect("c1", num); len(names) != 0 {
+			tt.Fatalf("%d: unexpected readahead %v", num, names)
+		}
+	}
+	// detected: the next window objects (single target owns all)
+	names, sequential := detect("c1", 10+raMinSeq)
+	if !sequential || len(names) != 4 || names[0] != "train-000013.tar" || names[3] != "train-000016.tar" {
+		tt.Fatalf("expected train-000013.tar ... train-000016.tar, got %v (%t)", names, sequential)
+	}
+	if ra.inflight != 4 {
+		tt.Fatalf("expected 4 inflight, got %d", ra.inflight)
+	}
+	// next sequential GET: only what's not yet read-ahead
+	if names, _ = detect("c1", 13); len(names) != 1 || names[0] != "train-000017.tar" {
+		tt.Fatalf("expected train-000017.tar, got %v", names)
+	}
+
+	// another client (stream) with random access: never triggers
+	for _, num := range []int64{100, 7, 500, 3, 1000} {
+		if names, _ = detect("c2", num); len(names) != 0 {
+			tt.Fatalf("%d: unexpected readahead %v", num, names)
+		}
+	}
+	// going back resets the sequence
+	if names, sequential = detect("c1", 5); len(names) != 0 || !sequential {
+		tt.Fatalf("expected reset after sequential access, got %v (%t)", names, sequential)
+	}
+	if s := ra.streams["c1"]; s.seq != 0 {
+		tt.Fatalf("expected reset, got seq %d", s.seq)
+	}
+}
@@ -0,0 +1,485 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Replication ---------------------------------------------------------------------------
+// Bucket property `replication` (cmn.ReplicationConf) defines continuous asynchronous
+// replication of the bucket's objects (optionally, only those matching `prefix`) to
+// a remote AIS or cloud bucket (`dest`).
+//
+// Each target:
+// - appends object mutations (PUT-like writes and, if `deletes` is set, deletions)
+//   to its durable change log (kvdb), one record per object with the last mutation
+//   winning, and
+// - replays the log asynchronously, with retries and exponential backoff; same as
+//   write-back (ais/tgtwriteback.go), mutations of any given object are always
+//   handled by the same worker, one at a time.
+//
+// Successfully replicated object gets marked (cmn.ReplicatedObjMD => destination);
+// the mark is removed by any subsequent local write.
+//
+// Periodically (`reconcile_time`), each target scans its objects in the bucket and
+// enqueues those that are not marked as replicated to the current destination;
+// when `deletes` is enabled (and the bucket is an ais bucket), the scan also lists
+// the destination to delete objects that no longer exist locally.
+// ---------------------------------------------------------------------------------------
+
+const (
+	replCollection = "replication"
+	replWorkers    = 4
+
+	replRetryMin = time.Second
+	replRetryMax = 5 * time.Minute
+
+	replTick = time.Minute // check for reconciliation
+)
+
+// change log ops
+const (
+	replPut = "put"
+	replDel = "del"
+)
+
+type (
+	// persistent (kvdb) record
+	replRec struct {
+		Bck     cmn.Bck `json:"bck"`
+		ObjName string  `json:"name"`
+		Op      string  `json:"op"`   // replPut | replDel
+		Time    int64   `json:"time"` // when mutated (Unix nanoseconds)
+	}
+	replTask   = objqTask[replRec]
+	replicator struct {
+		t        *target
+		db       kvdb.Driver
+		scans    map[string]int64 // bucket cname => mono time of the last reconciliation
+		scanning map[string]bool
+		q        objq[replRec]
+		mu       sync.Mutex
+		// replication lag (see added, fini)
+		base    int64        // Unix time (ms)
+		pending atomic.Int64 // num pending mutations
+		ages    atomic.Int64 // sum of their times (ms) relative to base
+	}
+)
+
+func replEnabled(lom *core.LOM) bool {
+	conf := &lom.Bprops().Replication
+	return conf.Enabled && strings.HasPrefix(lom.ObjName, conf.Prefix)
+}
+
+func (r *replicator) init(t *target, db kvdb.Driver) {
+	r.t, r.db = t, db
+	r.scans = make(map[string]int64, 4)
+	r.scanning = make(map[string]bool, 4)
+	r.q.exec = r.replay
+	r.q.merge = replMerge
+	r.q.added = r.added
+	r.q.fini = r.fini
+	r.base = time.Now().UnixMilli()
+	r.q.retryMin, r.q.retryMax = replRetryMin, replRetryMax
+	r.q.init(replWorkers)
+	hk.Reg(replCollection+hk.NameSuffix, r.housekeep, replTick)
+}
+
+// (stats gauges)
+func (r *replicator) npending() int64 { return r.q.npending() }
+
+// average age of pending mutations (ms)
+func (r *replicator) lag() int64 {
+	n := r.pending.Load()
+	if n <= 0 {
+		return 0
+	}
+	return max(time.Now().UnixMilli()-r.base-r.ages.Load()/n, 0)
+}
+
+// (under lock) the last mutation wins while the first one determines the lag
+func replMerge(prev, curr *replRec) { prev.Op = curr.Op }
+
+// (under lock)
+func (r *replicator) added(task *replTask) {
+	r.pending.Inc()
+	r.ages.Add(task.rec.Time/int64(time.Millisecond) - r.base)
+}
+
+// (under lock) remove the record once replicated
+func (r *replicator) fini(task *replTask) {
+	r.pending.Dec()
+	r.ages.Sub(task.rec.Time/int64(time.Millisecond) - r.base)
+	if r.db == nil {
+		return
+	}
+	if err := r.db.Delete(replCollection, task.uname); err != nil && !cos.IsErrNotFound(err) {
+		nlog.Errorln(r.t.String(), "failed to delete replication record", task.uname+":", err)
+	}
+}
+
+// is called under the object's lock upon PUT-like write:
+// remove the "replicated" mark and, if enabled, append to the change log
+func (r *replicator) onPut(lom *core.LOM) error {
+	lom.ObjAttrs().DelCustomKeys(cmn.ReplicatedObjMD)
+	if !replEnabled(lom) {
+		return nil
+	}
+	return r.add(lom, replPut)
+}
+
+// is called under the object's lock upon deletion
+func (r *replicator) onDel(lom *core.LOM) {
+	if !replEnabled(lom) || !lom.Bprops().Replication.Deletes {
+		return
+	}
+	if err := r.add(lom, replDel); err != nil {
+		nlog.Errorln(err)
+	}
+}
+
+func (r *replicator) add(lom *core.LOM, op string) (err error) {
+	var (
+		uname = lom.Uname()
+		rec   = replRec{Bck: *lom.Bucket(), ObjName: lom.ObjName, Op: op, Time: time.Now().UnixNano()}
+		w     = r.q.worker(uname)
+	)
+	w.mu.Lock()
+	if err = r.db.Set(replCollection, uname, &rec); err == nil {
+		w._push(&replTask{rec: rec, uname: uname})
+	}
+	w.mu.Unlock()
+	if err != nil {
+		return cmn.NewErrFailedTo(r.t, "append replication log", lom.Cname(), err)
+	}
+	w.wakeup()
+	return nil
+}
+
+// upon startup: enqueue all persisted records
+func (r *replicator) resume() {
+	all, err := r.db.GetAll(replCollection, "")
+	if err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln(r.t.String(), "failed to load replication log:", err)
+		}
+		return
+	}
+	if len(all) == 0 {
+		return
+	}
+	for !r.t.ClusterStarted() {
+		if nlog.Stopping() {
+			return
+		}
+		time.Sleep(cmn.Rom.MaxKeepalive())
+	}
+	nlog.Infoln(r.t.String(), "resuming replication of", len(all), "object(s)")
+	for uname, v := range all {
+		var rec replRec
+		if err := cos.JSON.UnmarshalFromString(v, &rec); err != nil {
+			nlog.Errorln(r.t.String(), "failed to unmarshal replication record", uname+":", err)
+			continue
+		}
+		uname = string(rec.Bck.MakeUname(rec.ObjName))
+		r.q.worker(uname).push(&replTask{rec: rec, uname: uname})
+	}
+}
+
+////////////////
+// replicator //
+////////////////
+
+// destination bucket (must be present in the BMD - see proxy's httpbckpatch)
+func (r *replicator) dst(conf *cmn.ReplicationConf) (*meta.Bck, error) {
+	bck, err := conf.DestBck()
+	if err != nil {
+		return nil, err
+	}
+	dst := meta.CloneBck(&bck)
+	if err := dst.Init(r.t.owner.bmd); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// returns true to retry
+func (r *replicator) replay(task *replTask) bool {
+	rec := r.q.snap(task)
+	lom := core.AllocLOM(rec.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&rec.Bck); err != nil {
+		nlog.Warningln(r.t.String(), "replication: dropping", rec.Bck.Cname(rec.ObjName)+":", err)
+		return false
+	}
+	conf := lom.Bprops().Replication
+	if !conf.Enabled {
+		return false // disabled in the meantime
+	}
+	dst, err := r.dst(&conf)
+	if err != nil {
+		r.t.statsT.IncErr(stats.ErrReplCount)
+		nlog.Errorln(r.t.String(), "replication: invalid destination", conf.Dest+":", err)
+		return true
+	}
+
+	lom.Lock(false)
+	err = lom.Load(false /*cache it*/, true /*locked*/)
+	switch {
+	case err == nil:
+		return r.put(lom, dst) // (unlocks)
+	case cos.IsNotExist(err, 0) || cmn.IsErrObjNought(err):
+		lom.Unlock(false)
+		if rec.Op == replDel && conf.Deletes {
+			return r.del(lom, dst)
+		}
+		return false // migrated or deleted
+	default:
+		lom.Unlock(false)
+		nlog.Errorln(r.t.String(), "replication: failed to load", lom.Cname()+":", err)
+		return true
+	}
+}
+
+// is called under rlock (and unlocks)
+func (r *replicator) put(lom *core.LOM, dst *meta.Bck) bool {
+	dcname := dst.Cname("")
+	if v, ok := lom.GetCustomKey(cmn.ReplicatedObjMD); ok && v == dcname {
+		lom.Unlock(false)
+		return false // nothing to do
+	}
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		lom.Unlock(false)
+		return !os.IsNotExist(err)
+	}
+	fh, err := cos.NewFileHandle(lom.FQN)
+	size, cksum := lom.Lsize(), lom.Checksum()
+	lom.Unlock(false)
+	if err != nil {
+		nlog.Errorln(r.t.String(), "replication: failed to open", lom.Cname()+":", err)
+		return true
+	}
+
+	dlom := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(dlom)
+	if err := dlom.InitBck(dst.Bucket()); err != nil {
+		cos.Close(fh)
+		nlog.Errorln(r.t.String(), "replication:", err)
+		return true
+	}
+	dlom.SetSize(size)
+	dlom.SetCksum(cksum)
+	ecode, err := r.t.Backend(dst).PutObj(fh, dlom, nil /*origReq*/)
+	if err != nil {
+		r.t.statsT.IncErr(stats.ErrReplCount)
+		nlog.Errorf("%s: replication %s => %s failed: %v(%d)", r.t, lom.Cname(), dcname, err, ecode)
+		return true
+	}
+	r.t.statsT.AddMany(
+		cos.NamedVal64{Name: stats.ReplPutCount, Value: 1},
+		cos.NamedVal64{Name: stats.ReplPutSize, Value: size},
+	)
+	r.commit(lom, finfo, dcname)
+	return false
+}
+
+// under wlock: mark replicated unless overwritten in the meantime
+func (r *replicator) commit(replicated *core.LOM, finfo os.FileInfo, dcname string) {
+	lom := core.AllocLOM(replicated.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(replicated.Bucket()); err != nil {
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return
+	}
+	if curr, err := os.Stat(lom.FQN); err != nil || !os.SameFile(finfo, curr) {
+		return // overwritten (and re-added)
+	}
+	lom.SetCustomKey(cmn.ReplicatedObjMD, dcname)
+	if err := lom.PersistMain(); err != nil {
+		nlog.Errorln(r.t.String(), "replication: failed to persist", lom.Cname()+":", err)
+		return
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(r.t.String(), "replication:", lom.Cname(), "=>", dcname, "done")
+	}
+}
+
+func (r *replicator) del(lom *core.LOM, dst *meta.Bck) bool {
+	dlom := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(dlom)
+	if err := dlom.InitBck(dst.Bucket()); err != nil {
+		nlog.Errorln(r.t.String(), "replication:", err)
+		return true
+	}
+	ecode, err := r.t.Backend(dst).DeleteObj(dlom)
+	if err != nil && ecode != http.StatusNotFound {
+		r.t.statsT.IncErr(stats.ErrReplCount)
+		nlog.Errorf("%s: replication: failed to delete %s: %v(%d)", r.t, dlom.Cname(), err, ecode)
+		return true
+	}
+	r.t.statsT.Inc(stats.ReplDelCount)
+	return false
+}
+
+//
+// reconciliation
+//
+
+func (r *replicator) housekeep() time.Duration {
+	if !r.t.ClusterStarted() || nlog.Stopping() {
+		return replTick
+	}
+	var (
+		bmd = r.t.owner.bmd.get()
+		now = mono.NanoTime()
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		conf := &bck.Props.Replication
+		if !conf.Enabled || conf.ReconcileTime == 0 {
+			return false
+		}
+		cname := bck.Cname("")
+		r.mu.Lock()
+		last, ok := r.scans[cname]
+		if r.scanning[cname] || (ok && time.Duration(now-last) < conf.ReconcileTime.D()) {
+			r.mu.Unlock()
+			return false
+		}
+		r.scans[cname] = now
+		r.scanning[cname] = true
+		r.mu.Unlock()
+
+		go r.reconcile(bck, *conf)
+		return false
+	})
+	return replTick
+}
+
+func (r *replicator) reconcile(bck *meta.Bck, conf cmn.ReplicationConf) {
+	var (
+		cname  = bck.Cname("")
+		n, err = r._reconcile(bck, &conf)
+	)
+	r.mu.Lock()
+	delete(r.scanning, cname)
+	r.mu.Unlock()
+	if err != nil {
+		nlog.Errorln(r.t.String(), "replication: failed to reconcile", cname, "=>", conf.Dest+":", err)
+	} else if n > 0 {
+		nlog.Infoln(r.t.String(), "replication: reconciled", cname, "=>", conf.Dest, "- enqueued", n, "object(s)")
+	}
+}
+
+func (r *replicator) _reconcile(bck *meta.Bck, conf *cmn.ReplicationConf) (n int, err error) {
+	dst, err := r.dst(conf)
+	if err != nil {
+		return 0, err
+	}
+	dcname := dst.Cname("")
+	opts := &fs.WalkBckOpts{
+		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Sorted: true},
+	}
+	opts.WalkOpts.Bck.Copy(bck.Bucket())
+	opts.Callback = func(fqn string, _ fs.DirEntry) error {
+		if nlog.Stopping() {
+			return cmn.NewErrAborted(r.t.String(), "reconcile "+bck.Cname(""), nil)
+		}
+		lom := core.AllocLOM("")
+		defer core.FreeLOM(lom)
+		if lom.InitFQN(fqn, bck.Bucket()) != nil || !strings.HasPrefix(lom.ObjName, conf.Prefix) {
+			return nil
+		}
+		lom.Lock(false)
+		defer lom.Unlock(false)
+		if lom.Load(false /*cache it*/, true /*locked*/) != nil || !lom.IsHRW() {
+			return nil
+		}
+		if v, ok := lom.GetCustomKey(cmn.ReplicatedObjMD); ok && v == dcname {
+			return nil
+		}
+		if err := r.add(lom, replPut); err != nil {
+			return err
+		}
+		n++
+		return nil
+	}
+	if err = fs.WalkBck(opts); err != nil {
+		return n, err
+	}
+
+	// ais buckets only: local absence of a cloud object does not mean it was deleted
+	if !conf.Deletes || !bck.IsAIS() {
+		return n, nil
+	}
+	var (
+		smap    = r.t.owner.smap.get()
+		backend = r.t.Backend(dst)
+		msg     = &apc.LsoMsg{Prefix: conf.Prefix}
+	)
+	for {
+		lst := &cmn.LsoRes{}
+		if _, err = backend.ListObjects(dst, msg, lst); err != nil {
+			return n, err
+		}
+		for _, en := range lst.Entries {
+			tsi, err := smap.HrwName2T(bck.MakeUname(en.Name))
+			if err != nil {
+				return n, err
+			}
+			if tsi.ID() != r.t.SID() {
+				continue
+			}
+			if r._absent(bck, en.Name) {
+				n++
+			}
+		}
+		if lst.ContinuationToken == "" || nlog.Stopping() {
+			return n, nil
+		}
+		msg.ContinuationToken = lst.ContinuationToken
+	}
+}
+
+// (under rlock to serialize with concurrent PUT)
+func (r *replicator) _absent(bck *meta.Bck, objName string) bool {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if lom.InitBck(bck.Bucket()) != nil {
+		return false
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil || !cos.IsNotExist(err, 0) {
+		return false
+	}
+	if err := r.add(lom, replDel); err != nil {
+		nlog.Errorln(err)
+		return false
+	}
+	return true
+}
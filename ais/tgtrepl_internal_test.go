@@ -0,0 +1,42 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplicationLag(t *testing.T) {
+	var (
+		r = &replicator{}
+		w = newTestObjq(&r.q)
+	)
+	r.q.merge, r.q.added, r.q.fini = replMerge, r.added, r.fini
+	if lag := r.lag(); lag != 0 {
+		t.Fatalf("expected zero lag, got %d", lag)
+	}
+	w.push(&replTask{uname: "a", rec: replRec{Op: replPut, Time: 1}})
+	if lag := r.lag(); lag <= 0 {
+		t.Fatalf("expected positive lag, got %d", lag)
+	}
+	w.done(w.pop(), false /*retry*/)
+	if lag := r.lag(); lag != 0 {
+		t.Fatalf("expected zero lag, got %d", lag)
+	}
+
+	// average age; re-adding does not count
+	now := time.Now()
+	w.push(&replTask{uname: "a", rec: replRec{Op: replPut, Time: now.Add(-4 * time.Second).UnixNano()}})
+	w.push(&replTask{uname: "b", rec: replRec{Op: replPut, Time: now.UnixNano()}})
+	w.push(&replTask{uname: "a", rec: replRec{Op: replDel, Time: now.UnixNano()}})
+	if lag := r.lag(); lag < 2000 || lag > 3000 {
+		t.Fatalf("expected lag ~2s, got %dms", lag)
+	}
+	w.done(w.pop(), false)
+	if lag := r.lag(); lag > 1000 {
+		t.Fatalf("expected lag ~0, got %dms", lag)
+	}
+}
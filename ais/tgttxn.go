@@ -20,7 +20,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
-	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -112,6 +111,10 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, c.msg.Value, err)
 			return
 		}
+		if err := tcbmsg.Validate(msg.Action == apc.ActETLBck); err != nil { // (compiles rename rules)
+			t.writeErr(w, r, err)
+			return
+		}
 		if msg.Action == apc.ActETLBck {
 			var err error
 			if dp, err = etlDP(tcbmsg); err != nil {
@@ -129,6 +132,10 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 			t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, c.msg.Value, err)
 			return
 		}
+		if err := tcomsg.Validate(msg.Action == apc.ActETLObjects); err != nil { // ditto
+			t.writeErr(w, r, err)
+			return
+		}
 		if msg.Action == apc.ActETLObjects {
 			cs := fs.Cap()
 			if err := cs.Err(); err != nil {
@@ -159,6 +166,9 @@ func (t *target) txnHandler(w http.ResponseWriter, r *http.Request) {
 	if err == nil {
 		if xid != "" {
 			w.Header().Set(apc.HdrXactionID, xid)
+			if phase == apc.ActCommit {
+				xreg.SetOrigin(xid, &msg.ActMsg, msg.User)
+			}
 		}
 		return
 	}
@@ -505,13 +515,8 @@ func (t *target) validateBckRenTxn(bckFrom, bckTo *meta.Bck, msg *aisMsg) error
 	return nil
 }
 
+// (no K8s check: in-process ETLs run anywhere, and non-existing ETL fails regardless)
 func etlDP(msg *apc.TCBMsg) (core.DP, error) {
-	if !k8s.IsK8s() {
-		return nil, k8s.ErrK8sRequired
-	}
-	if err := msg.Validate(true); err != nil {
-		return nil, err
-	}
 	return etl.NewOfflineDP(msg, cmn.GCO.Get())
 }
 
@@ -0,0 +1,331 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Write-back ----------------------------------------------------------------------------
+// When bucket property `write_back.enabled` is set, PUT into a cloud bucket (or an ais
+// bucket with cloud backend) commits the object locally and returns - without calling
+// backend.PutObj(). Instead, the object is:
+// - marked pending via its custom metadata (cmn.WritebackObjMD => unique per-PUT token);
+// - recorded in the target's kvdb (to survive restarts), and
+// - enqueued for asynchronous upload.
+//
+// Uploads are executed by wbWorkers workers (see objq), with each object name always
+// handled by the same worker, which serializes uploads of any given object and guarantees
+// that the last (locally) written content is the one that gets uploaded last.
+//
+// Upload reads the object as it is at the time of the upload; the pending mark is cleared
+// only if the object was not overwritten in the meantime (same token). DELETE is not
+// ordered with an upload in flight - instead, an object deleted while being uploaded
+// gets deleted from the backend once again, when the upload completes.
+//
+// Failed uploads are retried with exponential backoff - unless the failure is permanent
+// (see wbTerminal) or the number of attempts exceeds wbMaxTries. Giving up on an object
+// replaces its pending mark with cmn.WritebackErrObjMD (=> the last error) - the object
+// stays in the cluster (but not in the backend) until overwritten, evicted, or deleted.
+//
+// Pending objects are never evicted by LRU (space/lru.go); rebalance migrates
+// the pending mark as part of the object's metadata (and the receiving target
+// enqueues the upload - see putOI.fini).
+// ---------------------------------------------------------------------------------------
+
+const (
+	wbCollection = "writeback"
+	wbWorkers    = 8
+
+	wbRetryMin = time.Second
+	wbRetryMax = time.Minute
+	wbMaxTries = 64 // (about an hour, given wbRetryMax)
+)
+
+type (
+	// persistent (kvdb) record
+	wbRec struct {
+		Bck     cmn.Bck `json:"bck"`
+		ObjName string  `json:"name"`
+		Undo    bool    `json:"undo,omitempty"` // deleted while being uploaded - to delete the uploaded copy
+	}
+	wbTask = objqTask[wbRec]
+
+	writeback struct {
+		t  *target
+		db kvdb.Driver
+		q  objq[wbRec]
+	}
+)
+
+func wbEnabled(lom *core.LOM, oreq *http.Request) bool {
+	bck := lom.Bck()
+	if !bck.IsRemote() || !bck.Props.WriteBack.Enabled {
+		return false
+	}
+	// presigned requests must be forwarded as is (synchronously)
+	return oreq == nil || !lom.IsFeatureSet(feat.S3PresignedRequest)
+}
+
+// permanent failures (e.g., access denied, missing bucket) - not to retry
+func wbTerminal(ecode int) bool {
+	switch ecode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+		http.StatusMethodNotAllowed, http.StatusGone:
+		return true
+	default:
+		return false
+	}
+}
+
+// unique (per PUT) token
+func wbToken() string { return strconv.FormatInt(time.Now().UnixNano(), 36) }
+
+func (wb *writeback) init(t *target, db kvdb.Driver) {
+	wb.t, wb.db = t, db
+	wb.q.exec = wb.upload
+	wb.q.retryMin, wb.q.retryMax = wbRetryMin, wbRetryMax
+	wb.q.init(wbWorkers)
+}
+
+// (num objects pending upload - stats gauge)
+func (wb *writeback) npending() int64 { return wb.q.npending() }
+
+// is called under wlock when committing PUT (or rebalance-received) object
+// that is pending write-back
+func (wb *writeback) add(lom *core.LOM) error {
+	var (
+		uname = lom.Uname()
+		rec   = wbRec{Bck: *lom.Bucket(), ObjName: lom.ObjName}
+	)
+	if err := wb.db.Set(wbCollection, uname, &rec); err != nil {
+		return cmn.NewErrFailedTo(wb.t, "persist write-back record", lom.Cname(), err)
+	}
+	wb.q.worker(uname).push(&wbTask{rec: rec, uname: uname})
+	return nil
+}
+
+// upon startup: enqueue all persisted records
+func (wb *writeback) resume() {
+	all, err := wb.db.GetAll(wbCollection, "")
+	if err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln(wb.t.String(), "failed to load write-back records:", err)
+		}
+		return
+	}
+	if len(all) == 0 {
+		return
+	}
+	for !wb.t.ClusterStarted() {
+		if nlog.Stopping() {
+			return
+		}
+		time.Sleep(cmn.Rom.MaxKeepalive())
+	}
+	nlog.Infoln(wb.t.String(), "resuming write-back of", len(all), "object(s)")
+	for uname, v := range all {
+		var rec wbRec
+		if err := cos.JSON.UnmarshalFromString(v, &rec); err != nil {
+			nlog.Errorln(wb.t.String(), "failed to unmarshal write-back record", uname+":", err)
+			continue
+		}
+		uname = string(rec.Bck.MakeUname(rec.ObjName))
+		wb.q.worker(uname).push(&wbTask{rec: rec, uname: uname})
+	}
+}
+
+///////////////
+// writeback //
+///////////////
+
+// returns true to retry
+func (wb *writeback) upload(task *wbTask) bool {
+	lom := core.AllocLOM(task.rec.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&task.rec.Bck); err != nil {
+		nlog.Warningln(wb.t.String(), "write-back: dropping", task.uname+":", err)
+		wb.del(task.uname)
+		return false
+	}
+
+	// 1. under rlock: open the current content and remember the token
+	lom.Lock(false)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if !cos.IsNotExist(err, 0) && !cmn.IsErrObjNought(err) {
+			lom.Unlock(false)
+			nlog.Errorln(wb.t.String(), "write-back: failed to load", lom.Cname()+":", err)
+			return true
+		}
+		if task.rec.Undo {
+			retry := wb.undo(lom, task, wb.t.Backend(lom.Bck()))
+			lom.Unlock(false)
+			return retry
+		}
+		wb.del(task.uname) // deleted or migrated
+		lom.Unlock(false)
+		return false
+	}
+	task.rec.Undo = false // (re-written)
+	token, ok := lom.GetCustomKey(cmn.WritebackObjMD)
+	if !ok {
+		wb.del(task.uname) // uploaded
+		lom.Unlock(false)
+		return false
+	}
+	fh, err := cos.NewFileHandle(lom.FQN)
+	lom.Unlock(false)
+	if err != nil {
+		nlog.Errorln(wb.t.String(), "write-back: failed to open", lom.Cname()+":", err)
+		return true
+	}
+
+	// 2. upload
+	var (
+		bck     = lom.Bck()
+		backend = wb.t.Backend(bck)
+	)
+	ecode, err := backend.PutObj(fh, lom, nil /*origReq*/)
+	if err != nil {
+		wb.t.statsT.IncErr(stats.WritebackCount)
+		nlog.Errorf("%s: write-back %s failed (attempt %d): %v(%d)", wb.t, lom.Cname(), task.tries+1, err, ecode)
+		if wbTerminal(ecode) || task.tries+1 >= wbMaxTries {
+			wb.fail(lom, token, task, err)
+			return false
+		}
+		return true
+	}
+	wb.t.statsT.AddMany(
+		cos.NamedVal64{Name: stats.WritebackCount, Value: 1},
+		cos.NamedVal64{Name: stats.WritebackSize, Value: lom.Lsize()},
+	)
+
+	// 3. under wlock: clear pending mark unless overwritten or deleted in the meantime
+	return wb.commit(lom, token, task, backend)
+}
+
+// returns true to retry
+func (wb *writeback) commit(uploaded *core.LOM, token string, task *wbTask, backend core.Backend) bool {
+	lom := core.AllocLOM(uploaded.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(uploaded.Bucket()); err != nil {
+		return false
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) || cmn.IsErrObjNought(err) {
+			return wb.undo(lom, task, backend)
+		}
+		return false
+	}
+	if tok, ok := lom.GetCustomKey(cmn.WritebackObjMD); !ok || tok != token {
+		return false // overwritten (and re-enqueued)
+	}
+	// custom metadata set by backend.PutObj (version, ETag, etc.)
+	for k, v := range uploaded.GetCustomMD() {
+		lom.SetCustomKey(k, v)
+	}
+	if v := uploaded.Version(); v != "" {
+		lom.SetVersion(v)
+	}
+	if !lom.Bck().IsRemoteAIS() {
+		lom.SetCustomKey(cmn.SourceObjMD, backend.Provider())
+	}
+	lom.ObjAttrs().DelCustomKeys(cmn.WritebackObjMD)
+	if err := lom.PersistMain(); err != nil {
+		nlog.Errorln(wb.t.String(), "write-back: failed to persist", lom.Cname()+":", err)
+		return false
+	}
+	wb.del(task.uname)
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln(wb.t.String(), "write-back:", lom.Cname(), "done")
+	}
+	return false
+}
+
+// (under lock) the object was deleted while being uploaded - the upload
+// that has landed after the deletion must not resurrect it
+// (unless migrated, in which case the new owner takes over)
+func (wb *writeback) undo(lom *core.LOM, task *wbTask, backend core.Backend) bool {
+	smap := wb.t.owner.smap.get()
+	if tsi, err := smap.HrwName2T(cos.UnsafeB(task.uname)); err != nil || tsi.ID() != wb.t.SID() {
+		task.rec.Undo = false
+		wb.del(task.uname)
+		return false
+	}
+	ecode, err := backend.DeleteObj(lom)
+	if err != nil && ecode != http.StatusNotFound {
+		wb.t.statsT.IncErr(stats.WritebackCount)
+		nlog.Errorf("%s: write-back: failed to delete %s uploaded after deletion: %v(%d)", wb.t, lom.Cname(), err, ecode)
+		if wbTerminal(ecode) || task.tries+1 >= wbMaxTries {
+			wb.t.statsT.IncErr(stats.ErrWritebackFailCount)
+			nlog.Errorln(wb.t.String(), "write-back: giving up on", lom.Cname(), "- remains in the backend")
+			task.rec.Undo = false
+			wb.del(task.uname)
+			return false
+		}
+		if !task.rec.Undo {
+			// persist, to keep retrying after restart (when the object is no longer there to tell)
+			task.rec.Undo = true
+			if err := wb.db.Set(wbCollection, task.uname, &task.rec); err != nil {
+				nlog.Errorln(wb.t.String(), "failed to persist write-back record", task.uname+":", err)
+			}
+		}
+		return true
+	}
+	task.rec.Undo = false
+	wb.del(task.uname)
+	nlog.Infoln(wb.t.String(), "write-back:", lom.Cname(), "deleted while being uploaded")
+	return false
+}
+
+// give up: replace the pending mark with the error (unless overwritten or deleted
+// in the meantime)
+func (wb *writeback) fail(uploaded *core.LOM, token string, task *wbTask, err error) {
+	wb.t.statsT.IncErr(stats.ErrWritebackFailCount)
+	nlog.Errorln(wb.t.String(), "write-back: giving up on", uploaded.Cname(), "after", task.tries+1, "attempt(s)")
+
+	lom := core.AllocLOM(uploaded.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(uploaded.Bucket()); err != nil {
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) || cmn.IsErrObjNought(err) {
+			wb.del(task.uname) // deleted (nothing got uploaded)
+		}
+		return
+	}
+	if tok, ok := lom.GetCustomKey(cmn.WritebackObjMD); !ok || tok != token {
+		return // overwritten (and re-enqueued)
+	}
+	lom.ObjAttrs().DelCustomKeys(cmn.WritebackObjMD)
+	lom.SetCustomKey(cmn.WritebackErrObjMD, err.Error())
+	if err := lom.PersistMain(); err != nil {
+		nlog.Errorln(wb.t.String(), "write-back: failed to persist", lom.Cname()+":", err)
+		return
+	}
+	wb.del(task.uname)
+}
+
+// (is called under the object's lock)
+func (wb *writeback) del(uname string) {
+	if err := wb.db.Delete(wbCollection, uname); err != nil && !cos.IsErrNotFound(err) {
+		nlog.Errorln(wb.t.String(), "failed to delete write-back record", uname+":", err)
+	}
+}
@@ -0,0 +1,281 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+func TestWritebackQueue(tt *testing.T) {
+	var (
+		wb = &writeback{}
+		w  = newTestObjq(&wb.q)
+	)
+
+	// same object enqueued twice: one task
+	w.push(&wbTask{uname: "a"})
+	w.push(&wbTask{uname: "a"})
+	w.push(&wbTask{uname: "b"})
+	if n := wb.npending(); n != 2 {
+		tt.Fatalf("expected 2 pending, got %d", n)
+	}
+
+	// re-added while being uploaded: uploaded again
+	task := w.pop()
+	if task == nil || task.uname != "a" {
+		tt.Fatalf("expected task %q, got %+v", "a", task)
+	}
+	w.push(&wbTask{uname: "a"})
+	w.done(task, false /*retry*/)
+	if n := wb.npending(); n != 2 {
+		tt.Fatalf("expected 2 pending, got %d", n)
+	}
+	if task = w.pop(); task == nil || task.uname != "b" {
+		tt.Fatalf("expected task %q, got %+v", "b", task)
+	}
+	w.done(task, false)
+	if task = w.pop(); task == nil || task.uname != "a" {
+		tt.Fatalf("expected task %q, got %+v", "a", task)
+	}
+	w.done(task, false)
+	if n := wb.npending(); n != 0 {
+		tt.Fatalf("expected none pending, got %d", n)
+	}
+	if task = w.pop(); task != nil {
+		tt.Fatalf("expected empty queue, got %+v", task)
+	}
+}
+
+type wbBackend struct {
+	core.Backend
+	remote  map[string]bool // uploaded objects
+	during  func()          // runs while uploading
+	delErrs int             // num DeleteObj calls to fail
+	putErr  int             // PutObj status code (when failing)
+}
+
+func (*wbBackend) Provider() string { return apc.AWS }
+
+func (bp *wbBackend) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (int, error) {
+	cos.Close(r)
+	if bp.putErr != 0 {
+		return bp.putErr, errors.New("put failed")
+	}
+	if bp.during != nil {
+		bp.during()
+	}
+	bp.remote[lom.ObjName] = true
+	return 0, nil
+}
+
+func (bp *wbBackend) DeleteObj(lom *core.LOM) (int, error) {
+	if bp.delErrs > 0 {
+		bp.delErrs--
+		return http.StatusInternalServerError, errors.New("delete failed")
+	}
+	if !bp.remote[lom.ObjName] {
+		return http.StatusNotFound, cos.NewErrNotFound(nil, lom.Cname())
+	}
+	delete(bp.remote, lom.ObjName)
+	return 0, nil
+}
+
+func wbTestInit(tt *testing.T) (*meta.Bck, *wbBackend, kvdb.Driver) {
+	var (
+		bck    = meta.NewBck("wb-bck", apc.AWS, cmn.NsGlobal)
+		config = cmn.GCO.Get()
+		bp     = &wbBackend{remote: make(map[string]bool)}
+	)
+	bmd := t.owner.bmd.get().clone()
+	if _, present := bmd.Get(bck); !present {
+		bmd.add(bck, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}})
+		t.owner.bmd.putPersist(bmd, nil)
+	}
+	fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	if config.Backend.Providers == nil {
+		config.Backend.Providers = make(map[string]cmn.Ns, 1)
+	}
+	config.Backend.Providers[apc.AWS] = cmn.NsGlobal
+	tt.Cleanup(func() { delete(config.Backend.Providers, apc.AWS) })
+	t.backend[apc.AWS] = bp
+	tt.Cleanup(func() { delete(t.backend, apc.AWS) })
+	if smap := t.owner.smap.get(); smap == nil || smap.GetTarget(t.SID()) == nil {
+		smap = newSmap()
+		smap.addTarget(t.si)
+		t.owner.smap.put(smap)
+	}
+	db, err := kvdb.NewBuntDB(filepath.Join(tt.TempDir(), "wb.db"))
+	if err != nil {
+		tt.Fatal(err)
+	}
+	return bck, bp, db
+}
+
+// PUT pending write-back (and return the respective task)
+func wbTestPut(tt *testing.T, wb *writeback, bck *meta.Bck, objName string) *wbTask {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		tt.Fatal(err)
+	}
+	if err := cos.CreateDir(filepath.Dir(lom.FQN)); err != nil {
+		tt.Fatal(err)
+	}
+	if err := os.WriteFile(lom.FQN, []byte("data"), cos.PermRWR); err != nil {
+		tt.Fatal(err)
+	}
+	lom.SetSize(4)
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	lom.SetCustomKey(cmn.WritebackObjMD, wbToken())
+	if err := lom.PersistMain(); err != nil {
+		tt.Fatal(err)
+	}
+	rec := wbRec{Bck: *bck.Bucket(), ObjName: objName}
+	if err := wb.db.Set(wbCollection, lom.Uname(), &rec); err != nil {
+		tt.Fatal(err)
+	}
+	return &wbTask{rec: rec, uname: lom.Uname()}
+}
+
+// concurrent DELETE (see target.httpobjdelete)
+func wbTestDelete(tt *testing.T, bck *meta.Bck, objName string) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		tt.Fatal(err)
+	}
+	lom.Lock(true)
+	_, err, _ := t.delobj(lom, false /*evict*/)
+	lom.Unlock(true)
+	if err != nil {
+		tt.Fatal(err)
+	}
+}
+
+func TestWritebackDeleteDuringUpload(tt *testing.T) {
+	bck, bp, db := wbTestInit(tt)
+	wb := &writeback{t: t, db: db}
+
+	for _, deleted := range []bool{false, true} {
+		objName := "obj-" + strconv.FormatBool(deleted)
+		task := wbTestPut(tt, wb, bck, objName)
+
+		// DELETE that lands while uploading
+		bp.during = nil
+		if deleted {
+			bp.during = func() { wbTestDelete(tt, bck, objName) }
+		}
+		if retry := wb.upload(task); retry {
+			tt.Fatalf("%s: unexpected retry", objName)
+		}
+		if bp.remote[objName] == deleted {
+			tt.Errorf("%s: expected remote copy to exist: %t", objName, !deleted)
+		}
+	}
+}
+
+// restart while (failing to) delete the copy uploaded after deletion
+func TestWritebackUndoRestart(tt *testing.T) {
+	var (
+		bck, bp, db = wbTestInit(tt)
+		wb          = &writeback{t: t, db: db}
+		objName     = "obj-undo"
+		task        = wbTestPut(tt, wb, bck, objName)
+	)
+	bp.during = func() {
+		wbTestDelete(tt, bck, objName)
+		bp.delErrs = 1 // (and fail to undo the upload)
+	}
+	if retry := wb.upload(task); !retry {
+		tt.Fatal("expected retry")
+	}
+	if !bp.remote[objName] {
+		tt.Fatal("expected remote copy (failed to delete)")
+	}
+
+	// restart: the persisted record is all there is
+	var rec wbRec
+	if err := db.Get(wbCollection, task.uname, &rec); err != nil {
+		tt.Fatal(err)
+	}
+	if !rec.Undo {
+		tt.Fatalf("expected persisted undo, got %+v", rec)
+	}
+	wb = &writeback{t: t, db: db}
+	bp.during = nil
+	if retry := wb.upload(&wbTask{rec: rec, uname: task.uname}); retry {
+		tt.Fatal("unexpected retry")
+	}
+	if bp.remote[objName] {
+		tt.Fatal("expected remote copy to be deleted")
+	}
+	if err := db.Get(wbCollection, task.uname, &rec); !cos.IsErrNotFound(err) {
+		tt.Fatalf("expected record to be deleted, got %v (%+v)", err, rec)
+	}
+}
+
+func TestWritebackFail(tt *testing.T) {
+	bck, bp, db := wbTestInit(tt)
+	wb := &writeback{t: t, db: db}
+
+	tests := []struct {
+		name  string
+		ecode int
+		tries int
+		retry bool
+	}{
+		{name: "transient", ecode: http.StatusServiceUnavailable, retry: true},
+		{name: "forbidden", ecode: http.StatusForbidden},
+		{name: "no-bucket", ecode: http.StatusNotFound},
+		{name: "max-tries", ecode: http.StatusServiceUnavailable, tries: wbMaxTries - 1},
+	}
+	for _, test := range tests {
+		tt.Run(test.name, func(tt *testing.T) {
+			task := wbTestPut(tt, wb, bck, "obj-"+test.name)
+			task.tries = test.tries
+			bp.putErr = test.ecode
+			if retry := wb.upload(task); retry != test.retry {
+				tt.Fatalf("expected retry %t, got %t", test.retry, retry)
+			}
+
+			lom := core.AllocLOM(task.rec.ObjName)
+			defer core.FreeLOM(lom)
+			if err := lom.InitBck(bck.Bucket()); err != nil {
+				tt.Fatal(err)
+			}
+			if err := lom.Load(false, false); err != nil {
+				tt.Fatal(err)
+			}
+			_, pending := lom.GetCustomKey(cmn.WritebackObjMD)
+			_, failed := lom.GetCustomKey(cmn.WritebackErrObjMD)
+			var rec wbRec
+			errDB := db.Get(wbCollection, task.uname, &rec)
+			if test.retry {
+				if !pending || failed || errDB != nil {
+					tt.Fatalf("expected pending (%t, %t, %v)", pending, failed, errDB)
+				}
+				return
+			}
+			if pending || !failed || !cos.IsErrNotFound(errDB) {
+				tt.Fatalf("expected failed (%t, %t, %v)", pending, failed, errDB)
+			}
+		})
+	}
+}
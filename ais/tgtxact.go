@@ -86,7 +86,7 @@ func (t *target) httpxget(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	xactQuery := xreg.Flt{
-		ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck, OnlyRunning: xactMsg.OnlyRunning,
+		ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck, OnlyRunning: xactMsg.OnlyRunning, Since: xactMsg.Since.D(),
 	}
 	t.xquery(w, r, what, xactQuery)
 }
@@ -96,7 +96,7 @@ func (t *target) httpxput(w http.ResponseWriter, r *http.Request) {
 		xargs xact.ArgsMsg
 		bck   *meta.Bck
 	)
-	msg, err := t.readActionMsg(w, r)
+	msg, err := t.readAisMsg(w, r)
 	if err != nil {
 		return
 	}
@@ -123,15 +123,28 @@ func (t *target) httpxput(w http.ResponseWriter, r *http.Request) {
 			ecode, err := t.runPrefetch(xargs.ID, bck, &apc.PrefetchMsg{})
 			if err != nil {
 				t.writeErr(w, r, err, ecode)
+				return
 			}
+			xreg.SetOrigin(xargs.ID, &msg.ActMsg, msg.User)
+			return
+		}
+		if xargs.Kind == apc.ActCreateInventory {
+			if err := t.runInventory(xargs.ID, bck, &cmn.InvMsg{}); err != nil {
+				t.writeErr(w, r, err)
+				return
+			}
+			xreg.SetOrigin(xargs.ID, &msg.ActMsg, msg.User)
 			return
 		}
 		// all other "startables"
-		xid, err := t.xstart(&xargs, bck, msg)
+		xid, err := t.xstart(&xargs, bck, &msg.ActMsg)
 		if err != nil {
 			t.writeErr(w, r, err)
 			return
 		}
+		if xid != "" {
+			xreg.SetOrigin(xid, &msg.ActMsg, msg.User)
+		}
 		if l := len(xid); l > 0 {
 			w.Header().Set(cos.HdrContentLength, strconv.Itoa(l))
 			w.Write([]byte(xid))
@@ -163,6 +176,10 @@ func (t *target) xget(w http.ResponseWriter, r *http.Request, what, uuid string)
 		t.writeJSON(w, r, xctn.Snap(), what)
 		return
 	}
+	if snap := xreg.GetHistSnap(uuid); snap != nil {
+		t.writeJSON(w, r, snap, what)
+		return
+	}
 	err = cmn.NewErrXactNotFoundError("[" + uuid + "]")
 	t.writeErr(w, r, err, http.StatusNotFound, Silent)
 }
@@ -231,6 +248,9 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 	case apc.ActLoadLomCache:
 		rns := xreg.RenewBckLoadLomCache(args.ID, bck)
 		return xid, rns.Err
+	case apc.ActIndexShards:
+		rns := xreg.RenewBckArchIdx(args.ID, bck)
+		return xid, rns.Err
 	case apc.ActBlobDl:
 		debug.Assert(msg.Name != "")
 		lom := core.AllocLOM(msg.Name)
@@ -83,6 +83,11 @@ const (
 
 	// permission to perform cluster-level ops
 	AccessCluster = AceListBuckets | AceCreateBucket | AceDestroyBucket | AceMoveBucket | AceAdmin
+
+	// data-modifying ops that are rejected when the bucket (or the entire cluster) is read-only
+	// (bucket props are separately handled - see "fence" in the docs)
+	AccessMut = AcePUT | AceAPPEND | AceObjDELETE | AceObjMOVE | AcePromote | AceObjUpdate |
+		AceCreateBucket | AceDestroyBucket | AceMoveBucket
 )
 
 // verbs
@@ -49,18 +49,21 @@ const (
 	ActLRU          = "lru"
 	ActStoreCleanup = "cleanup-store"
 
-	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
-	ActInvalListCache = "inval-listobj-cache"
-	ActList           = "list"
-	ActLoadLomCache   = "load-lom-cache"
-	ActNewPrimary     = "new-primary"
-	ActPromote        = "promote"
-	ActRenameObject   = "rename-obj"
+	ActCreateInventory = "create-inventory" // generate bucket inventory (see also: HdrInventory)
+	ActEvictRemoteBck  = "evict-remote-bck" // evict remote bucket's data
+	ActIndexShards     = "index-shards"     // generate random-access index sidecars for TAR shards
+	ActInvalListCache  = "inval-listobj-cache"
+	ActList            = "list"
+	ActLoadLomCache    = "load-lom-cache"
+	ActNewPrimary      = "new-primary"
+	ActPromote         = "promote"
+	ActRenameObject    = "rename-obj"
 
 	// cp (reverse)
-	ActResetStats  = "reset-stats"
-	ActResetConfig = "reset-config"
-	ActSetConfig   = "set-config"
+	ActResetStats     = "reset-stats"
+	ActResetConfig    = "reset-config"
+	ActSetConfig      = "set-config"
+	ActRollbackConfig = "rollback-config" // to one of the (primary-retained) prior versions
 
 	ActRotateLogs = "rotate-logs"
 
@@ -108,6 +111,8 @@ const (
 	ActStartGFN       = "start-gfn"      // get-from-neighbor
 	ActStopGFN        = "stop-gfn"       // off
 	ActCleanupMarkers = "cleanup-markers"
+	ActSyncJobQueue   = "sync-job-queue" // primary => proxies (see QparamQueue)
+	ActSyncCfgHistory = "sync-cfg-hist"  // primary => proxies (see WhatConfigHistory)
 )
 
 const (
@@ -108,6 +108,7 @@ const (
 	EntryIsArchive  = 1 << (EntryStatusBits + 4)
 	EntryVerChanged = 1 << (EntryStatusBits + 5) // see also: QparamLatestVer, et al.
 	EntryVerRemoved = 1 << (EntryStatusBits + 6) // ditto
+	EntryIsPending  = 1 << (EntryStatusBits + 7) // write-back: not yet uploaded to remote backend
 )
 
 // ObjEntry.Flags field
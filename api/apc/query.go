@@ -13,7 +13,8 @@ const (
 
 	QparamUUID    = "uuid"     // xaction
 	QparamJobID   = "jobid"    // job
-	QparamETLName = "etl_name" // etl
+	QparamETLName = "etl_name" // etl name or pipeline, e.g. "decode,augment:30s,encode" (see ParseETLPipeline)
+	QparamETLArgs = "etl_args" // etl: opaque, transformer-specific, per-request arguments (e.g., resize target)
 
 	QparamRegex      = "regex"       // dsort: list regex
 	QparamOnlyActive = "only_active" // dsort: list only active
@@ -127,6 +128,17 @@ const (
 	// deleted objects
 	QparamSync = "synchronize"
 
+	// config_diff: two cluster config versions to compare (see WhatConfigDiff)
+	QparamFromVer = "from-ver"
+	QparamToVer   = "to-ver"
+
+	// when conflicting with a running xaction (see cmn.ErrLimitedCoexistence), queue the job
+	// with a given (integer) priority - rather than fail (see also: WhatJobQueue)
+	QparamQueue = "queue"
+
+	// drain_status: target ID (see WhatDrainStatus)
+	QparamNodeID = "node-id"
+
 	// when true, skip nlog.Error and friends
 	// (to opt-out logging too many messages and/or benign warnings)
 	QparamSilent = "sln"
@@ -215,6 +227,14 @@ const (
 	// config
 	WhatNodeConfig    = "config" // query specific node for (cluster config + overrides, local config)
 	WhatClusterConfig = "cluster_config"
+	WhatConfigHistory = "config_history" // cluster config and bucket props change history (primary)
+	WhatConfigDiff    = "config_diff"    // diff between two retained cluster config versions
+
+	// target drain: remaining objects and bytes, throughput, ETA (see cmn.DrainStatus)
+	WhatDrainStatus = "drain_status"
+
+	// queued jobs and their current states (see QparamQueue)
+	WhatJobQueue = "job_queue"
 
 	// stats and status
 	WhatNodeStatsV322          = "stats"  // [ backward compatibility ]
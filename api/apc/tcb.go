@@ -6,9 +6,13 @@ package apc
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
 )
 
 // copy & (offline) transform bucket to bucket
@@ -18,12 +22,49 @@ type (
 		Prefix    string `json:"prefix"`      // prefix to select matching _source_ objects or virtual directories
 		DryRun    bool   `json:"dry_run"`     // visit all source objects, don't make any modifications
 		Force     bool   `json:"force"`       // force running in presence of "limited coexistence" type conflicts
+		Queue     int    `json:"queue"`       // or else, queue the job with a given (non-zero) priority (see QparamQueue)
 		LatestVer bool   `json:"latest-ver"`  // see also: QparamLatestVer, 'versioning.validate_warm_get', PrefetchMsg
 		Sync      bool   `json:"synchronize"` // see also: 'versioning.synchronize'
+
+		// destination naming: the first matching rule (if any) applies; see also: Prepend, TCBMsg.Ext
+		Rename []RenameRule `json:"rename,omitempty"`
+		// select _source_ objects by size, time, and custom metadata (in addition to Prefix or list/range)
+		Filter *ObjFilter `json:"filter,omitempty"`
+	}
+	// e.g., {Regex: "^images/(.*)\\.jpeg$", Replace: "img/${1}.jpg"}
+	RenameRule struct {
+		Regex   string `json:"regex"`
+		Replace string `json:"replace"` // may reference capture groups: $1, ${1}, ${name}
+		re      *regexp.Regexp
+	}
+	// all specified conditions must hold; time values are Unix nanoseconds, zero meaning "not specified";
+	// time ranges are [after, before); size range is [min, max] - nil MaxSize meaning "not specified"
+	// (and zero - empty objects only)
+	ObjFilter struct {
+		MinSize     int64      `json:"min_size,omitempty"`
+		MaxSize     *int64     `json:"max_size,omitempty"`
+		MtimeAfter  int64      `json:"mtime_after,omitempty"`
+		MtimeBefore int64      `json:"mtime_before,omitempty"`
+		AtimeAfter  int64      `json:"atime_after,omitempty"`
+		AtimeBefore int64      `json:"atime_before,omitempty"`
+		CustomMD    cos.StrKVs `json:"custom_md,omitempty"` // empty value: the key must be present (any value)
 	}
 	Transform struct {
 		Name    string       `json:"id,omitempty"`
 		Timeout cos.Duration `json:"request_timeout,omitempty"`
+		// opaque, transformer-specific arguments forwarded to the ETL container with each object
+		// (see QparamETLArgs); apply to all pipeline stages that do not specify their own
+		Args string `json:"args,omitempty"`
+		// one-to-many: ETL (or the last stage of the pipeline) responds with a TAR stream, and each
+		// member becomes a separate destination object named "<destination name>/<member name>"
+		FanOut bool `json:"fan_out,omitempty"`
+		// ETL pipeline: subsequent stages, if any, each transforming the output of the previous one
+		Pipeline []ETLStage `json:"pipeline,omitempty"`
+	}
+	ETLStage struct {
+		Name    string       `json:"id"`
+		Timeout cos.Duration `json:"request_timeout,omitempty"`
+		Args    string       `json:"args,omitempty"`
 	}
 	TCBMsg struct {
 		// NOTE: objname extension ----------------------------------------------------------------------
@@ -43,14 +84,92 @@ type (
 ////////////
 
 func (msg *TCBMsg) Validate(isEtl bool) (err error) {
-	if isEtl && msg.Transform.Name == "" {
-		err = errors.New("ETL name can't be empty")
+	if err := msg.CopyBckMsg.Validate(); err != nil {
+		return err
+	}
+	if !isEtl {
+		return nil
+	}
+	if msg.Transform.Name == "" {
+		return errors.New("ETL name can't be empty")
+	}
+	for i := range msg.Transform.Pipeline {
+		if msg.Transform.Pipeline[i].Name == "" {
+			return fmt.Errorf("ETL pipeline: stage #%d has no name", i+2)
+		}
+	}
+	return nil
+}
+
+///////////////
+// Transform //
+///////////////
+
+// all pipeline stages, in order (a single one when there's no pipeline)
+func (t *Transform) Stages() []ETLStage {
+	stages := make([]ETLStage, 0, 1+len(t.Pipeline))
+	stages = append(stages, ETLStage{Name: t.Name, Timeout: t.Timeout, Args: t.Args})
+	stages = append(stages, t.Pipeline...)
+	for i := 1; i < len(stages); i++ {
+		if stages[i].Args == "" {
+			stages[i].Args = t.Args
+		}
+	}
+	return stages
+}
+
+// ParseETLPipeline parses comma-separated ETL names with optional per-stage timeouts,
+// e.g. "decode,augment:30s,encode" (see also: QparamETLName)
+func ParseETLPipeline(spec string) (stages []ETLStage, err error) {
+	for _, s := range strings.Split(spec, ",") {
+		var (
+			stage ETLStage
+			name  = strings.TrimSpace(s)
+		)
+		if i := strings.IndexByte(name, ':'); i >= 0 {
+			d, err := time.ParseDuration(name[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid ETL pipeline %q: stage %q: %v", spec, name, err)
+			}
+			stage.Timeout = cos.Duration(d)
+			name = name[:i]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("invalid ETL pipeline %q: empty stage name", spec)
+		}
+		stage.Name = name
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+// (compare with ParseETLPipeline)
+func ETLPipelineSpec(stages []ETLStage) string {
+	var sb strings.Builder
+	for i := range stages {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(stages[i].Name)
+		if stages[i].Timeout != 0 {
+			sb.WriteByte(':')
+			sb.WriteString(stages[i].Timeout.String())
+		}
 	}
-	return
+	return sb.String()
 }
 
-// Replace extension and add suffix if provided.
+// Rename (the first matching rule), replace extension, and prepend - in that order.
 func (msg *TCBMsg) ToName(name string) string {
+	for i := range msg.Rename {
+		rule := &msg.Rename[i]
+		debug.Assert(rule.re != nil, "not validated: ", rule.Regex)
+		if loc := rule.re.FindStringSubmatchIndex(name); loc != nil {
+			dst := rule.re.ExpandString(nil, rule.Replace, name, loc)
+			name = name[:loc[0]] + string(dst) + name[loc[1]:]
+			break
+		}
+	}
 	if msg.Ext != nil {
 		if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
 			ext := name[idx+1:]
@@ -64,3 +183,73 @@ func (msg *TCBMsg) ToName(name string) string {
 	}
 	return name
 }
+
+////////////////
+// CopyBckMsg //
+////////////////
+
+// NOTE: compiles rename rules - must be called prior to TCBMsg.ToName
+func (msg *CopyBckMsg) Validate() error {
+	if msg.Sync && len(msg.Rename) > 0 {
+		return errors.New("rename rules are incompatible with the request to synchronize buckets")
+	}
+	for i := range msg.Rename {
+		rule := &msg.Rename[i]
+		if rule.Regex == "" {
+			return fmt.Errorf("rename rule #%d: empty regex", i+1)
+		}
+		re, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return fmt.Errorf("rename rule #%d: invalid regex %q: %v", i+1, rule.Regex, err)
+		}
+		rule.re = re
+	}
+	if msg.Filter != nil {
+		return msg.Filter.validate()
+	}
+	return nil
+}
+
+///////////////
+// ObjFilter //
+///////////////
+
+func (f *ObjFilter) validate() error {
+	var maxSize int64
+	if f.MaxSize != nil {
+		maxSize = *f.MaxSize
+	}
+	switch {
+	case f.MinSize < 0 || maxSize < 0:
+		return fmt.Errorf("invalid object filter: negative size (%d, %d)", f.MinSize, maxSize)
+	case f.MaxSize != nil && f.MinSize > maxSize:
+		return fmt.Errorf("invalid object filter: min size %d is greater than max size %d", f.MinSize, maxSize)
+	case f.MtimeBefore > 0 && f.MtimeAfter >= f.MtimeBefore:
+		return errors.New("invalid object filter: empty mtime range")
+	case f.AtimeBefore > 0 && f.AtimeAfter >= f.AtimeBefore:
+		return errors.New("invalid object filter: empty atime range")
+	}
+	return nil
+}
+
+// mtime is not part of object metadata - the caller may want to skip (stat-ing) when not needed
+func (f *ObjFilter) NeedMtime() bool { return f.MtimeAfter > 0 || f.MtimeBefore > 0 }
+
+func (f *ObjFilter) Match(size, mtime, atime int64, getCustom func(key string) (string, bool)) bool {
+	if size < f.MinSize || (f.MaxSize != nil && size > *f.MaxSize) {
+		return false
+	}
+	if !inRange(mtime, f.MtimeAfter, f.MtimeBefore) || !inRange(atime, f.AtimeAfter, f.AtimeBefore) {
+		return false
+	}
+	for k, v := range f.CustomMD {
+		if value, ok := getCustom(k); !ok || (v != "" && v != value) {
+			return false
+		}
+	}
+	return true
+}
+
+func inRange(t, after, before int64) bool {
+	return (after == 0 || t >= after) && (before == 0 || t < before)
+}
@@ -0,0 +1,189 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestParseETLPipeline(t *testing.T) {
+	tests := []struct {
+		spec   string
+		stages []apc.ETLStage
+		canon  string // ETLPipelineSpec(stages), when different from spec
+	}{
+		{spec: "md5", stages: []apc.ETLStage{{Name: "md5"}}},
+		{spec: "md5:30s", stages: []apc.ETLStage{{Name: "md5", Timeout: cos.Duration(30 * time.Second)}}},
+		{
+			spec: "decode,augment:1m30s,encode",
+			stages: []apc.ETLStage{
+				{Name: "decode"},
+				{Name: "augment", Timeout: cos.Duration(90 * time.Second)},
+				{Name: "encode"},
+			},
+		},
+		{
+			spec: " decode , encode:90s",
+			stages: []apc.ETLStage{
+				{Name: "decode"},
+				{Name: "encode", Timeout: cos.Duration(90 * time.Second)},
+			},
+			canon: "decode,encode:1m30s",
+		},
+	}
+	for _, test := range tests {
+		stages, err := apc.ParseETLPipeline(test.spec)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(stages) == len(test.stages), "%q: expected %d stages, got %+v", test.spec, len(test.stages), stages)
+		for i := range stages {
+			tassert.Errorf(t, stages[i] == test.stages[i], "%q: stage #%d: expected %+v, got %+v",
+				test.spec, i, test.stages[i], stages[i])
+		}
+
+		// round-trip
+		canon := test.canon
+		if canon == "" {
+			canon = test.spec
+		}
+		spec := apc.ETLPipelineSpec(stages)
+		tassert.Errorf(t, spec == canon, "expected %q, got %q", canon, spec)
+		again, err := apc.ParseETLPipeline(spec)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, len(again) == len(stages), "%q: round-trip mismatch: %+v vs %+v", spec, again, stages)
+	}
+}
+
+func TestParseETLPipelineInvalid(t *testing.T) {
+	for _, spec := range []string{"", ",", "decode,,encode", "decode,", ":30s", "decode:", "decode:30", "decode:abc", "decode:30s:1m"} {
+		_, err := apc.ParseETLPipeline(spec)
+		tassert.Errorf(t, err != nil, "expected %q to fail", spec)
+	}
+}
+
+func TestTCBToName(t *testing.T) {
+	rules := []apc.RenameRule{
+		{Regex: `^images/(?P<base>.*)\.jpeg$`, Replace: "img/${base}.jpg"},
+		{Regex: `^images/(.*)$`, Replace: "other/$1"},
+		{Regex: `\.tmp$`, Replace: ".bak"},
+	}
+	tests := []struct {
+		name    string
+		ext     cos.StrKVs
+		prepend string
+		rename  bool
+		out     string
+	}{
+		// first matching rule wins
+		{name: "images/cat.jpeg", rename: true, out: "img/cat.jpg"},
+		{name: "images/cat.png", rename: true, out: "other/cat.png"},
+		{name: "images/cat.tmp", rename: true, out: "other/cat.tmp"},
+		// only the match is replaced
+		{name: "data/x.tmp", rename: true, out: "data/x.bak"},
+		{name: "data/x.txt", rename: true, out: "data/x.txt"},
+		// rename, then extension, then prepend
+		{name: "images/cat.jpeg", rename: true, ext: cos.StrKVs{"jpg": "png"}, out: "img/cat.png"},
+		{name: "images/cat.jpeg", rename: true, ext: cos.StrKVs{"jpeg": "png"}, out: "img/cat.jpg"},
+		{name: "images/cat.jpeg", rename: true, prepend: "v2/", out: "v2/img/cat.jpg"},
+		{name: "images/cat.jpeg", ext: cos.StrKVs{"jpeg": ".png"}, prepend: "v2/", out: "v2/images/cat.png"},
+		{name: "noext", ext: cos.StrKVs{"jpeg": "png"}, out: "noext"},
+	}
+	for _, test := range tests {
+		msg := &apc.TCBMsg{Ext: test.ext}
+		msg.Prepend = test.prepend
+		if test.rename {
+			msg.Rename = append([]apc.RenameRule(nil), rules...)
+		}
+		tassert.CheckFatal(t, msg.Validate(false))
+		out := msg.ToName(test.name)
+		tassert.Errorf(t, out == test.out, "%q (rename=%t, ext=%v, prepend=%q): expected %q, got %q",
+			test.name, test.rename, test.ext, test.prepend, test.out, out)
+	}
+}
+
+func TestCopyBckMsgValidate(t *testing.T) {
+	var (
+		zero  int64
+		ten   int64 = 10
+		minus int64 = -1
+	)
+	tests := []struct {
+		msg apc.CopyBckMsg
+		ok  bool
+	}{
+		{msg: apc.CopyBckMsg{}, ok: true},
+		{msg: apc.CopyBckMsg{Rename: []apc.RenameRule{{Regex: "a", Replace: "b"}}}, ok: true},
+		{msg: apc.CopyBckMsg{Rename: []apc.RenameRule{{Regex: "", Replace: "b"}}}},
+		{msg: apc.CopyBckMsg{Rename: []apc.RenameRule{{Regex: "(", Replace: "b"}}}},
+		{msg: apc.CopyBckMsg{Sync: true, Rename: []apc.RenameRule{{Regex: "a", Replace: "b"}}}},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{MinSize: 10, MaxSize: &ten}}, ok: true},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{MaxSize: &zero}}, ok: true},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{MinSize: 1, MaxSize: &zero}}},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{MaxSize: &minus}}},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{MinSize: -1}}},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{MtimeAfter: 5, MtimeBefore: 5}}},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{AtimeAfter: 6, AtimeBefore: 5}}},
+		{msg: apc.CopyBckMsg{Filter: &apc.ObjFilter{AtimeAfter: 6}}, ok: true},
+	}
+	for i, test := range tests {
+		err := test.msg.Validate()
+		tassert.Errorf(t, (err == nil) == test.ok, "#%d: expected ok=%t, got %v", i, test.ok, err)
+	}
+}
+
+func TestObjFilterMatch(t *testing.T) {
+	var (
+		zero int64
+		ten  int64 = 10
+		md         = map[string]string{"owner": "alice", "empty": ""}
+	)
+	getCustom := func(key string) (string, bool) {
+		v, ok := md[key]
+		return v, ok
+	}
+	type obj struct{ size, mtime, atime int64 }
+	tests := []struct {
+		filter apc.ObjFilter
+		obj    obj
+		match  bool
+	}{
+		{filter: apc.ObjFilter{}, obj: obj{}, match: true},
+
+		// size: [min, max]; nil max - unbounded, zero max - empty objects only
+		{filter: apc.ObjFilter{MinSize: 10}, obj: obj{size: 9}},
+		{filter: apc.ObjFilter{MinSize: 10}, obj: obj{size: 10}, match: true},
+		{filter: apc.ObjFilter{MaxSize: &ten}, obj: obj{size: 10}, match: true},
+		{filter: apc.ObjFilter{MaxSize: &ten}, obj: obj{size: 11}},
+		{filter: apc.ObjFilter{}, obj: obj{size: 1 << 40}, match: true},
+		{filter: apc.ObjFilter{MaxSize: &zero}, obj: obj{size: 0}, match: true},
+		{filter: apc.ObjFilter{MaxSize: &zero}, obj: obj{size: 1}},
+
+		// time: [after, before)
+		{filter: apc.ObjFilter{MtimeAfter: 100}, obj: obj{mtime: 99}},
+		{filter: apc.ObjFilter{MtimeAfter: 100}, obj: obj{mtime: 100}, match: true},
+		{filter: apc.ObjFilter{MtimeBefore: 100}, obj: obj{mtime: 99}, match: true},
+		{filter: apc.ObjFilter{MtimeBefore: 100}, obj: obj{mtime: 100}},
+		{filter: apc.ObjFilter{AtimeAfter: 100, AtimeBefore: 200}, obj: obj{atime: 100}, match: true},
+		{filter: apc.ObjFilter{AtimeAfter: 100, AtimeBefore: 200}, obj: obj{atime: 199}, match: true},
+		{filter: apc.ObjFilter{AtimeAfter: 100, AtimeBefore: 200}, obj: obj{atime: 200}},
+		{filter: apc.ObjFilter{AtimeAfter: 100, AtimeBefore: 200}, obj: obj{atime: 300, mtime: 150}},
+
+		// custom metadata: empty value - the key must be present
+		{filter: apc.ObjFilter{CustomMD: cos.StrKVs{"owner": "alice"}}, match: true},
+		{filter: apc.ObjFilter{CustomMD: cos.StrKVs{"owner": "bob"}}},
+		{filter: apc.ObjFilter{CustomMD: cos.StrKVs{"owner": ""}}, match: true},
+		{filter: apc.ObjFilter{CustomMD: cos.StrKVs{"empty": ""}}, match: true},
+		{filter: apc.ObjFilter{CustomMD: cos.StrKVs{"missing": ""}}},
+		{filter: apc.ObjFilter{CustomMD: cos.StrKVs{"owner": "alice", "missing": ""}}},
+	}
+	for i, test := range tests {
+		match := test.filter.Match(test.obj.size, test.obj.mtime, test.obj.atime, getCustom)
+		tassert.Errorf(t, match == test.match, "#%d: filter %+v, object %+v: expected match=%t", i, test.filter, test.obj, test.match)
+	}
+}
@@ -187,6 +187,9 @@ func CopyBucket(bp BaseParams, bckFrom, bckTo cmn.Bck, msg *apc.CopyBckMsg, fltP
 	if len(fltPresence) > 0 {
 		q.Set(apc.QparamFltPresence, strconv.Itoa(fltPresence[0]))
 	}
+	if msg.Queue != 0 {
+		q.Set(apc.QparamQueue, strconv.Itoa(msg.Queue))
+	}
 	bp.Method = http.MethodPost
 	reqParams := AllocRp()
 	{
@@ -286,3 +289,22 @@ func ECEncodeBucket(bp BaseParams, bck cmn.Bck, data, parity int) (xid string, e
 	FreeRp(reqParams)
 	return
 }
+
+// CreateInventory generates inventory of a given ais:// bucket: sharded CSV manifest
+// (written into `msg.ToBck`, bucket's `inventory.dest`, or the bucket itself)
+// that can then be used to list the bucket - see `apc.HdrInventory`.
+// Returns xaction ID if successful, an error otherwise.
+func CreateInventory(bp BaseParams, bck cmn.Bck, msg *cmn.InvMsg) (xid string, err error) {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActCreateInventory, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	_, err = reqParams.doReqStr(&xid)
+	FreeRp(reqParams)
+	return
+}
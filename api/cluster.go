@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -291,6 +292,50 @@ func GetClusterConfig(bp BaseParams) (*cmn.ClusterConfig, error) {
 	return cluConfig, nil
 }
 
+// GetClusterConfigHistory returns the last (primary-retained) cluster config
+// and bucket props changes: who, when, and what changed
+func GetClusterConfigHistory(bp BaseParams) (*cmn.CfgHistory, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatConfigHistory}}
+	}
+	hist := &cmn.CfgHistory{}
+	_, err := reqParams.DoReqAny(hist)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return hist, nil
+}
+
+// DiffClusterConfig compares two cluster config versions - either retained
+// in the history or current
+func DiffClusterConfig(bp BaseParams, fromVer, toVer int64) (delta []cmn.PropDelta, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{
+			apc.QparamWhat:    []string{apc.WhatConfigDiff},
+			apc.QparamFromVer: []string{strconv.FormatInt(fromVer, 10)},
+			apc.QparamToVer:   []string{strconv.FormatInt(toVer, 10)},
+		}
+	}
+	_, err = reqParams.DoReqAny(&delta)
+	FreeRp(reqParams)
+	return delta, err
+}
+
+// RollbackClusterConfig reverts cluster config to one of the retained prior versions
+// (the result is a new config version that is then distributed via metasync)
+func RollbackClusterConfig(bp BaseParams, ver int64) error {
+	return _putCluster(bp, apc.ActMsg{Action: apc.ActRollbackConfig, Value: ver})
+}
+
 func AttachRemoteAIS(bp BaseParams, alias, u string) error {
 	bp.Method = http.MethodPut
 	reqParams := AllocRp()
@@ -394,6 +439,34 @@ func DecommissionNode(bp BaseParams, actValue *apc.ActValRmNode) (xid string, er
 	return xid, err
 }
 
+// DrainNode puts the target in maintenance mode _after_ migrating all its data
+// to the rest of the cluster (global rebalance); returns rebalance ID
+// - use GetDrainStatus to monitor the progress;
+// - the node is safe to power off when DrainStatus.Safe is true
+func DrainNode(bp BaseParams, tid string) (xid string, err error) {
+	return StartMaintenance(bp, &apc.ActValRmNode{DaemonID: tid})
+}
+
+func GetDrainStatus(bp BaseParams, tid string) (*cmn.DrainStatus, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{
+			apc.QparamWhat:   []string{apc.WhatDrainStatus},
+			apc.QparamNodeID: []string{tid},
+		}
+	}
+	ds := &cmn.DrainStatus{}
+	_, err := reqParams.DoReqAny(ds)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
 func StopMaintenance(bp BaseParams, actValue *apc.ActValRmNode) (xid string, err error) {
 	msg := apc.ActMsg{
 		Action: apc.ActStopMaintenance,
@@ -148,10 +148,16 @@ func etlPostAction(bp BaseParams, etlName, action string) (err error) {
 
 // TODO: add ETL-specific query param and change the examples/docs (!4455)
 func ETLObject(bp BaseParams, etlName string, bck cmn.Bck, objName string, w io.Writer) (err error) {
-	_, err = GetObject(bp, bck, objName, &GetArgs{
-		Writer: w,
-		Query:  url.Values{apc.QparamETLName: []string{etlName}},
-	})
+	return ETLObjectWithArgs(bp, etlName, "", bck, objName, w)
+}
+
+// same as above with (opaque, transformer-specific) per-request arguments (see apc.QparamETLArgs)
+func ETLObjectWithArgs(bp BaseParams, etlName, etlArgs string, bck cmn.Bck, objName string, w io.Writer) (err error) {
+	q := url.Values{apc.QparamETLName: []string{etlName}}
+	if etlArgs != "" {
+		q.Set(apc.QparamETLArgs, etlArgs)
+	}
+	_, err = GetObject(bp, bck, objName, &GetArgs{Writer: w, Query: q})
 	return
 }
 
@@ -179,6 +185,9 @@ func ETLBucket(bp BaseParams, bckFrom, bckTo cmn.Bck, msg *apc.TCBMsg, fltPresen
 	if len(fltPresence) > 0 {
 		q.Set(apc.QparamFltPresence, strconv.Itoa(fltPresence[0]))
 	}
+	if msg.Queue != 0 {
+		q.Set(apc.QparamQueue, strconv.Itoa(msg.Queue))
+	}
 	reqParams := AllocRp()
 	{
 		reqParams.BaseParams = bp
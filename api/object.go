@@ -38,6 +38,7 @@ type (
 
 		// Currently, this (optional) Query field can (optionally) carry:
 		// - `apc.QparamETLName`: named ETL to transform the object (i.e., perform "inline transformation")
+		// - `apc.QparamETLArgs`: (opaque, transformer-specific) arguments for the above, e.g. resize target
 		// - `apc.QparamOrigURL`: GET from a vanilla http(s) location (`ht://` bucket with the corresponding `OrigURLBck`)
 		// - `apc.QparamSilent`: do not log errors
 		// - `apc.QparamLatestVer`: get latest version from the associated Cloud bucket; see also: `ValidateWarmGet`
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -28,6 +29,9 @@ func StartXaction(bp BaseParams, args *xact.ArgsMsg, extra string) (xid string,
 	if args.Force {
 		q.Set(apc.QparamForce, "true")
 	}
+	if args.Queue != 0 {
+		q.Set(apc.QparamQueue, strconv.Itoa(args.Queue))
+	}
 	msg := apc.ActMsg{Action: apc.ActXactStart, Value: args, Name: extra}
 	bp.Method = http.MethodPut
 	reqParams := AllocRp()
@@ -43,6 +47,21 @@ func StartXaction(bp BaseParams, args *xact.ArgsMsg, extra string) (xid string,
 	return
 }
 
+// GetJobQueue returns queued jobs (see xact.ArgsMsg.Queue, apc.CopyBckMsg.Queue) and their states;
+// a queued job, once started, references the resulting xaction ID
+func GetJobQueue(bp BaseParams) (jobs []cmn.QueuedJob, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatJobQueue}}
+	}
+	_, err = reqParams.DoReqAny(&jobs)
+	FreeRp(reqParams)
+	return jobs, err
+}
+
 // Abort ("stop") xactions
 func AbortXaction(bp BaseParams, args *xact.ArgsMsg) (err error) {
 	msg := apc.ActMsg{Action: apc.ActXactStop, Value: args}
@@ -85,8 +104,9 @@ func GetAllRunningXactions(bp BaseParams, kindOrName string) (out []string, err
 
 // QueryXactionSnaps gets all xaction snaps based on the specified selection.
 // NOTE: args.Kind can be either xaction kind or name - here and elsewhere
+// Finished xactions include those retained in the (persistent) job history - see `args.Since`.
 func QueryXactionSnaps(bp BaseParams, args *xact.ArgsMsg) (xs xact.MultiSnap, err error) {
-	msg := xact.QueryMsg{ID: args.ID, Kind: args.Kind, Bck: args.Bck}
+	msg := xact.QueryMsg{ID: args.ID, Kind: args.Kind, Bck: args.Bck, Since: cos.Duration(args.Since)}
 	if args.OnlyRunning {
 		msg.OnlyRunning = apc.Ptr(true)
 	}
@@ -281,7 +281,7 @@ func newTraceCtx(proxyURL string) *traceCtx {
 	return tctx
 }
 
-func newGetRequest(proxyURL string, bck cmn.Bck, objName string, offset, length int64, latest bool) (*http.Request, error) {
+func newGetRequest(proxyURL string, bck cmn.Bck, objName, archpath string, offset, length int64, latest bool) (*http.Request, error) {
 	var (
 		hdr   http.Header
 		query = url.Values{}
@@ -293,6 +293,9 @@ func newGetRequest(proxyURL string, bck cmn.Bck, objName string, offset, length
 	if latest {
 		query.Add(apc.QparamLatestVer, "true")
 	}
+	if archpath != "" {
+		query.Add(apc.QparamArchpath, archpath)
+	}
 	if length > 0 {
 		rng := cmn.MakeRangeHdr(offset, length)
 		hdr = http.Header{cos.HdrRange: []string{rng}}
@@ -335,8 +338,8 @@ func s3getDiscard(bck cmn.Bck, objName string) (int64, error) {
 }
 
 // getDiscard sends a GET request and discards returned data.
-func getDiscard(proxyURL string, bck cmn.Bck, objName string, offset, length int64, validate, latest bool) (int64, error) {
-	req, err := newGetRequest(proxyURL, bck, objName, offset, length, latest)
+func getDiscard(proxyURL string, bck cmn.Bck, objName, archpath string, offset, length int64, validate, latest bool) (int64, error) {
+	req, err := newGetRequest(proxyURL, bck, objName, archpath, offset, length, latest)
 	if err != nil {
 		return 0, err
 	}
@@ -364,12 +367,13 @@ func getDiscard(proxyURL string, bck cmn.Bck, objName string, offset, length int
 }
 
 // Same as above, but with HTTP trace.
-func getTraceDiscard(proxyURL string, bck cmn.Bck, objName string, latencies *httpLatencies, offset, length int64, validate, latest bool) (int64, error) {
+func getTraceDiscard(proxyURL string, bck cmn.Bck, objName, archpath string, latencies *httpLatencies, offset, length int64,
+	validate, latest bool) (int64, error) {
 	var (
 		hdrCksumValue string
 		hdrCksumType  string
 	)
-	req, err := newGetRequest(proxyURL, bck, objName, offset, length, latest)
+	req, err := newGetRequest(proxyURL, bck, objName, archpath, offset, length, latest)
 	if err != nil {
 		return 0, err
 	}
@@ -404,6 +408,57 @@ func getTraceDiscard(proxyURL string, bck cmn.Bck, objName string, latencies *ht
 
 // getConfig sends a {what:config} request to the url and discard the message
 // For testing purpose only
+func baseParams(proxyURL string) api.BaseParams {
+	return api.BaseParams{
+		Client: runParams.bp.Client,
+		URL:    proxyURL,
+		Token:  loggedUserToken,
+		UA:     ua,
+	}
+}
+
+func head(proxyURL string, bck cmn.Bck, objName string) (int64, error) {
+	props, err := api.HeadObject(baseParams(proxyURL), bck, objName, apc.FltExists, true /*silent*/)
+	if err != nil {
+		return 0, err
+	}
+	return props.Size, nil
+}
+
+// list a single page; returns the number of listed entries
+func listPage(proxyURL string, bck cmn.Bck, prefix string) (int64, error) {
+	msg := &apc.LsoMsg{Prefix: prefix, PageSize: int64(runParams.batchSize), Flags: apc.LsNoDirs}
+	lst, err := api.ListObjectsPage(baseParams(proxyURL), bck, msg, api.ListArgs{})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(lst.Entries)), nil
+}
+
+func del(proxyURL string, bck cmn.Bck, objName string) error {
+	return api.DeleteObject(baseParams(proxyURL), bck, objName)
+}
+
+// create a new object via (single) append followed by flush
+func appendFlush(proxyURL string, bck cmn.Bck, objName string, size int64, reader cos.ReadOpenCloser) error {
+	bp := baseParams(proxyURL)
+	handle, err := api.AppendObject(&api.AppendArgs{BaseParams: bp, Bck: bck, Object: objName, Reader: reader, Size: size})
+	if err != nil {
+		return err
+	}
+	return api.FlushObject(&api.FlushArgs{BaseParams: bp, Bck: bck, Object: objName, Handle: handle})
+}
+
+// start multi-object archiving job (into a new shard in the same bucket)
+func archiveMultiObj(proxyURL string, bck cmn.Bck, archName string, lr apc.ListRange) error {
+	msg := &cmn.ArchiveBckMsg{ToBck: bck}
+	msg.ArchName = archName
+	msg.ListRange = lr
+	msg.ContinueOnError = true
+	_, err := api.ArchiveMultiObj(baseParams(proxyURL), bck, msg)
+	return err
+}
+
 func getConfig(proxyURL string) (httpLatencies, error) {
 	tctx := newTraceCtx(proxyURL)
 
@@ -49,6 +49,23 @@ type (
 		permidx   int
 		nextReady sync.WaitGroup
 	}
+	// Zipfian popularity: the k-th most popular object is accessed
+	// with probability proportional to 1/k^S (S > 1)
+	ZipfNameGetter struct {
+		BaseNameGetter
+		rnd  *rand.Rand
+		zipf *rand.Zipf
+		perm []int // rank => name index (so that popularity does not follow the listing order)
+		S    float64
+	}
+	// HotKeys percent of all objects receive HotOps percent of all accesses
+	HotspotNameGetter struct {
+		BaseNameGetter
+		rnd     *rand.Rand
+		perm    []int
+		HotKeys int
+		HotOps  int
+	}
 	BaseNameGetter struct {
 		names []string
 	}
@@ -208,6 +225,60 @@ func (pung *PermutationUniqueImprovedNameGetter) ObjName() string {
 	return objName
 }
 
+// ZipfNameGetter //
+
+func (zng *ZipfNameGetter) Init(names []string, rnd *rand.Rand) {
+	zng.names = names
+	zng.rnd = rnd
+	zng.perm = rnd.Perm(len(names))
+	zng.zipf = nil
+}
+
+// (newly added objects are the least popular)
+func (zng *ZipfNameGetter) AddObjName(objName string) {
+	zng.perm = append(zng.perm, len(zng.names))
+	zng.names = append(zng.names, objName)
+	zng.zipf = nil
+}
+
+func (zng *ZipfNameGetter) ObjName() string {
+	if zng.zipf == nil {
+		zng.zipf = rand.NewZipf(zng.rnd, zng.S, 1, uint64(len(zng.names)-1))
+	}
+	rank := zng.zipf.Uint64()
+	return zng.names[zng.perm[rank]]
+}
+
+// HotspotNameGetter //
+
+func (hng *HotspotNameGetter) Init(names []string, rnd *rand.Rand) {
+	hng.names = names
+	hng.rnd = rnd
+	hng.perm = rnd.Perm(len(names))
+}
+
+func (hng *HotspotNameGetter) AddObjName(objName string) {
+	hng.perm = append(hng.perm, len(hng.names))
+	hng.names = append(hng.names, objName)
+}
+
+func (hng *HotspotNameGetter) ObjName() string {
+	var (
+		l   = len(hng.names)
+		hot = max((l*hng.HotKeys+99)/100, 1)
+		idx int
+	)
+	switch {
+	case hot >= l:
+		idx = hng.rnd.IntN(l)
+	case hng.rnd.IntN(100) < hng.HotOps:
+		idx = hng.rnd.IntN(hot)
+	default:
+		idx = hot + hng.rnd.IntN(l-hot)
+	}
+	return hng.names[hng.perm[idx]]
+}
+
 // BaseNameGetter //
 
 func (bng *BaseNameGetter) Names() []string {
@@ -0,0 +1,146 @@
+// Package aisloader
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package aisloader
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Open-loop (constant-rate) load generation
+// - closed-loop (default): '-numworkers' goroutines issue requests back to back,
+//   and so a slow response delays all subsequent requests - which, in turn,
+//   hides the latency that would've been observed under a given offered load
+//   ("coordinated omission");
+// - open-loop ('-rate'): requests are scheduled on a fixed timeline (optionally,
+//   ramping linearly from '-rate-from' to '-rate' over '-rate-ramp') regardless of
+//   completions, and latency is measured from the _intended_ start time;
+// - '-numworkers' bounds the number of requests in flight - requests that are due
+//   while at the limit wait in the backlog, and the wait counts as latency.
+
+type openLoop struct {
+	ch          chan time.Time // pacer => main loop: intended start times
+	stopCh      chan struct{}
+	doneCh      chan struct{}
+	backlog     []*workOrder
+	rate        float64 // target ops/sec
+	from        float64 // initial rate (when ramping)
+	ramp        time.Duration
+	cnt         int64
+	maxBacklog  int
+	maxInflight int
+	inflight    int
+}
+
+func newOpenLoop(rate, from float64, ramp time.Duration, maxInflight int) *openLoop {
+	return &openLoop{
+		ch:          make(chan time.Time, 1024),
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+		rate:        rate,
+		from:        from,
+		ramp:        ramp,
+		maxInflight: maxInflight,
+	}
+}
+
+// nil when not running open-loop
+func (ol *openLoop) dueCh() <-chan time.Time {
+	if ol == nil {
+		return nil
+	}
+	return ol.ch
+}
+
+// pacer goroutine
+func (ol *openLoop) run() {
+	defer close(ol.doneCh)
+	var (
+		t0    = time.Now()
+		timer = time.NewTimer(time.Hour)
+	)
+	timer.Stop()
+	for i := int64(0); ; i++ {
+		due := t0.Add(ol.at(i))
+		if d := time.Until(due); d > 0 {
+			timer.Reset(d)
+			select {
+			case <-timer.C:
+			case <-ol.stopCh:
+				timer.Stop()
+				return
+			}
+		}
+		select {
+		case ol.ch <- due:
+		case <-ol.stopCh:
+			return
+		}
+	}
+}
+
+// intended start of the i-th request relative to the beginning of the run
+// (during the ramp, the number of requests by time t is from*t + (rate-from)*t^2/(2*ramp))
+func (ol *openLoop) at(i int64) time.Duration {
+	n := float64(i)
+	if n == 0 {
+		return 0
+	}
+	if r := ol.ramp.Seconds(); r > 0 {
+		nramp := (ol.from + ol.rate) * r / 2
+		if n < nramp {
+			a, b := (ol.rate-ol.from)/(2*r), ol.from
+			t := 2 * n / (b + math.Sqrt(b*b+4*a*n))
+			return time.Duration(t * float64(time.Second))
+		}
+		return ol.ramp + time.Duration((n-nramp)/ol.rate*float64(time.Second))
+	}
+	return time.Duration(n / ol.rate * float64(time.Second))
+}
+
+// main loop: new request is due
+func (ol *openLoop) submit(wo *workOrder, wg *sync.WaitGroup) {
+	ol.cnt++
+	if ol.inflight < ol.maxInflight {
+		ol.inflight++
+		goWorkOrder(wo, wg)
+		return
+	}
+	ol.backlog = append(ol.backlog, wo)
+	ol.maxBacklog = max(ol.maxBacklog, len(ol.backlog))
+}
+
+// main loop: request completed
+func (ol *openLoop) done(wg *sync.WaitGroup) {
+	if len(ol.backlog) == 0 {
+		ol.inflight--
+		return
+	}
+	wo := ol.backlog[0]
+	ol.backlog[0] = nil
+	ol.backlog = ol.backlog[1:]
+	goWorkOrder(wo, wg)
+}
+
+func (ol *openLoop) stop() {
+	close(ol.stopCh)
+	<-ol.doneCh
+}
+
+func (ol *openLoop) report() {
+	fmt.Printf("Open-loop: %d request%s scheduled at %v ops/s", ol.cnt, cos.Plural(int(ol.cnt)), ol.rate)
+	if ol.maxBacklog > 0 {
+		fmt.Printf(", max backlog %d", ol.maxBacklog)
+	}
+	if l := len(ol.backlog); l > 0 {
+		fmt.Printf(", %d not issued", l)
+	}
+	fmt.Println()
+}
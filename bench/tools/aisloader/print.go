@@ -7,10 +7,14 @@ package aisloader
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"math"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -60,6 +64,13 @@ var examples = `# 1. Cleanup (i.e., destroy) an existing bucket:
      $ aisloader -bucket=s3://xyz -cleanup=false -numworkers=8 -pctput=0 -duration=10m -s3endpoint=https://s3.amazonaws.com
 # 13. PUT approx. 8000 files into s3 bucket directly, skip printing usage and defaults (NOTE: aistore is not being used):
      $ aisloader -bucket=s3://xyz -cleanup=false -minsize=16B -maxsize=16B -numworkers=8 -pctput=100 -totalputsize=128k -s3endpoint=https://s3.amazonaws.com -quiet
+# 14. Mixed workload with Zipf-distributed reads (the most popular objects are read most often):
+     $ aisloader -bucket=ais://abc -cleanup=false -duration=5m -numworkers=16 -opmix="get=80,head=10,put=5,del=5" -dist=zipf:1.2
+# 15. Replay a trace (open-loop) at twice the recorded rate:
+     $ aisloader -bucket=ais://abc -cleanup=false -numworkers=64 -trace=/tmp/trace.csv -trace-rate=2
+# 16. Open-loop 1000 ops/sec (ramping up over 30s) with latency histograms to merge with other loaders:
+     $ aisloader -bucket=ais://abc -cleanup=false -duration=5m -numworkers=128 -rate=1000 -rate-ramp=30s -loaderid=1 -latency-out=/tmp/lat-1.json
+     $ aisloader -latency-merge="/tmp/lat-*.json"
 `
 
 const readme = cmn.GitHubHome + "/blob/main/docs/howto_benchmark.md"
@@ -187,21 +198,45 @@ func jsonStatsFromReq(r stats.HTTPReq) *jsonStats {
 		Latency:    r.AvgLatency(),
 		MinLatency: r.MinLatency(),
 		MaxLatency: r.MaxLatency(),
+		P50:        r.Percentile(50),
+		P90:        r.Percentile(90),
+		P99:        r.Percentile(99),
+		P999:       r.Percentile(99.9),
 		Throughput: r.Throughput(r.Start(), time.Now()),
 	}
 
 	return jStats
 }
 
+// same as above but nil when there was no activity (to omit)
+func jsonStatsFromOther(r *stats.HTTPReq) *jsonStats {
+	if r.Total() == 0 && r.TotalErrs() == 0 {
+		return nil
+	}
+	return jsonStatsFromReq(*r)
+}
+
 func writeStatsJSON(to io.Writer, s *sts, withcomma ...bool) {
 	jStats := struct {
-		Get *jsonStats `json:"get"`
-		Put *jsonStats `json:"put"`
-		Cfg *jsonStats `json:"cfg"`
+		Get    *jsonStats `json:"get"`
+		Put    *jsonStats `json:"put"`
+		Cfg    *jsonStats `json:"cfg"`
+		Head   *jsonStats `json:"head,omitempty"`
+		List   *jsonStats `json:"list,omitempty"`
+		Del    *jsonStats `json:"del,omitempty"`
+		Append *jsonStats `json:"append,omitempty"`
+		Arch   *jsonStats `json:"archpath,omitempty"`
+		Mobj   *jsonStats `json:"mobj,omitempty"`
 	}{
-		Get: jsonStatsFromReq(s.get),
-		Put: jsonStatsFromReq(s.put),
-		Cfg: jsonStatsFromReq(s.getConfig),
+		Get:    jsonStatsFromReq(s.get),
+		Put:    jsonStatsFromReq(s.put),
+		Cfg:    jsonStatsFromReq(s.getConfig),
+		Head:   jsonStatsFromOther(&s.head),
+		List:   jsonStatsFromOther(&s.list),
+		Del:    jsonStatsFromOther(&s.del),
+		Append: jsonStatsFromOther(&s.appnd),
+		Arch:   jsonStatsFromOther(&s.arch),
+		Mobj:   jsonStatsFromOther(&s.mobj),
 	}
 
 	jsonOutput, err := json.MarshalIndent(jStats, "", "  ")
@@ -260,6 +295,30 @@ func writeHumanReadibleIntervalStats(to io.Writer, s, t *sts) {
 			ps(s.getConfig.Throughput(s.getConfig.Start(), time.Now()))+" ("+ps(t.getConfig.Throughput(t.getConfig.Start(), time.Now()))+")",
 			pn(s.getConfig.TotalErrs())+" ("+pn(t.getConfig.TotalErrs())+")")
 	}
+	for _, op := range otherOps {
+		sr, tr := s.req(op), t.req(op)
+		if sr.Total() == 0 && sr.TotalErrs() == 0 {
+			continue
+		}
+		p(to, statsPrintHeader, pt(), opTag(op),
+			pn(sr.Total())+" ("+pn(tr.Total())+")",
+			pb(sr.TotalBytes())+" ("+pb(tr.TotalBytes())+")",
+			pl(sr.MinLatency(), sr.AvgLatency(), sr.MaxLatency()),
+			ps(sr.Throughput(sr.Start(), time.Now()))+" ("+ps(tr.Throughput(tr.Start(), time.Now()))+")",
+			pn(sr.TotalErrs())+" ("+pn(tr.TotalErrs())+")")
+	}
+}
+
+// (column width permitting)
+func opTag(op int) string {
+	switch op {
+	case opAppend:
+		return "APND"
+	case opArch:
+		return "ARCH"
+	default:
+		return strings.ToUpper(opNames[op])
+	}
 }
 
 func writeHumanReadibleFinalStats(to io.Writer, t *sts) {
@@ -298,6 +357,37 @@ func writeHumanReadibleFinalStats(to io.Writer, t *sts) {
 			pb(sconfig.Throughput(sconfig.Start(), time.Now())),
 			pn(sconfig.TotalErrs()))
 	}
+	for _, op := range otherOps {
+		r := t.req(op)
+		if r.Total() == 0 && r.TotalErrs() == 0 {
+			continue
+		}
+		p(to, statsPrintHeader, pt(), opTag(op),
+			pn(r.Total()),
+			pb(r.TotalBytes()),
+			pl(r.MinLatency(), r.AvgLatency(), r.MaxLatency()),
+			ps(r.Throughput(r.Start(), time.Now())),
+			pn(r.TotalErrs()))
+	}
+	writeLatencyPercentiles(to, t)
+}
+
+// final (human-readable) latency percentiles, for all ops that were executed
+func writeLatencyPercentiles(to io.Writer, t *sts) {
+	const hdr = "%-10s%-12s%-12s%-12s%-12s\n"
+	var printed bool
+	for _, op := range append([]int{opPut, opGet, opConfig}, otherOps[:]...) {
+		r := t.req(op)
+		if r.Total() == 0 {
+			continue
+		}
+		if !printed {
+			fprintf(to, "\n"+hdr, "OP", "p50", "p90", "p99", "p99.9")
+			printed = true
+		}
+		fprintf(to, hdr, opTag(op), prettyDuration(r.Percentile(50)), prettyDuration(r.Percentile(90)),
+			prettyDuration(r.Percentile(99)), prettyDuration(r.Percentile(99.9)))
+	}
 }
 
 // writeStatus writes stats to the writter.
@@ -317,6 +407,13 @@ func printRunParams(p *params) {
 	if p.duration.Val == time.Duration(math.MaxInt64) {
 		d = "-"
 	}
+	var rate string
+	switch {
+	case p.rateRamp > 0:
+		rate = fmt.Sprintf("%v => %v ops/s over %v", p.rateFrom, p.rate, p.rateRamp)
+	case p.rate > 0:
+		rate = fmt.Sprintf("%v ops/s", p.rate)
+	}
 	b, err := jsoniter.MarshalIndent(struct {
 		Seed          int64  `json:"seed,string"`
 		URL           string `json:"proxy"`
@@ -331,6 +428,10 @@ func printRunParams(p *params) {
 		NumWorkers    int    `json:"# workers"`
 		StatsInterval string `json:"stats interval"`
 		Backing       string `json:"backed by"`
+		OpMix         string `json:"op mix,omitempty"`
+		Dist          string `json:"GET distribution,omitempty"`
+		Trace         string `json:"trace,omitempty"`
+		Rate          string `json:"open-loop rate,omitempty"`
 		Cleanup       bool   `json:"cleanup"`
 	}{
 		Seed:          p.seed,
@@ -346,9 +447,127 @@ func printRunParams(p *params) {
 		NumWorkers:    p.numWorkers,
 		StatsInterval: (time.Duration(runParams.statsShowInterval) * time.Second).String(),
 		Backing:       p.readerType,
+		OpMix:         p.opMixStr,
+		Dist:          p.dist,
+		Trace:         p.traceFile,
+		Rate:          rate,
 		Cleanup:       p.cleanUp.Val,
 	}, "", "   ")
 	cos.AssertNoErr(err)
 
 	fmt.Printf("Runtime configuration:\n%s\n\n", string(b))
 }
+
+//
+// latency histograms: write and merge (across multiple aisloaders)
+//
+
+type latencyHists struct {
+	LoaderIDs []string                    `json:"loaderids"`
+	Start     time.Time                   `json:"start_time"`
+	Duration  time.Duration               `json:"duration"`
+	Ops       map[string]*stats.Histogram `json:"ops"`
+}
+
+func writeLatency(fqn string, t *sts, start time.Time) error {
+	lh := &latencyHists{
+		LoaderIDs: []string{runParams.loaderID},
+		Start:     start,
+		Duration:  time.Since(start),
+		Ops:       make(map[string]*stats.Histogram, 4),
+	}
+	for _, op := range append([]int{opPut, opGet, opConfig}, otherOps[:]...) {
+		if r := t.req(op); r.Total() > 0 {
+			lh.Ops[opNames[op]] = r.Histogram()
+		}
+	}
+	return writeLatencyHists(fqn, lh)
+}
+
+func writeLatencyHists(fqn string, lh *latencyHists) error {
+	b, err := jsoniter.Marshal(lh)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fqn, b, 0o644)
+}
+
+func mergeLatency(pattern string) error {
+	fqns, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	if len(fqns) == 0 {
+		return fmt.Errorf("no files matching %q", pattern)
+	}
+	var (
+		merged = &latencyHists{Ops: make(map[string]*stats.Histogram, 4)}
+		end    time.Time
+	)
+	for _, fqn := range fqns {
+		var lh latencyHists
+		b, err := os.ReadFile(fqn)
+		if err != nil {
+			return err
+		}
+		if err := jsoniter.Unmarshal(b, &lh); err != nil {
+			return fmt.Errorf("%s: %v", fqn, err)
+		}
+		merged.LoaderIDs = append(merged.LoaderIDs, lh.LoaderIDs...)
+		if merged.Start.IsZero() || lh.Start.Before(merged.Start) {
+			merged.Start = lh.Start
+		}
+		if e := lh.Start.Add(lh.Duration); e.After(end) {
+			end = e
+		}
+		for name, h := range lh.Ops {
+			if merged.Ops[name] == nil {
+				merged.Ops[name] = &stats.Histogram{}
+			}
+			merged.Ops[name].Merge(h)
+		}
+	}
+	if len(merged.Ops) == 0 {
+		return errors.New("no latency histograms in " + strings.Join(fqns, ", "))
+	}
+	merged.Duration = end.Sub(merged.Start)
+
+	names := make([]string, 0, len(merged.Ops))
+	for name := range merged.Ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if runParams.jsonFormat {
+		type pcts struct {
+			Cnt  int64 `json:"count,string"`
+			P50  int64 `json:"p50_latency"`
+			P90  int64 `json:"p90_latency"`
+			P99  int64 `json:"p99_latency"`
+			P999 int64 `json:"p999_latency"`
+			Max  int64 `json:"max_latency"`
+		}
+		out := make(map[string]pcts, len(names))
+		for _, name := range names {
+			h := merged.Ops[name]
+			out[name] = pcts{h.Count(), h.Percentile(50), h.Percentile(90), h.Percentile(99), h.Percentile(99.9), h.Percentile(100)}
+		}
+		b, err := json.MarshalIndent(out, "", "  ")
+		cos.AssertNoErr(err)
+		fmt.Println(string(b))
+	} else {
+		const hdr = "%-10s%-14s%-12s%-12s%-12s%-12s%-12s\n"
+		fmt.Printf("Merged %d file%s (loaders: %s), start %s, duration %v\n\n", len(fqns), cos.Plural(len(fqns)),
+			strings.Join(merged.LoaderIDs, ", "), merged.Start.Format(cos.StampSec), merged.Duration.Round(time.Millisecond))
+		fmt.Printf(hdr, "OP", "Count", "p50", "p90", "p99", "p99.9", "max")
+		for _, name := range names {
+			h := merged.Ops[name]
+			fmt.Printf(hdr, strings.ToUpper(name), prettyNumber(h.Count()), prettyDuration(h.Percentile(50)),
+				prettyDuration(h.Percentile(90)), prettyDuration(h.Percentile(99)), prettyDuration(h.Percentile(99.9)),
+				prettyDuration(h.Percentile(100)))
+		}
+	}
+	if runParams.latencyOut != "" {
+		return writeLatencyHists(runParams.latencyOut, merged)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+// Package aisloader
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+
+package aisloader
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Trace replay
+// - trace is a CSV file, one request per line (lines that start with '#' are ignored):
+//   timestamp,op,bucket,object[,size[,range[,extra]]]
+// - timestamp: seconds (floating point, e.g. Unix epoch) or RFC 3339; only the
+//   differences between timestamps matter
+// - op: get | put | head | list | del | append | archpath | mobj
+// - bucket: bucket name or URI (e.g. s3://abc); empty - use '-bucket'
+// - object: object name; prefix (list); destination shard name (mobj)
+// - size: PUT and append size (empty or zero - random as per '-minsize' and '-maxsize')
+// - range: "[bytes=]<first>-<last>" to read a byte range (get only)
+// - extra: pathname inside the shard (archpath); ';'-separated names or a template,
+//   e.g. "obj-{0..99}", of the objects to archive (mobj)
+//
+// Replay is open-loop: requests are issued at the (scaled) recorded times
+// regardless of completions. '-numworkers' bounds the number of requests in flight;
+// when the bound is reached, replay falls behind schedule - which is then reported.
+// Latency is measured from the scheduled (recorded) time.
+
+const replayLagTolerance = 10 * time.Millisecond
+
+type replay struct {
+	err      error
+	ch       chan *workOrder // dispatcher => main loop
+	recv     <-chan *workOrder
+	sema     chan struct{} // in-flight requests
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	path     string
+	rate     float64
+	late     atomic.Int64 // issued behind schedule
+	maxLag   atomic.Int64
+	cnt      int64
+	inflight int
+	eof      bool
+}
+
+func newReplay(path string, rate float64, maxInflight int) *replay {
+	rp := &replay{
+		ch:     make(chan *workOrder),
+		sema:   make(chan struct{}, maxInflight),
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+		path:   path,
+		rate:   rate,
+	}
+	rp.recv = rp.ch
+	return rp
+}
+
+// nil when not replaying (or when done)
+func (rp *replay) workCh() <-chan *workOrder {
+	if rp == nil {
+		return nil
+	}
+	return rp.recv
+}
+
+// dispatcher goroutine
+func (rp *replay) run() {
+	defer close(rp.doneCh)
+	defer close(rp.ch)
+
+	fh, err := os.Open(rp.path)
+	if err != nil {
+		rp.err = err
+		return
+	}
+	defer fh.Close()
+
+	var (
+		r     = csv.NewReader(bufio.NewReader(fh))
+		timer = time.NewTimer(time.Hour)
+		t0    time.Time
+		ts0   time.Duration
+	)
+	timer.Stop()
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	for {
+		fields, err := r.Read()
+		if err != nil {
+			if err != io.EOF {
+				rp.err = fmt.Errorf("trace %q: %v", rp.path, err)
+			}
+			return
+		}
+		wo, ts, err := parseTraceRec(fields)
+		if err != nil {
+			line, _ := r.FieldPos(0)
+			rp.err = fmt.Errorf("trace %q, line %d: %v", rp.path, line, err)
+			return
+		}
+		if t0.IsZero() {
+			t0, ts0 = time.Now(), ts
+		}
+		due := t0
+		if rp.rate > 0 {
+			due = t0.Add(time.Duration(float64(ts-ts0) / rp.rate))
+		}
+		if d := time.Until(due); d > 0 {
+			timer.Reset(d)
+			select {
+			case <-timer.C:
+			case <-rp.stopCh:
+				timer.Stop()
+				return
+			}
+		}
+		select {
+		case rp.sema <- struct{}{}:
+		case <-rp.stopCh:
+			return
+		}
+		if lag := time.Since(due); lag > replayLagTolerance {
+			rp.late.Inc()
+			if int64(lag) > rp.maxLag.Load() {
+				rp.maxLag.Store(int64(lag))
+			}
+		}
+		wo.intended = due
+		select {
+		case rp.ch <- wo:
+		case <-rp.stopCh:
+			return
+		}
+	}
+}
+
+// main loop: execute the next trace record; returns true when the replay is complete
+func (rp *replay) dispatch(wo *workOrder, ok bool, wg *sync.WaitGroup) bool {
+	if !ok {
+		rp.eof, rp.recv = true, nil
+		return rp.inflight == 0
+	}
+	switch wo.op {
+	case opGet:
+		getPending++
+	case opPut:
+		putPending++
+	}
+	rp.cnt++
+	rp.inflight++
+	goWorkOrder(wo, wg)
+	return false
+}
+
+// main loop: trace request completed; returns true when the replay is complete
+func (rp *replay) done() bool {
+	rp.inflight--
+	<-rp.sema
+	return rp.eof && rp.inflight == 0
+}
+
+func (rp *replay) stop() {
+	close(rp.stopCh)
+	<-rp.doneCh
+}
+
+func (rp *replay) report() {
+	fmt.Printf("Trace replay: %d request%s", rp.cnt, cos.Plural(int(rp.cnt)))
+	if late := rp.late.Load(); late > 0 {
+		fmt.Printf(", %d issued behind schedule (max lag %v)", late, time.Duration(rp.maxLag.Load()))
+	}
+	fmt.Println()
+	if rp.err != nil {
+		fmt.Fprintln(os.Stderr, rp.err)
+	}
+}
+
+//
+// parsing
+//
+
+func parseTraceRec(fields []string) (wo *workOrder, ts time.Duration, err error) {
+	if len(fields) < 4 {
+		return nil, 0, fmt.Errorf("expecting at least 4 fields (timestamp,op,bucket,object), got %d", len(fields))
+	}
+	if ts, err = parseTraceTime(fields[0]); err != nil {
+		return nil, 0, err
+	}
+	op := opByName(fields[1])
+	if op < 0 || op == opConfig {
+		return nil, 0, fmt.Errorf("unknown op %q", fields[1])
+	}
+	if isDirectS3() && op != opGet && op != opPut {
+		return nil, 0, fmt.Errorf("direct S3 access via '-s3endpoint': op %q is not supported yet", fields[1])
+	}
+	wo = &workOrder{op: op, proxyURL: runParams.proxyURL, bck: runParams.bck, objName: fields[3]}
+	if fields[2] != "" {
+		if wo.bck, err = parseTraceBck(fields[2]); err != nil {
+			return nil, 0, err
+		}
+	}
+	if wo.objName == "" && op != opList {
+		return nil, 0, fmt.Errorf("%s: missing object name", fields[1])
+	}
+	var extra string
+	if len(fields) > 6 {
+		extra = fields[6]
+	}
+	switch op {
+	case opPut, opAppend:
+		if len(fields) > 4 && fields[4] != "" {
+			if wo.size, err = cos.ParseSize(fields[4], cos.UnitsIEC); err != nil {
+				return nil, 0, err
+			}
+		}
+		if wo.size == 0 {
+			wo.size = randSize()
+		}
+		wo.cksumType = runParams.cksumType
+	case opGet:
+		if len(fields) > 5 && fields[5] != "" {
+			if wo.readOff, wo.readLen, err = parseTraceRange(fields[5]); err != nil {
+				return nil, 0, err
+			}
+		}
+	case opArch:
+		if extra == "" {
+			return nil, 0, errors.New("archpath: missing pathname (extra field)")
+		}
+		wo.archPath = extra
+	case opMobj:
+		switch {
+		case extra == "":
+			return nil, 0, errors.New("mobj: missing objects to archive (extra field)")
+		case strings.Contains(extra, "{"):
+			wo.lr.Template = extra
+		default:
+			wo.lr.ObjNames = strings.Split(extra, ";")
+		}
+	}
+	return wo, ts, nil
+}
+
+func parseTraceTime(s string) (time.Duration, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q (expecting seconds or RFC 3339)", s)
+	}
+	return time.Duration(t.UnixNano()), nil
+}
+
+func parseTraceBck(s string) (cmn.Bck, error) {
+	if !strings.Contains(s, apc.BckProviderSeparator) {
+		return cmn.Bck{Name: s, Provider: runParams.bck.Provider, Ns: runParams.bck.Ns}, nil
+	}
+	bck, objName, err := cmn.ParseBckObjectURI(s, cmn.ParseURIOpts{})
+	if err == nil && objName != "" {
+		err = fmt.Errorf("expecting bucket name or bucket URI, got %q", s)
+	}
+	return bck, err
+}
+
+// "[bytes=]<first>-<last>" (inclusive, as in HTTP Range)
+func parseTraceRange(s string) (off, length int64, err error) {
+	first, last, ok := strings.Cut(strings.TrimPrefix(s, "bytes="), "-")
+	if ok {
+		off, err = strconv.ParseInt(first, 10, 64)
+		if err == nil {
+			var end int64
+			end, err = strconv.ParseInt(last, 10, 64)
+			length = end - off + 1
+		}
+	}
+	if !ok || err != nil || off < 0 || length <= 0 {
+		return 0, 0, fmt.Errorf("invalid range %q (expecting <first>-<last>)", s)
+	}
+	return off, length, nil
+}
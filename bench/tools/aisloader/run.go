@@ -125,6 +125,24 @@ type (
 		etlName     string // name of a ETL to apply to each object. Omitted when etlSpecPath specified.
 		etlSpecPath string // Path to a ETL spec to apply to each object.
 
+		opMixStr   string     // weighted ops, e.g. "get=80,put=10,head=10" (takes precedence over putPct)
+		opMix      []opWeight // parsed opMixStr
+		opTotal    int        // sum of opMix weights
+		dist       string     // GET key distribution: uniform (default) | zipf[:s] | hotspot[:keys%:ops%]
+		traceFile  string     // trace to replay (see replay.go)
+		traceRate  float64    // replay rate multiplier (1 - original rate)
+		zipfS      float64
+		hotKeys    int
+		hotOps     int
+		mixWrites  bool // opMix includes PUT and/or append
+		mixDeletes bool // opMix includes deletes
+
+		rate         float64       // open-loop target rate (ops/sec); 0 - closed-loop
+		rateFrom     float64       // initial rate when ramping
+		rateRamp     time.Duration // ramp from rateFrom to rate over this duration
+		latencyOut   string        // file to write (mergeable) latency histograms to
+		latencyMerge string        // merge latency histograms (glob) and exit
+
 		cleanUp BoolExt // cleanup i.e. remove and destroy everything created during bench
 
 		statsdProbe   bool
@@ -149,6 +167,12 @@ type (
 		put       stats.HTTPReq
 		get       stats.HTTPReq
 		getConfig stats.HTTPReq
+		head      stats.HTTPReq
+		list      stats.HTTPReq
+		del       stats.HTTPReq
+		appnd     stats.HTTPReq
+		arch      stats.HTTPReq
+		mobj      stats.HTTPReq
 		statsd    stats.Metrics
 	}
 
@@ -161,6 +185,10 @@ type (
 		Duration   time.Duration `json:"duration"`
 		MinLatency int64         `json:"min_latency"`
 		MaxLatency int64         `json:"max_latency"`
+		P50        int64         `json:"p50_latency"`
+		P90        int64         `json:"p90_latency"`
+		P99        int64         `json:"p99_latency"`
+		P999       int64         `json:"p999_latency"`
 		Throughput int64         `json:"throughput,string"`
 	}
 )
@@ -212,6 +240,8 @@ var (
 	workCh  chan *workOrder
 	resCh   chan *workOrder
 	wo2Free []*workOrder
+	rp      *replay   // when replaying '-trace'
+	ol      *openLoop // when running open-loop ('-rate')
 )
 
 var _version, _buildtime string
@@ -229,6 +259,10 @@ func Start(version, buildtime string) (err error) {
 	f := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 	addCmdLine(f, runParams)
 
+	if runParams.latencyMerge != "" {
+		return mergeLatency(runParams.latencyMerge)
+	}
+
 	// validate and finish initialization
 	if err = _init(runParams); err != nil {
 		return err
@@ -298,18 +332,18 @@ func Start(version, buildtime string) (err error) {
 
 	// list objects, or maybe not
 	if created {
-		if runParams.putPct < 100 {
+		if runParams.putPct < 100 && !runParams.mixWrites && runParams.traceFile == "" {
 			return errors.New("new bucket, expecting 100% PUT")
 		}
-		bucketObjsNames = &namegetter.RandomNameGetter{}
+		bucketObjsNames = newNameGetter()
 		bucketObjsNames.Init([]string{}, rnd)
-	} else if !runParams.getConfig && !runParams.skipList {
+	} else if !runParams.getConfig && !runParams.skipList && runParams.traceFile == "" {
 		if err := listObjects(); err != nil {
 			return err
 		}
 
 		objsLen := bucketObjsNames.Len()
-		if runParams.putPct == 0 && objsLen == 0 {
+		if runParams.putPct == 0 && !runParams.mixWrites && objsLen == 0 {
 			if runParams.subDir == "" {
 				return errors.New("the bucket is empty, cannot run 100% read benchmark")
 			}
@@ -318,7 +352,7 @@ func Start(version, buildtime string) (err error) {
 
 		fmt.Printf("Found %s existing object%s\n\n", cos.FormatBigNum(objsLen), cos.Plural(objsLen))
 	} else {
-		bucketObjsNames = &namegetter.RandomNameGetter{}
+		bucketObjsNames = newNameGetter()
 		bucketObjsNames.Init([]string{}, rnd)
 	}
 
@@ -423,14 +457,22 @@ func Start(version, buildtime string) (err error) {
 
 	preWriteStats(statsWriter, runParams.jsonFormat)
 
-	// Get the workers started
-	for range runParams.numWorkers {
-		if err = postNewWorkOrder(); err != nil {
-			break
+	// Get the workers started (or, start replaying the trace, or start open-loop pacing)
+	if runParams.traceFile != "" {
+		rp = newReplay(runParams.traceFile, runParams.traceRate, runParams.numWorkers)
+		go rp.run()
+	} else if runParams.rate > 0 {
+		ol = newOpenLoop(runParams.rate, runParams.rateFrom, runParams.rateRamp, runParams.numWorkers)
+		go ol.run()
+	} else {
+		for range runParams.numWorkers {
+			if err = postNewWorkOrder(); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			goto Done
 		}
-	}
-	if err != nil {
-		goto Done
 	}
 
 MainLoop:
@@ -466,10 +508,32 @@ MainLoop:
 				accumulatedStats.aggregate(&intervalStats)
 				intervalStats = newStats(time.Now())
 			}
+			if rp != nil {
+				if rp.done() {
+					break MainLoop
+				}
+				continue
+			}
+			if ol != nil {
+				ol.done(wg)
+				continue
+			}
 			if err := postNewWorkOrder(); err != nil {
 				fmt.Fprintln(os.Stderr, err.Error())
 				break MainLoop
 			}
+		case wo, ok := <-rp.workCh():
+			if rp.dispatch(wo, ok, wg) {
+				break MainLoop
+			}
+		case due := <-ol.dueCh():
+			wo, err := newWorkOrder()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err.Error())
+				break MainLoop
+			}
+			wo.intended = due
+			ol.submit(wo, wg)
 		case <-statsTicker.C:
 			accumulatedStats.aggregate(&intervalStats)
 			writeStats(statsWriter, runParams.jsonFormat, false /* final */, &intervalStats, &accumulatedStats)
@@ -496,6 +560,12 @@ MainLoop:
 Done:
 	timer.Stop()
 	statsTicker.Stop()
+	if rp != nil {
+		rp.stop()
+	}
+	if ol != nil {
+		ol.stop()
+	}
 	close(workCh)
 	wg.Wait() // wait until all workers complete their work
 
@@ -507,6 +577,22 @@ Done:
 
 	finalizeStats(statsWriter)
 	fmt.Printf("Stats written to %s\n", statsWriter.Name())
+	if rp != nil {
+		rp.report()
+		if rp.err != nil && err == nil {
+			err = rp.err
+		}
+	}
+	if ol != nil {
+		ol.report()
+	}
+	if runParams.latencyOut != "" {
+		if err := writeLatency(runParams.latencyOut, &accumulatedStats, tsStart); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+		} else {
+			fmt.Printf("Latency histograms written to %s\n", runParams.latencyOut)
+		}
+	}
 	if runParams.cleanUp.Val {
 		cleanup()
 	}
@@ -570,6 +656,32 @@ func addCmdLine(f *flag.FlagSet, p *params) {
 	f.BoolVar(&p.skipList, "skiplist", false, "when true, skip listing objects in a bucket before running 100% PUT workload")
 	f.StringVar(&p.fileList, "filelist", "", "local or locally accessible text file file containing object names (for subsequent reading)")
 
+	//
+	// access patterns
+	//
+	f.StringVar(&p.opMixStr, "opmix", "",
+		"weighted mix of operations, e.g. \"get=70,put=20,head=5,del=5\" (supported: get, put, head, list, del, append, archpath, mobj);\n"+
+			"takes precedence over '-pctput'")
+	f.StringVar(&p.dist, "dist", "",
+		"GET key distribution: uniform (default, see also '-uniquegets') | zipf[:<s>] (default s=1.1) | hotspot[:<keys%>:<ops%>] (default 20:80)")
+	f.StringVar(&p.traceFile, "trace", "",
+		"trace to replay open-loop: CSV file with \"timestamp,op,bucket,object[,size[,range[,extra]]]\" records (see docs/aisloader.md)")
+	f.Float64Var(&p.traceRate, "trace-rate", 1,
+		"trace replay rate multiplier (1 - original rate, 2 - twice as fast, 0 - ignore timestamps)")
+
+	//
+	// open-loop
+	//
+	f.Float64Var(&p.rate, "rate", 0,
+		"open-loop target rate (ops/sec): schedule requests on a fixed timeline regardless of completions\n"+
+			"and measure latency from the intended start time ('-numworkers' bounds requests in flight); 0 - closed-loop")
+	f.Float64Var(&p.rateFrom, "rate-from", 0, "open-loop: initial rate (ops/sec) to linearly ramp from (requires '-rate-ramp')")
+	f.DurationVar(&p.rateRamp, "rate-ramp", 0, "open-loop: ramp duration (from '-rate-from' to '-rate')")
+	f.StringVar(&p.latencyOut, "latency-out", "",
+		"upon completion, write per-op latency histograms to this file (to merge across multiple loaders with '-latency-merge')")
+	f.StringVar(&p.latencyMerge, "latency-merge", "",
+		"merge latency histograms from the files matching this pattern (e.g. \"/tmp/lat-*.json\"), show percentiles, and exit")
+
 	//
 	// object naming
 	//
@@ -681,7 +793,7 @@ func _init(p *params) (err error) {
 	}
 
 	if !p.duration.IsSet {
-		if p.putSizeUpperBound != 0 || p.numEpochs != 0 {
+		if p.putSizeUpperBound != 0 || p.numEpochs != 0 || p.traceFile != "" {
 			// user specified putSizeUpperBound or numEpochs, but not duration, override default 1 minute
 			// and run aisloader until other threshold is reached
 			p.duration.Val = time.Duration(math.MaxInt64)
@@ -699,6 +811,10 @@ func _init(p *params) (err error) {
 		return fmt.Errorf("invalid option: PUT percent %d", p.putPct)
 	}
 
+	if err := p.initAccess(); err != nil {
+		return err
+	}
+
 	if p.skipList {
 		if p.fileList != "" {
 			fmt.Printf("Warning: '-skiplist' is redundant (implied) when '-filelist' is specified")
@@ -730,6 +846,11 @@ func _init(p *params) (err error) {
 		if p.readOffStr != "" || p.readLenStr != "" {
 			return errors.New("direct S3 access via '-s3endpoint': Read range is not supported yet")
 		}
+		for _, ow := range p.opMix {
+			if ow.op != opGet && ow.op != opPut {
+				return fmt.Errorf("direct S3 access via '-s3endpoint': op %q is not supported yet", opNames[ow.op])
+			}
+		}
 	}
 
 	if p.statsShowInterval < 0 {
@@ -930,6 +1051,88 @@ func _init(p *params) (err error) {
 	return nil
 }
 
+// validate and parse '-opmix', '-dist', and '-trace'
+func (p *params) initAccess() (err error) {
+	if p.opMixStr != "" {
+		if p.getConfig || p.traceFile != "" {
+			return errors.New("'-opmix' cannot be used together with '-getconfig' or '-trace'")
+		}
+		if p.putPct != 0 {
+			return errors.New("'-opmix' and '-pctput' are mutually exclusive")
+		}
+		if p.opMix, p.opTotal, err = parseOpMix(p.opMixStr); err != nil {
+			return err
+		}
+		for _, ow := range p.opMix {
+			switch ow.op {
+			case opPut, opAppend:
+				p.mixWrites = true
+			case opDel:
+				p.mixDeletes = true
+			}
+		}
+	}
+
+	kind, args, _ := strings.Cut(p.dist, ":")
+	switch kind {
+	case "", "uniform":
+		if args != "" {
+			return fmt.Errorf("invalid '-dist=%s'", p.dist)
+		}
+	case "zipf":
+		p.zipfS = 1.1
+		if args != "" {
+			if p.zipfS, err = strconv.ParseFloat(args, 64); err != nil || p.zipfS <= 1 {
+				return fmt.Errorf("invalid '-dist=%s': zipf exponent must be a number greater than 1", p.dist)
+			}
+		}
+	case "hotspot":
+		p.hotKeys, p.hotOps = 20, 80
+		if args != "" {
+			keys, ops, ok := strings.Cut(args, ":")
+			if !ok {
+				return fmt.Errorf("invalid '-dist=%s': expecting hotspot:<keys%%>:<ops%%>", p.dist)
+			}
+			p.hotKeys, err = strconv.Atoi(keys)
+			if err == nil {
+				p.hotOps, err = strconv.Atoi(ops)
+			}
+			if err != nil || p.hotKeys <= 0 || p.hotKeys >= 100 || p.hotOps <= 0 || p.hotOps >= 100 {
+				return fmt.Errorf("invalid '-dist=%s': hotspot percentages must be in the (0, 100) range", p.dist)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid '-dist=%s': expecting uniform, zipf, or hotspot", p.dist)
+	}
+
+	switch {
+	case p.rate < 0 || p.rateFrom < 0 || p.rateRamp < 0:
+		return errors.New("invalid option: negative '-rate', '-rate-from', or '-rate-ramp'")
+	case p.rate == 0 && (p.rateFrom != 0 || p.rateRamp != 0):
+		return errors.New("'-rate-from' and '-rate-ramp' require '-rate'")
+	case p.rateFrom != 0 && p.rateRamp == 0:
+		return errors.New("'-rate-from' requires '-rate-ramp'")
+	case p.rate != 0 && p.traceFile != "":
+		return errors.New("'-rate' and '-trace' are mutually exclusive")
+	}
+
+	if p.traceFile != "" {
+		if p.getConfig || p.putPct != 0 {
+			return errors.New("'-trace' cannot be used together with '-getconfig' or '-pctput'")
+		}
+		if p.bck.Name == "" {
+			return errors.New("'-trace' requires '-bucket' (the default for trace records that do not specify one)")
+		}
+		if p.traceRate < 0 {
+			return fmt.Errorf("invalid '-trace-rate=%v'", p.traceRate)
+		}
+		if err := cos.Stat(p.traceFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func isDirectS3() bool {
 	debug.Assert(flag.Parsed())
 	return s3Endpoint != ""
@@ -968,6 +1171,12 @@ func newStats(t time.Time) sts {
 		put:       stats.NewHTTPReq(t),
 		get:       stats.NewHTTPReq(t),
 		getConfig: stats.NewHTTPReq(t),
+		head:      stats.NewHTTPReq(t),
+		list:      stats.NewHTTPReq(t),
+		del:       stats.NewHTTPReq(t),
+		appnd:     stats.NewHTTPReq(t),
+		arch:      stats.NewHTTPReq(t),
+		mobj:      stats.NewHTTPReq(t),
 		statsd:    stats.NewStatsdMetrics(t),
 	}
 }
@@ -977,6 +1186,37 @@ func (s *sts) aggregate(other *sts) {
 	s.get.Aggregate(other.get)
 	s.put.Aggregate(other.put)
 	s.getConfig.Aggregate(other.getConfig)
+	for _, op := range otherOps {
+		s.req(op).Aggregate(*other.req(op))
+	}
+}
+
+// ops other than GET, PUT, and get-config
+var otherOps = [...]int{opHead, opList, opDel, opAppend, opArch, opMobj}
+
+func (s *sts) req(op int) *stats.HTTPReq {
+	switch op {
+	case opPut:
+		return &s.put
+	case opGet:
+		return &s.get
+	case opConfig:
+		return &s.getConfig
+	case opHead:
+		return &s.head
+	case opList:
+		return &s.list
+	case opDel:
+		return &s.del
+	case opAppend:
+		return &s.appnd
+	case opArch:
+		return &s.arch
+	case opMobj:
+		return &s.mobj
+	}
+	debug.Assert(false, op)
+	return nil
 }
 
 func setupBucket(runParams *params, created *bool) error {
@@ -1090,18 +1330,19 @@ func cleanup() {
 	if bucketObjsNames != nil {
 		// `bucketObjsNames` has been actually assigned to/initialized.
 		var (
+			names   = append(bucketObjsNames.Names(), delNames...) // (not deleted yet)
 			w       = runParams.numWorkers
-			objsLen = bucketObjsNames.Len()
+			objsLen = len(names)
 			n       = objsLen / w
 			wg      = &sync.WaitGroup{}
 		)
 		for i := range w {
 			wg.Add(1)
-			go cleanupObjs(bucketObjsNames.Names()[i*n:(i+1)*n], wg)
+			go cleanupObjs(names[i*n:(i+1)*n], wg)
 		}
 		if objsLen%w != 0 {
 			wg.Add(1)
-			go cleanupObjs(bucketObjsNames.Names()[n*w:], wg)
+			go cleanupObjs(names[n*w:], wg)
 		}
 		wg.Wait()
 	}
@@ -1176,6 +1417,18 @@ func objNamesFromFile() (names []string, err error) {
 	return
 }
 
+// random (default) or skewed as per '-dist'
+func newNameGetter() namegetter.ObjectNameGetter {
+	switch {
+	case runParams.zipfS != 0:
+		return &namegetter.ZipfNameGetter{S: runParams.zipfS}
+	case runParams.hotKeys != 0:
+		return &namegetter.HotspotNameGetter{HotKeys: runParams.hotKeys, HotOps: runParams.hotOps}
+	default:
+		return &namegetter.RandomNameGetter{}
+	}
+}
+
 func listObjects() error {
 	var (
 		names []string
@@ -1193,13 +1446,14 @@ func listObjects() error {
 		return err
 	}
 
-	if !runParams.uniqueGETs {
-		bucketObjsNames = &namegetter.RandomNameGetter{}
-	} else {
+	switch {
+	case runParams.zipfS != 0 || runParams.hotKeys != 0 || !runParams.uniqueGETs:
+		bucketObjsNames = newNameGetter()
+	default:
 		bucketObjsNames = &namegetter.RandomUniqueNameGetter{}
 
 		// Permutation strategies seem to be always better (they use more memory though)
-		if runParams.putPct == 0 {
+		if runParams.putPct == 0 && !runParams.mixWrites {
 			bucketObjsNames = &namegetter.PermutationUniqueNameGetter{}
 
 			// Number from benchmarks: aisloader/tests/objnamegetter_test.go
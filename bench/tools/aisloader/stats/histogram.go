@@ -0,0 +1,124 @@
+// Package stats provides various structs for collecting stats
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"errors"
+	"math/bits"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Histogram is a log-linear (HDR-style) latency histogram:
+// values below 2^histSubBits are recorded exactly, larger values - in buckets
+// that subdivide each power of two into 2^(histSubBits-1) linear sub-buckets,
+// which bounds the relative error of any reported percentile to ~1.6%.
+// Same as HTTPReq, it assumes single threaded access.
+type Histogram struct {
+	counts []int64 // allocated upon the first Record()
+	total  int64
+}
+
+const (
+	histSubBits = 7
+	histSub     = 1 << histSubBits
+	histHalf    = histSub >> 1
+	histLen     = histSub + (63-histSubBits)*histHalf // (max int64 maps into the last bucket)
+)
+
+func histIndex(v int64) int {
+	if v < histSub {
+		return int(max(v, 0))
+	}
+	e := bits.Len64(uint64(v)) - histSubBits // >= 1
+	m := int(v >> e)                         // [histHalf, histSub)
+	return histSub + (e-1)*histHalf + m - histHalf
+}
+
+// the highest value that maps into a given bucket
+func histValue(idx int) int64 {
+	if idx < histSub {
+		return int64(idx)
+	}
+	var (
+		e = (idx-histSub)/histHalf + 1
+		m = int64((idx-histSub)%histHalf + histHalf)
+	)
+	return (m+1)<<e - 1
+}
+
+// Record adds a single value (nanoseconds, in aisloader)
+func (h *Histogram) Record(v int64) { h.RecordN(v, 1) }
+
+// RecordN adds the same value n times
+func (h *Histogram) RecordN(v, n int64) {
+	if h.counts == nil {
+		h.counts = make([]int64, histLen)
+	}
+	h.counts[histIndex(v)] += n
+	h.total += n
+}
+
+// Count returns the number of recorded values.
+func (h *Histogram) Count() int64 { return h.total }
+
+// Merge adds all values recorded by another histogram
+func (h *Histogram) Merge(other *Histogram) {
+	if other == nil || other.total == 0 {
+		return
+	}
+	if h.counts == nil {
+		h.counts = make([]int64, histLen)
+	}
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+	h.total += other.total
+}
+
+// Percentile returns the value at the given percentile (0 < p <= 100), or zero when empty.
+func (h *Histogram) Percentile(p float64) int64 {
+	if h.total == 0 {
+		return 0
+	}
+	rank := int64(float64(h.total)*p/100 + 0.5)
+	rank = min(max(rank, 1), h.total)
+	var cnt int64
+	for i, c := range h.counts {
+		cnt += c
+		if cnt >= rank {
+			return histValue(i)
+		}
+	}
+	return histValue(histLen - 1)
+}
+
+// JSON: sparse list of [value, count] pairs, where value is the highest value
+// that maps into a given bucket - so that histograms recorded by different
+// aisloader instances can be merged (see aisloader '-latency-merge').
+
+func (h *Histogram) MarshalJSON() ([]byte, error) {
+	pairs := make([][2]int64, 0, 64)
+	for i, c := range h.counts {
+		if c != 0 {
+			pairs = append(pairs, [2]int64{histValue(i), c})
+		}
+	}
+	return jsoniter.Marshal(pairs)
+}
+
+func (h *Histogram) UnmarshalJSON(b []byte) error {
+	var pairs [][2]int64
+	if err := jsoniter.Unmarshal(b, &pairs); err != nil {
+		return err
+	}
+	for _, pair := range pairs {
+		if pair[0] < 0 || pair[1] < 0 {
+			return errors.New("invalid histogram: negative value or count")
+		}
+		h.RecordN(pair[0], pair[1])
+	}
+	return nil
+}
@@ -1,6 +1,6 @@
 // Package stats provides various structs for collecting stats
 /*
- * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package stats
 
@@ -17,6 +17,7 @@ type HTTPReq struct {
 	bytes   int64         // total bytes by all requests
 	errs    int64         // number of failed requests
 	latency time.Duration // Accumulated request latency
+	lat     *Histogram    // latency distribution (percentiles)
 
 	// self maintained fields
 	minLatency time.Duration
@@ -28,6 +29,7 @@ func NewHTTPReq(t time.Time) HTTPReq {
 	return HTTPReq{
 		start:      t,
 		minLatency: time.Duration(math.MaxInt64),
+		lat:        &Histogram{},
 	}
 }
 
@@ -38,6 +40,7 @@ func (s *HTTPReq) Add(size int64, delta time.Duration) {
 	s.latency += delta
 	s.minLatency = min(s.minLatency, delta)
 	s.maxLatency = max(s.maxLatency, delta)
+	s.lat.Record(int64(delta))
 }
 
 // AddErr increases the number of failed count by 1
@@ -79,6 +82,16 @@ func (s *HTTPReq) AvgLatency() int64 {
 	return int64(s.latency) / s.cnt
 }
 
+// Percentile returns the p-th percentile latency in nano second.
+func (s *HTTPReq) Percentile(p float64) int64 {
+	return s.lat.Percentile(p)
+}
+
+// Histogram returns the latency histogram.
+func (s *HTTPReq) Histogram() *Histogram {
+	return s.lat
+}
+
 // Throughput returns throughput of requests (bytes/per second).
 func (s *HTTPReq) Throughput(start, end time.Time) int64 {
 	if start == end {
@@ -106,4 +119,5 @@ func (s *HTTPReq) Aggregate(other HTTPReq) {
 
 	s.minLatency = min(s.minLatency, other.minLatency)
 	s.maxLatency = max(s.maxLatency, other.maxLatency)
+	s.lat.Merge(other.lat)
 }
@@ -70,3 +70,47 @@ func TestStats(t *testing.T) {
 	verify(t, "Max latency", 100000000, total.MaxLatency())
 	verify(t, "Throughput", 5, total.Throughput(start, start.Add(70*time.Second)))
 }
+
+func TestHistogram(t *testing.T) {
+	var h stats.Histogram
+	verify(t, "Empty", 0, h.Percentile(50))
+
+	// 1..100ms
+	for i := 1; i <= 100; i++ {
+		h.Record(int64(i) * int64(time.Millisecond))
+	}
+	for _, p := range []float64{50, 90, 99} {
+		exp := int64(p) * int64(time.Millisecond)
+		act := h.Percentile(p)
+		if act < exp || float64(act-exp) > float64(exp)*0.02 {
+			t.Fatalf("p%v: expected %d (+2%%), actual %d", p, exp, act)
+		}
+	}
+
+	// merge and small (exact) values
+	var h2 stats.Histogram
+	for range 300 {
+		h2.Record(7)
+	}
+	h2.Merge(&h)
+	verify(t, "Count", 400, h2.Count())
+	verify(t, "p50", 7, h2.Percentile(50))
+}
+
+func TestHistogramJSON(t *testing.T) {
+	var h, h2 stats.Histogram
+	for i := 1; i <= 1000; i++ {
+		h.Record(int64(i) * int64(time.Microsecond))
+	}
+	b, err := h.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h2.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	verify(t, "Count", h.Count(), h2.Count())
+	for _, p := range []float64{50, 90, 99, 99.9, 100} {
+		verify(t, "Percentile", h.Percentile(p), h2.Percentile(p))
+	}
+}
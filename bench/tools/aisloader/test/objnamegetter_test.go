@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/NVIDIA/aistore/bench/tools/aisloader/namegetter"
@@ -103,6 +104,39 @@ func TestPermutationUniqueImprovedNameGetter(t *testing.T) {
 	checkSmallSampleRandomness(t, ng, "PermutationUniqueImprovedNameGetter")
 }
 
+func TestZipfNameGetter(t *testing.T) {
+	ng := &namegetter.ZipfNameGetter{S: 1.2}
+	checkSkew(t, ng, "ZipfNameGetter", 1, 10)
+	checkSmallSampleRandomness(t, ng, "ZipfNameGetter")
+}
+
+func TestHotspotNameGetter(t *testing.T) {
+	ng := &namegetter.HotspotNameGetter{HotKeys: 10, HotOps: 90}
+	checkSkew(t, ng, "HotspotNameGetter", 10, 80)
+	checkSmallSampleRandomness(t, ng, "HotspotNameGetter")
+}
+
+// the top `keysPct` percent of the most frequently accessed names must get at least `opsPct` percent of all accesses
+func checkSkew(t *testing.T, getter namegetter.ObjectNameGetter, name string, keysPct, opsPct int) {
+	getter.Init(objNames, cos.NowRand())
+	m := make(map[string]int, objNamesSize)
+	for range objNamesSize {
+		m[getter.ObjName()]++
+	}
+	counts := make([]int, 0, len(m))
+	for _, c := range m {
+		counts = append(counts, c)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
+
+	var top int
+	for i := 0; i < objNamesSize*keysPct/100 && i < len(counts); i++ {
+		top += counts[i]
+	}
+	tassert.Fatalf(t, top*100 >= objNamesSize*opsPct, "%s: top %d%% names got %d%% accesses, expected at least %d%%",
+		name, keysPct, top*100/objNamesSize, opsPct)
+}
+
 func checkGetsAllObjNames(t *testing.T, getter namegetter.ObjectNameGetter, name string) {
 	getter.Init(objNames, cos.NowRand())
 	m := make(map[string]struct{})
@@ -12,11 +12,14 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/bench/tools/aisloader/stats"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -29,29 +32,81 @@ const (
 	opPut = iota
 	opGet
 	opConfig
+	opHead
+	opList
+	opDel
+	opAppend
+	opArch // GET a file from a shard (archpath)
+	opMobj // multi-object (archiving) job
 )
 
+// (lowercase names are used in the command line and traces)
+var opNames = [...]string{
+	opPut:    "put",
+	opGet:    "get",
+	opConfig: "cfg",
+	opHead:   "head",
+	opList:   "list",
+	opDel:    "del",
+	opAppend: "append",
+	opArch:   "archpath",
+	opMobj:   "mobj",
+}
+
 type (
 	workOrder struct {
 		op        int
 		proxyURL  string
 		bck       cmn.Bck
 		objName   string // In the format of 'virtual dir' + "/" + objName
+		archPath  string // opArch only
+		lr        apc.ListRange
 		size      int64
+		readOff   int64
+		readLen   int64
 		err       error
+		intended  time.Time // open-loop: scheduled start (zero otherwise)
 		start     time.Time
 		end       time.Time
 		latencies httpLatencies
 		cksumType string
 		sgl       *memsys.SGL
 	}
+
+	// weighted op as per '-opmix'
+	opWeight struct {
+		op     int
+		weight int
+	}
+	// shard created by this run (to subsequently read from)
+	shard struct {
+		name    string
+		members []string
+	}
+)
+
+var (
+	// objects written by this run (and not yet deleted) when '-opmix' includes deletes;
+	// otherwise, written objects are added to `bucketObjsNames`
+	// either way, written objects are readable - see readName()
+	delNames []string
+	shards   []shard
 )
 
-func postNewWorkOrder() (err error) {
-	var wo *workOrder
+func postNewWorkOrder() error {
+	wo, err := newWorkOrder()
+	if err == nil {
+		workCh <- wo
+	}
+	return err
+}
+
+func newWorkOrder() (wo *workOrder, err error) {
 	switch {
 	case runParams.getConfig:
 		wo = newGetConfigWorkOrder()
+	case len(runParams.opMix) > 0:
+		wo, err = newMixWorkOrder()
 	case runParams.putPct == 100:
 		wo, err = newPutWorkOrder()
 	case runParams.putPct == 0:
@@ -69,12 +124,146 @@ func postNewWorkOrder() (err error) {
 			wo, err = newGetWorkOrder()
 		}
 	}
-	if err == nil {
-		workCh <- wo
-	}
 	return
 }
 
+// open-loop (trace replay and '-rate'): execute asynchronously, bypassing workers
+func goWorkOrder(wo *workOrder, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		runWorkOrder(wo, &numGets)
+		resCh <- wo
+		wg.Done()
+	}()
+}
+
+func newMixWorkOrder() (*workOrder, error) {
+	op := pickOp()
+	switch op {
+	case opGet, opHead, opList, opArch, opMobj:
+		// nothing to read yet
+		if numReadable() == 0 && runParams.mixWrites {
+			op = opPut
+		}
+	}
+	switch op {
+	case opPut:
+		return newPutWorkOrder()
+	case opGet:
+		return newGetWorkOrder()
+	case opHead:
+		return newHeadWorkOrder()
+	case opList:
+		return &workOrder{proxyURL: runParams.proxyURL, bck: runParams.bck, op: opList, objName: runParams.subDir}, nil
+	case opDel:
+		// nothing to delete yet
+		if len(delNames) == 0 {
+			if runParams.mixWrites {
+				return newPutWorkOrder()
+			}
+			return newGetWorkOrder()
+		}
+		objName := delNames[0]
+		delNames = delNames[1:]
+		return &workOrder{proxyURL: runParams.proxyURL, bck: runParams.bck, op: opDel, objName: objName}, nil
+	case opAppend:
+		objName, err := _genObjName()
+		if err != nil {
+			return nil, err
+		}
+		return &workOrder{proxyURL: runParams.proxyURL, bck: runParams.bck, op: opAppend, objName: objName, size: randSize()}, nil
+	case opArch:
+		if len(shards) == 0 {
+			return newGetWorkOrder()
+		}
+		sh := &shards[rnd.IntN(len(shards))]
+		return &workOrder{
+			proxyURL: runParams.proxyURL,
+			bck:      runParams.bck,
+			op:       opArch,
+			objName:  sh.name,
+			archPath: sh.members[rnd.IntN(len(sh.members))],
+		}, nil
+	case opMobj:
+		return newMobjWorkOrder()
+	default:
+		debug.Assert(false, op)
+		return nil, nil
+	}
+}
+
+func pickOp() int {
+	n := rnd.IntN(runParams.opTotal)
+	for _, ow := range runParams.opMix {
+		if n < ow.weight {
+			return ow.op
+		}
+		n -= ow.weight
+	}
+	return runParams.opMix[len(runParams.opMix)-1].op
+}
+
+// e.g. "get=70,put=20,head=5,del=5"
+func parseOpMix(s string) (mix []opWeight, total int, err error) {
+	for _, kv := range strings.Split(s, ",") {
+		name, val, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid op mix %q: expecting comma-separated <op>=<weight>", s)
+		}
+		op := opByName(name)
+		if op < 0 || op == opConfig {
+			return nil, 0, fmt.Errorf("invalid op mix %q: unknown op %q", s, name)
+		}
+		w, err := strconv.Atoi(val)
+		if err != nil || w < 0 {
+			return nil, 0, fmt.Errorf("invalid op mix %q: invalid weight %q", s, val)
+		}
+		if w > 0 {
+			mix = append(mix, opWeight{op: op, weight: w})
+			total += w
+		}
+	}
+	if total == 0 {
+		return nil, 0, fmt.Errorf("invalid op mix %q: all weights are zero", s)
+	}
+	return mix, total, nil
+}
+
+func opByName(name string) int {
+	name = strings.ToLower(name)
+	if name == "delete" {
+		return opDel
+	}
+	for op, n := range opNames {
+		if n == name {
+			return op
+		}
+	}
+	return -1
+}
+
+// an object (that was PUT or appended) is now readable or deletable
+func addWritten(objName string) {
+	if runParams.mixDeletes {
+		delNames = append(delNames, objName)
+	} else {
+		bucketObjsNames.AddObjName(objName)
+	}
+}
+
+func numReadable() int { return bucketObjsNames.Len() + len(delNames) }
+
+// uniformly, out of all readable objects, including those written by this run
+// and not yet deleted (note that '-zipf' and '-hotspot' only apply to the former)
+func readName() string {
+	if n := len(delNames); n > 0 {
+		if i := rnd.IntN(bucketObjsNames.Len() + n); i < n {
+			return delNames[i]
+		}
+	}
+	return bucketObjsNames.ObjName()
+}
+
 func validateWorkOrder(wo *workOrder, delta time.Duration) error {
 	if wo.op == opGet || wo.op == opPut {
 		if delta == 0 {
@@ -86,6 +275,10 @@ func validateWorkOrder(wo *workOrder, delta time.Duration) error {
 
 func completeWorkOrder(wo *workOrder, terminating bool) {
 	delta := timeDelta(wo.end, wo.start)
+	if !wo.intended.IsZero() {
+		// open-loop latency includes the time spent waiting to start (no coordinated omission)
+		delta = timeDelta(wo.end, wo.intended)
+	}
 
 	if wo.err == nil && traceHTTPSig.Load() {
 		var lat *stats.MetricLatsAgg
@@ -131,7 +324,7 @@ func completeWorkOrder(wo *workOrder, terminating bool) {
 		putPending--
 		intervalStats.statsd.Put.AddPending(putPending)
 		if wo.err == nil {
-			bucketObjsNames.AddObjName(wo.objName)
+			addWritten(wo.objName)
 			intervalStats.put.Add(wo.size, delta)
 			intervalStats.statsd.Put.Add(wo.size, delta)
 		} else {
@@ -175,7 +368,19 @@ func completeWorkOrder(wo *workOrder, terminating bool) {
 			intervalStats.getConfig.AddErr()
 		}
 	default:
-		debug.Assert(false) // Should never be here
+		r := intervalStats.req(wo.op)
+		if wo.err != nil {
+			fmt.Println(strings.ToUpper(opNames[wo.op])+" failed: ", wo.err)
+			r.AddErr()
+			return
+		}
+		r.Add(wo.size, delta)
+		switch wo.op {
+		case opAppend:
+			addWritten(wo.objName)
+		case opMobj:
+			shards = append(shards, shard{name: wo.objName, members: wo.lr.ObjNames})
+		}
 	}
 }
 
@@ -201,13 +406,7 @@ func doPut(wo *workOrder) {
 		return
 	}
 	if runParams.randomProxy {
-		debug.Assert(!isDirectS3())
-		psi, err := runParams.smap.GetRandProxy(false /*excl. primary*/)
-		if err != nil {
-			fmt.Printf("PUT(wo): %v\n", err)
-			os.Exit(1)
-		}
-		url = psi.URL(cmn.NetPublic)
+		url = randomProxyURL("PUT")
 	}
 	if !traceHTTPSig.Load() {
 		if isDirectS3() {
@@ -225,30 +424,60 @@ func doPut(wo *workOrder) {
 	}
 }
 
+func randomProxyURL(tag string) string {
+	debug.Assert(!isDirectS3())
+	psi, err := runParams.smap.GetRandProxy(false /*excl. primary*/)
+	if err != nil {
+		fmt.Printf("%s(wo): %v\n", tag, err)
+		os.Exit(1)
+	}
+	return psi.URL(cmn.NetPublic)
+}
+
 func doGet(wo *workOrder) {
 	var (
 		url = wo.proxyURL
 	)
 	if runParams.randomProxy {
-		debug.Assert(!isDirectS3())
-		psi, err := runParams.smap.GetRandProxy(false /*excl. primary*/)
-		if err != nil {
-			fmt.Printf("GET(wo): %v\n", err)
-			os.Exit(1)
-		}
-		url = psi.URL(cmn.NetPublic)
+		url = randomProxyURL("GET")
 	}
 	if !traceHTTPSig.Load() {
 		if isDirectS3() {
 			wo.size, wo.err = s3getDiscard(wo.bck, wo.objName)
 		} else {
 			wo.size, wo.err = getDiscard(url, wo.bck,
-				wo.objName, runParams.readOff, runParams.readLen, runParams.verifyHash, runParams.latest)
+				wo.objName, wo.archPath, wo.readOff, wo.readLen, runParams.verifyHash, runParams.latest)
 		}
 	} else {
 		debug.Assert(!isDirectS3())
 		wo.size, wo.err = getTraceDiscard(url, wo.bck,
-			wo.objName, &wo.latencies, runParams.readOff, runParams.readLen, runParams.verifyHash, runParams.latest)
+			wo.objName, wo.archPath, &wo.latencies, wo.readOff, wo.readLen, runParams.verifyHash, runParams.latest)
+	}
+}
+
+// HEAD, list, delete, append, and multi-object (aistore only)
+func doOther(wo *workOrder) {
+	url := wo.proxyURL
+	if runParams.randomProxy {
+		url = randomProxyURL(strings.ToUpper(opNames[wo.op]))
+	}
+	switch wo.op {
+	case opHead:
+		wo.size, wo.err = head(url, wo.bck, wo.objName)
+	case opList:
+		// (size is the number of listed entries)
+		wo.size, wo.err = listPage(url, wo.bck, wo.objName)
+	case opDel:
+		wo.err = del(url, wo.bck, wo.objName)
+	case opAppend:
+		r, err := readers.New(readers.Params{Type: readers.TypeRand, Size: wo.size}, cos.ChecksumNone)
+		if err != nil {
+			wo.err = err
+			return
+		}
+		wo.err = appendFlush(url, wo.bck, wo.objName, wo.size, r)
+	case opMobj:
+		wo.err = archiveMultiObj(url, wo.bck, wo.objName, wo.lr)
 	}
 }
 
@@ -264,24 +493,27 @@ func worker(wos <-chan *workOrder, results chan<- *workOrder, wg *sync.WaitGroup
 		if !more {
 			return
 		}
+		runWorkOrder(wo, numGets)
+		results <- wo
+	}
+}
 
-		wo.start = time.Now()
-
-		switch wo.op {
-		case opPut:
-			doPut(wo)
-		case opGet:
-			doGet(wo)
-			numGets.Inc()
-		case opConfig:
-			doGetConfig(wo)
-		default:
-			// Should not come here
-		}
+func runWorkOrder(wo *workOrder, numGets *atomic.Int64) {
+	wo.start = time.Now()
 
-		wo.end = time.Now()
-		results <- wo
+	switch wo.op {
+	case opPut:
+		doPut(wo)
+	case opGet, opArch:
+		doGet(wo)
+		numGets.Inc()
+	case opConfig:
+		doGetConfig(wo)
+	default:
+		doOther(wo)
 	}
+
+	wo.end = time.Now()
 }
 
 ///////////////
@@ -293,22 +525,26 @@ func newPutWorkOrder() (*workOrder, error) {
 	if err != nil {
 		return nil, err
 	}
-	size := runParams.minSize
-	if runParams.maxSize != runParams.minSize {
-		d := rnd.Int64N(runParams.maxSize + 1 - runParams.minSize)
-		size = runParams.minSize + d
-	}
 	putPending++
 	return &workOrder{
 		proxyURL:  runParams.proxyURL,
 		bck:       runParams.bck,
 		op:        opPut,
 		objName:   objName,
-		size:      size,
+		size:      randSize(),
 		cksumType: runParams.cksumType,
 	}, nil
 }
 
+func randSize() int64 {
+	size := runParams.minSize
+	if runParams.maxSize != runParams.minSize {
+		d := rnd.Int64N(runParams.maxSize + 1 - runParams.minSize)
+		size = runParams.minSize + d
+	}
+	return size
+}
+
 func _genObjName() (string, error) {
 	cnt := objNameCnt.Inc()
 	if runParams.maxputs != 0 && cnt-1 == runParams.maxputs {
@@ -344,7 +580,7 @@ func _genObjName() (string, error) {
 }
 
 func newGetWorkOrder() (*workOrder, error) {
-	if bucketObjsNames.Len() == 0 {
+	if numReadable() == 0 {
 		return nil, errors.New("no objects in bucket")
 	}
 
@@ -353,7 +589,49 @@ func newGetWorkOrder() (*workOrder, error) {
 		proxyURL: runParams.proxyURL,
 		bck:      runParams.bck,
 		op:       opGet,
-		objName:  bucketObjsNames.ObjName(),
+		objName:  readName(),
+		readOff:  runParams.readOff,
+		readLen:  runParams.readLen,
+	}, nil
+}
+
+func newHeadWorkOrder() (*workOrder, error) {
+	if numReadable() == 0 {
+		return nil, errors.New("no objects in bucket")
+	}
+	return &workOrder{
+		proxyURL: runParams.proxyURL,
+		bck:      runParams.bck,
+		op:       opHead,
+		objName:  readName(),
+	}, nil
+}
+
+// archive up to '-batchsize' objects into a new (uniquely named) shard
+func newMobjWorkOrder() (*workOrder, error) {
+	l := numReadable()
+	if l == 0 {
+		return nil, errors.New("no objects in bucket")
+	}
+	var (
+		n       = min(runParams.batchSize, l)
+		names   = make([]string, 0, n)
+		dedup   = make(map[string]struct{}, n)
+		objName = path.Join(runParams.subDir, "mobj-"+cos.RandStringWithSrc(rnd, 12)+archive.ExtTar)
+	)
+	for range n {
+		name := readName()
+		if _, ok := dedup[name]; !ok {
+			dedup[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return &workOrder{
+		proxyURL: runParams.proxyURL,
+		bck:      runParams.bck,
+		op:       opMobj,
+		objName:  objName,
+		lr:       apc.ListRange{ObjNames: names},
 	}, nil
 }
 
@@ -373,6 +651,8 @@ func (wo *workOrder) String() string {
 		opName = http.MethodPut
 	case opConfig:
 		opName = "CONFIG"
+	default:
+		opName = strings.ToUpper(opNames[wo.op])
 	}
 
 	if wo.err != nil {
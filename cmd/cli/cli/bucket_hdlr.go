@@ -88,12 +88,22 @@ var (
 			forceFlag,
 			copyDryRunFlag,
 			copyPrependFlag,
+			copyRenameRegexFlag,
+			copyRenameReplaceFlag,
+			copyMinSizeFlag,
+			copyMaxSizeFlag,
+			copyMtimeAfterFlag,
+			copyMtimeBeforeFlag,
+			copyAtimeAfterFlag,
+			copyAtimeBeforeFlag,
+			copyCustomMDFlag,
 			progressFlag,
 			refreshFlag,
 			waitFlag,
 			waitJobXactFinishedFlag,
 			latestVerFlag,
 			syncFlag,
+			queueJobFlag,
 			nonverboseFlag,
 		},
 		commandRename: {
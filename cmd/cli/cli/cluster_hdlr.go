@@ -9,9 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmd/cli/teb"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/urfave/cli"
@@ -46,6 +49,12 @@ var (
 			noRebalanceFlag,
 			yesFlag,
 		},
+		cmdDrain: {
+			waitFlag,
+			waitJobXactFinishedFlag,
+			refreshFlag,
+			yesFlag,
+		},
 		cmdShutdown + ".node": {
 			noRebalanceFlag,
 			rmUserDataFlag,
@@ -154,6 +163,16 @@ var (
 				Action: clusterDecommissionHandler,
 			},
 			// node level
+			{
+				Name: cmdDrain,
+				Usage: "migrate all data off of a given target and then put it in maintenance mode;\n" +
+					indent4 + "\twith '--wait': show remaining objects and bytes, throughput, and ETA\n" +
+					indent4 + "\tuntil the node is safe to power off",
+				ArgsUsage:    nodeIDArgument,
+				Flags:        clusterCmdsFlags[cmdDrain],
+				Action:       drainNodeHandler,
+				BashComplete: suggestTargets,
+			},
 			{
 				Name:  cmdMembership,
 				Usage: "manage cluster membership (add/remove nodes, temporarily or permanently)",
@@ -345,6 +364,87 @@ func joinNodeHandler(c *cli.Context) (err error) {
 	return
 }
 
+// drain target: global rebalance => maintenance mode
+// (compare with start-maintenance in nodeMaintShutDecommHandler below)
+func drainNodeHandler(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	node, sname, err := getNode(c, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	if !node.IsTarget() {
+		return fmt.Errorf("%s is not a target (only targets store data and can be drained)", sname)
+	}
+	config, err := api.GetClusterConfig(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	if !config.Rebalance.Enabled {
+		return errors.New("cannot drain " + sname + " with global rebalance disabled (see 'ais config cluster rebalance')")
+	}
+	if !flagIsSet(c, yesFlag) {
+		warn := fmt.Sprintf("about to migrate all data off of %s and put it in maintenance mode", sname)
+		if ok := confirm(c, "Proceed?", warn); !ok {
+			return nil
+		}
+	}
+	xid, err := api.DrainNode(apiBP, node.ID())
+	if err != nil {
+		return V(err)
+	}
+	if xid != "" {
+		fmt.Fprintf(c.App.Writer, fmtRebalanceStarted, xid)
+	}
+	if !flagIsSet(c, waitFlag) && !flagIsSet(c, waitJobXactFinishedFlag) {
+		fmt.Fprintf(c.App.Writer, "%s is being drained; to monitor, run 'ais cluster %s %s --wait'\n",
+			sname, cmdDrain, node.ID())
+		return nil
+	}
+	return waitDrained(c, node.ID(), sname)
+}
+
+func waitDrained(c *cli.Context, tid, sname string) error {
+	var (
+		timeout time.Duration
+		sleep   = _refreshRate(c)
+		started = time.Now()
+	)
+	if flagIsSet(c, waitJobXactFinishedFlag) {
+		timeout = parseDurationFlag(c, waitJobXactFinishedFlag)
+	}
+	for {
+		ds, err := api.GetDrainStatus(apiBP, tid)
+		if err != nil {
+			return V(err)
+		}
+		switch {
+		case ds.Safe:
+			fmt.Fprintf(c.App.Writer, "%s: drained %d objects (%s) - safe to power off\n",
+				sname, ds.SentObjs, teb.FmtSize(ds.SentBytes, "", 2))
+			return nil
+		case ds.Stage == cmn.DrainAborted:
+			return fmt.Errorf("%s: drain failed: %s", sname, ds.Err)
+		case ds.Stage == cmn.DrainMigrating:
+			eta := teb.NotSetVal
+			if ds.ETA > 0 {
+				eta = ds.ETA.String()
+			}
+			fmt.Fprintf(c.App.Writer, "%s: remaining %d objects (%s), throughput %s/s, ETA %s\n",
+				sname, ds.RemObjs, teb.FmtSize(ds.RemBytes, "", 2), teb.FmtSize(ds.Throughput, "", 2), eta)
+		case ds.Stage == cmn.DrainVerifying:
+			fmt.Fprintf(c.App.Writer, "%s: verifying...\n", sname)
+		default:
+			fmt.Fprintf(c.App.Writer, "%s: %s...\n", sname, ds.Stage)
+		}
+		if timeout > 0 && time.Since(started) > timeout {
+			return fmt.Errorf("timed out waiting for %s to drain", sname)
+		}
+		time.Sleep(sleep)
+	}
+}
+
 // (compare w/ cluster-level clusterDecommissionHandler & clusterShutdownHandler)
 func nodeMaintShutDecommHandler(c *cli.Context) error {
 	if c.NArg() < 1 {
@@ -56,6 +56,7 @@ var (
 		"distributed_sort.duplicated_records": cmn.SupportedReactions,
 		"distributed_sort.ekm_malformed_line": cmn.SupportedReactions,
 		"distributed_sort.ekm_missing_key":    cmn.SupportedReactions,
+		"distributed_sort.etl_error":          cmn.SupportedReactions,
 		"distributed_sort.missing_shards":     cmn.SupportedReactions,
 		"auth.enabled":                        supportedBool,
 		"checksum.enabl_read_range":           supportedBool,
@@ -69,6 +70,9 @@ var (
 		"rebalance.enabled":                   supportedBool,
 		"resilver.enabled":                    supportedBool,
 		"versioning.enabled":                  supportedBool,
+		"read_only":                           supportedBool,
+		"inventory.enabled":                   supportedBool,
+		"inventory.format":                    {cmn.InvFormatCSV, cmn.InvFormatParquet},
 		"replication.on_cold_get":             supportedBool,
 		"replication.on_lru_eviction":         supportedBool,
 		"replication.on_put":                  supportedBool,
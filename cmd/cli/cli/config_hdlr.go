@@ -10,7 +10,10 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
@@ -81,6 +84,27 @@ var (
 				Flags:        configCmdsFlags[cmdCluster],
 				Action:       setCluConfigHandler,
 				BashComplete: setCluConfigCompletions,
+				Subcommands: []cli.Command{
+					{
+						Name:   cmdCfgHistory,
+						Usage:  "show the last (primary-retained) cluster config and bucket props changes: who, when, and what",
+						Flags:  []cli.Flag{jsonFlag},
+						Action: showCfgHistoryHandler,
+					},
+					{
+						Name:      cmdCfgDiff,
+						Usage:     "show differences between two cluster config versions",
+						ArgsUsage: cfgVersionsArgument,
+						Flags:     []cli.Flag{jsonFlag},
+						Action:    diffCluConfigHandler,
+					},
+					{
+						Name:      cmdCfgRollback,
+						Usage:     "revert cluster config to one of the retained prior versions",
+						ArgsUsage: cfgVersionArgument,
+						Action:    rollbackCluConfigHandler,
+					},
+				},
 			},
 			{
 				Name:         cmdNode,
@@ -419,6 +443,100 @@ func resetNodeConfigHandler(c *cli.Context) error {
 	return nil
 }
 
+//
+// cluster config history, diff, and rollback
+//
+
+func showCfgHistoryHandler(c *cli.Context) error {
+	hist, err := api.GetClusterConfigHistory(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(hist, "", teb.Jopts(true))
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\t WHAT\t ACTION\t WHO\t WHEN\t CHANGES")
+	for i := range hist.Config {
+		_printCfgHistEntry(tw, &hist.Config[i], "cluster config")
+	}
+	for i := range hist.Bprops {
+		entry := &hist.Bprops[i]
+		_printCfgHistEntry(tw, entry, entry.Bck.Cname(""))
+	}
+	return tw.Flush()
+}
+
+func _printCfgHistEntry(tw *tabwriter.Writer, entry *cmn.CfgHistEntry, what string) {
+	var (
+		changes = make([]string, 0, len(entry.Delta))
+		when    = teb.NotSetVal
+		who     = entry.Who
+		action  = entry.Action
+	)
+	for _, d := range entry.Delta {
+		changes = append(changes, d.Name+"="+d.To)
+	}
+	if entry.Time != 0 {
+		when = time.Unix(0, entry.Time).Format(time.Stamp)
+	}
+	if who == "" {
+		who = teb.NotSetVal
+	}
+	if action == "" {
+		action = "(baseline)"
+	}
+	fmt.Fprintf(tw, "%d\t %s\t %s\t %s\t %s\t %s\n", entry.Version, what, action, who, when, strings.Join(changes, ", "))
+}
+
+func diffCluConfigHandler(c *cli.Context) error {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	from, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+	if err != nil {
+		return incorrectUsageMsg(c, "invalid config version %q", c.Args().Get(0))
+	}
+	to, err := strconv.ParseInt(c.Args().Get(1), 10, 64)
+	if err != nil {
+		return incorrectUsageMsg(c, "invalid config version %q", c.Args().Get(1))
+	}
+	delta, err := api.DiffClusterConfig(apiBP, from, to)
+	if err != nil {
+		return V(err)
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(delta, "", teb.Jopts(true))
+	}
+	if len(delta) == 0 {
+		actionDone(c, fmt.Sprintf("No differences between cluster config v%d and v%d", from, to))
+		return nil
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "PROPERTY\t v%d\t v%d\n", from, to)
+	for _, d := range delta {
+		fmt.Fprintf(tw, "%s\t %s\t %s\n", d.Name, d.From, d.To)
+	}
+	return tw.Flush()
+}
+
+func rollbackCluConfigHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	ver, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+	if err != nil {
+		return incorrectUsageMsg(c, "invalid config version %q", c.Args().Get(0))
+	}
+	if err := api.RollbackClusterConfig(apiBP, ver); err != nil {
+		return V(err)
+	}
+	actionDone(c, fmt.Sprintf("Cluster config rolled back to v%d", ver))
+	return nil
+}
+
 //
 // cli config (default location: ~/.config/ais/cli/)
 //
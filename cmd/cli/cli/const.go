@@ -118,6 +118,11 @@ const (
 	cmdCluConfig = "configure"
 	cmdReset     = "reset"
 
+	// Cluster config history
+	cmdCfgHistory  = "history"
+	cmdCfgDiff     = "diff"
+	cmdCfgRollback = "rollback"
+
 	// Mountpath (disk) actions
 	cmdMpathAttach  = cmdAttach
 	cmdMpathEnable  = "enable"
@@ -134,6 +139,7 @@ const (
 	cmdStopMaint           = "stop-maintenance"
 	cmdNodeDecommission    = "decommission"
 	cmdClusterDecommission = "decommission"
+	cmdDrain               = "drain"
 
 	// Show subcommands (not all)
 	cmdShowRemoteAIS  = "remote-cluster"
@@ -144,6 +150,7 @@ const (
 	cmdShowCounters   = "counters"
 	cmdShowThroughput = "throughput"
 	cmdShowLatency    = "latency"
+	cmdShowRepl       = "replication"
 
 	// Bucket properties subcommands
 	cmdSetBprops   = "set"
@@ -199,7 +206,7 @@ const (
 )
 
 const (
-	timeUnits    = `ns, us (or µs), ms, s (default), m, h`
+	timeUnits    = `ns, us (or µs), ms, s (default), m, h, d (days)`
 	sizeUnitsIEC = `(IEC units, e.g.: b or B, KB or KiB, MiB or mb, g or GB or GiB, etc.)`
 )
 
@@ -229,11 +236,16 @@ const (
 	// ETL
 	etlNameArgument     = "ETL_NAME"
 	etlNameListArgument = "ETL_NAME [ETL_NAME ...]"
+	etlPipelineArgument = "ETL_NAME[,ETL_NAME[:TIMEOUT] ...]"
 
 	// key/value
 	keyValuePairsArgument = "KEY=VALUE [KEY=VALUE...]"
 	jsonKeyValueArgument  = "JSON-formatted-KEY-VALUE"
 
+	// cluster config versions (see `ais config cluster history`)
+	cfgVersionArgument  = "VERSION"
+	cfgVersionsArgument = "VERSION1 VERSION2"
+
 	// Buckets
 	bucketArgument         = "BUCKET"
 	optionalBucketArgument = "[BUCKET]"
@@ -453,6 +465,19 @@ var (
 		Name:  regexFlag.Name,
 		Usage: "regular expression to select jobs by name, kind, or description, e.g.: --regex \"ec|mirror|elect\"",
 	}
+	sinceJobsFlag = DurationFlag{
+		Name: "since",
+		Usage: "show jobs that are running or finished within the specified interval (implies " + qflprn(allJobsFlag) + "), e.g.:\n" +
+			indent4 + "\t'--since 7d' - include all jobs that finished in the last 7 days, including those retained\n" +
+			indent4 + "\tin the (persistent) job history - see 'job_history' cluster configuration;\n" +
+			indent4 + "\tvalid time units: " + timeUnits,
+	}
+
+	queueJobFlag = cli.IntFlag{
+		Name: "queue",
+		Usage: "when conflicting with a running job (e.g., rebalance), queue this one with a given (non-zero)\n" +
+			indent4 + "\tpriority rather than fail; higher priority starts first (see 'ais show job')",
+	}
 
 	jsonFlag     = cli.BoolFlag{Name: "json,j", Usage: "json input/output"}
 	noHeaderFlag = cli.BoolFlag{Name: "no-headers,H", Usage: "display tables without headers"}
@@ -474,6 +499,13 @@ var (
 
 	averageSizeFlag = cli.BoolFlag{Name: "average-size", Usage: "show average GET, PUT, etc. request size"}
 
+	perBucketFlag = cli.BoolFlag{
+		Name: "bucket",
+		Usage: "show per-bucket GET, PUT, and DELETE counts, sizes, latencies, and errors\n" +
+			indent4 + "\t(requires 'Bucket-Metrics' feature flag, see 'ais config cluster features --help');\n" +
+			indent4 + "\twith --refresh: computed over each refresh interval, otherwise cumulative",
+	}
+
 	ignoreErrorFlag = cli.BoolFlag{
 		Name:  "ignore-error",
 		Usage: "ignore \"soft\" failures such as \"bucket already exists\", etc.",
@@ -557,10 +589,11 @@ var (
 
 	useInventoryFlag = cli.BoolFlag{
 		Name: "inventory",
-		Usage: "list objects using _bucket inventory_ (docs/s3inventory.md); requires s3:// backend; will provide significant performance\n" +
-			indent4 + "\tboost when used with very large s3 buckets; e.g. usage:\n" +
+		Usage: "list objects using _bucket inventory_ (docs/s3inventory.md); requires s3:// backend or ais:// bucket with generated inventory;\n" +
+			indent4 + "\twill provide significant performance boost when used with very large buckets; e.g. usage:\n" +
 			indent4 + "\t  1) 'ais ls s3://abc --inventory'\n" +
 			indent4 + "\t  2) 'ais ls s3://abc --inventory --paged --prefix=subdir/'\n" +
+			indent4 + "\t  3) 'ais start create-inventory ais://abc' and then 'ais ls ais://abc --inventory'\n" +
 			indent4 + "\t(see also: docs/s3inventory.md)",
 	}
 	invNameFlag = cli.StringFlag{
@@ -661,6 +694,13 @@ var (
 	dsortLogFlag  = cli.StringFlag{Name: "log", Usage: "filename to log metrics (statistics)"}
 	dsortSpecFlag = cli.StringFlag{Name: "file,f", Value: "", Usage: "path to JSON or YAML job specification"}
 
+	dsortResumeFlag = cli.StringFlag{
+		Name: "resume",
+		Usage: "resume failed (or aborted) checkpointed job, skipping its completed work, e.g.:\n" +
+			indent4 + "\t--resume srt-M8ld-VU_i\n" +
+			indent4 + "\t(the job must be restarted with the same specification - see docs/cli/dsort.md)",
+	}
+
 	cleanupFlag = cli.BoolFlag{
 		Name:  "cleanup",
 		Usage: "remove old bucket and create it again (warning: removes the entire content of the old bucket)",
@@ -946,6 +986,45 @@ var (
 			indent4 + "\t--prepend=abc\t- prefix all copied object names with \"abc\"\n" +
 			indent4 + "\t--prepend=abc/\t- copy objects into a virtual directory \"abc\" (note trailing filepath separator)",
 	}
+	copyRenameRegexFlag = cli.StringFlag{
+		Name: "rename-regex",
+		Usage: "rename copied (transformed) objects that match the regular expression (see also: '--rename-replace'), e.g.:\n" +
+			indent4 + "\t--rename-regex '^images/(.*)\\.jpeg$' --rename-replace 'img/${1}.jpg'",
+	}
+	copyRenameReplaceFlag = cli.StringFlag{
+		Name:  "rename-replace",
+		Usage: "replacement for the '--rename-regex' match; may reference capture groups: $1, ${1}, ${name}",
+	}
+	copyMinSizeFlag = cli.StringFlag{
+		Name:  "min-size",
+		Usage: "only copy (transform) source objects of at least this size, e.g. 4KiB, 1MB",
+	}
+	copyMaxSizeFlag = cli.StringFlag{
+		Name:  "max-size",
+		Usage: "only copy (transform) source objects of at most this size, e.g. 4KiB, 1MB (0 - empty objects only)",
+	}
+	copyMtimeAfterFlag = cli.StringFlag{
+		Name: "mtime-after",
+		Usage: "only copy (transform) source objects modified at or after the specified time:\n" +
+			indent4 + "\tRFC3339 timestamp (e.g. '2024-06-01T00:00:00Z') or duration back from now (e.g. '36h', '7d')",
+	}
+	copyMtimeBeforeFlag = cli.StringFlag{
+		Name:  "mtime-before",
+		Usage: "only copy (transform) source objects modified before the specified time (same format as '--mtime-after')",
+	}
+	copyAtimeAfterFlag = cli.StringFlag{
+		Name:  "atime-after",
+		Usage: "only copy (transform) source objects accessed at or after the specified time (same format as '--mtime-after')",
+	}
+	copyAtimeBeforeFlag = cli.StringFlag{
+		Name:  "atime-before",
+		Usage: "only copy (transform) source objects accessed before the specified time (same format as '--mtime-after')",
+	}
+	copyCustomMDFlag = cli.StringFlag{
+		Name: "custom-md",
+		Usage: "only copy (transform) source objects with matching custom metadata, e.g.:\n" +
+			indent4 + "\t--custom-md 'label=train,source'\t- 'label' must equal 'train', 'source' must be present (any value)",
+	}
 
 	// ETL
 	etlExtFlag  = cli.StringFlag{Name: "ext", Usage: "mapping from old to new extensions of transformed objects' names"}
@@ -954,6 +1033,20 @@ var (
 		Usage:    "unique ETL name (leaving this field empty will have unique ID auto-generated)",
 		Required: true,
 	}
+	etlGetFlag = cli.StringFlag{
+		Name:  "etl",
+		Usage: "transform the object using the named ETL or comma-separated ETL pipeline, e.g. 'decode,augment:30s,encode'",
+	}
+	etlArgsFlag = cli.StringFlag{
+		Name: "etl-args",
+		Usage: "(opaque) transformer-specific arguments passed to the ETL container with each object,\n" +
+			indent4 + "\te.g. resize target, crop box, or augmentation seed",
+	}
+	etlFanOutFlag = cli.BoolFlag{
+		Name: "fan-out",
+		Usage: "one-to-many transformation: ETL responds with a TAR stream, and each member becomes a separate\n" +
+			indent4 + "\tdestination object named '<destination name>/<member name>'",
+	}
 	etlBucketRequestTimeout = DurationFlag{
 		Name: "etl-timeout",
 		Usage: "server-side timeout transforming a single object;\n" +
@@ -970,9 +1063,24 @@ var (
 	}
 	runtimeFlag = cli.StringFlag{
 		Name:     "runtime",
-		Usage:    "environment used to run the provided code (currently supported: python3.8v2, python3.10v2, python3.11v2)",
+		Usage:    "environment used to run the provided code (currently supported: python3.8v2, python3.10v2, python3.11v2, wasm)",
 		Required: true,
 	}
+	// runtime "wasm" only
+	etlMaxMemFlag = cli.StringFlag{
+		Name: "max-mem",
+		Usage: "(runtime 'wasm' only) maximum memory of the WebAssembly module instance, e.g. 16MiB;\n" +
+			indent4 + "\tsystem default when omitted or zero",
+	}
+	etlMaxConcFlag = cli.IntFlag{
+		Name:  "max-conc",
+		Usage: "(runtime 'wasm' only) maximum number of concurrent transformations per target; number of CPUs when omitted or zero",
+	}
+	etlMaxOutFlag = cli.StringFlag{
+		Name: "max-out",
+		Usage: "(runtime 'wasm' only) maximum size of the transformed object, e.g. 1GiB;\n" +
+			indent4 + "\tsystem default when omitted or zero",
+	}
 	commTypeFlag = cli.StringFlag{
 		Name: "comm-type",
 		Usage: "enumerated communication type used between aistore cluster and ETL containers that run custom transformations:\n" +
@@ -982,13 +1090,15 @@ var (
 			indent4 + "\t - 'hrev' or 'hrev://' - same, but aistore nodes will reverse-proxy requests to their respective ETL containers)\n" +
 			indent4 + "\t - 'io' or 'io://' - for each request an aistore node will: run ETL container locally, write data\n" +
 			indent4 + "\t   to its standard input and then read transformed data from the standard output\n" +
+			indent4 + "\t - 'ws' or 'ws://' - aistore nodes will stream objects over pooled long-lived WebSocket connections\n" +
+			indent4 + "\t   (ETL container provides '/ws' endpoint, and HTTP PUT handler as a fallback)\n" +
 			indent4 + "\t For more defails, see https://aiatscale.org/docs/etl#communication-mechanisms\n",
 	}
 
 	funcTransformFlag = cli.StringFlag{
 		Name:  "transform",
 		Value: "transform", // NOTE: default name of the transform() function
-		Usage: "receives and _transforms_ the payload",
+		Usage: "receives and _transforms_ the payload (runtime 'wasm': exported function to start, default '_start')",
 	}
 	argTypeFlag = cli.StringFlag{
 		Name: "arg-type",
@@ -182,6 +182,9 @@ func startDsortHandler(c *cli.Context) (err error) {
 	if !dstbck.IsEmpty() {
 		spec.OutputBck = dstbck
 	}
+	if flagIsSet(c, dsortResumeFlag) {
+		spec.Resume = parseStrFlag(c, dsortResumeFlag)
+	}
 
 	if flagIsSet(c, verboseFlag) {
 		flat, config := _flattenSpec(&spec)
@@ -19,7 +19,9 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/xact"
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 )
@@ -37,6 +39,9 @@ var (
 			chunkSizeFlag,
 			waitPodReadyTimeoutFlag,
 			etlNameFlag,
+			etlMaxMemFlag,
+			etlMaxConcFlag,
+			etlMaxOutFlag,
 		},
 		cmdSpec: {
 			fromFileFlag,
@@ -48,12 +53,27 @@ var (
 		cmdStop: {
 			allRunningJobsFlag,
 		},
+		cmdObject: {
+			etlArgsFlag,
+		},
 		cmdBucket: {
+			etlArgsFlag,
+			etlFanOutFlag,
 			etlAllObjsFlag,
 			continueOnErrorFlag,
 			etlExtFlag,
 			forceFlag,
+			queueJobFlag,
 			copyPrependFlag,
+			copyRenameRegexFlag,
+			copyRenameReplaceFlag,
+			copyMinSizeFlag,
+			copyMaxSizeFlag,
+			copyMtimeAfterFlag,
+			copyMtimeBeforeFlag,
+			copyAtimeAfterFlag,
+			copyAtimeBeforeFlag,
+			copyCustomMDFlag,
 			copyDryRunFlag,
 			etlBucketRequestTimeout,
 			listFlag,
@@ -115,14 +135,15 @@ var (
 	objCmdETL = cli.Command{
 		Name:         cmdObject,
 		Usage:        "transform object",
-		ArgsUsage:    etlNameArgument + " " + objectArgument + " OUTPUT",
+		ArgsUsage:    etlPipelineArgument + " " + objectArgument + " OUTPUT",
 		Action:       etlObjectHandler,
+		Flags:        etlSubFlags[cmdObject],
 		BashComplete: etlIDCompletions,
 	}
 	bckCmdETL = cli.Command{
 		Name:         cmdBucket,
 		Usage:        "transform entire bucket or selected objects (to select, use '--list', '--template', or '--prefix')",
-		ArgsUsage:    etlNameArgument + " " + bucketObjectSrcArgument + " " + bucketDstArgument,
+		ArgsUsage:    etlPipelineArgument + " " + bucketObjectSrcArgument + " " + bucketDstArgument,
 		Action:       etlBucketHandler,
 		Flags:        etlSubFlags[cmdBucket],
 		BashComplete: manyBucketsCompletions([]cli.BashCompleteFunc{etlIDCompletions}, 1, 2),
@@ -264,7 +285,7 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 	msg.Runtime = parseStrFlag(c, runtimeFlag)
 
 	msg.CommTypeX = parseStrFlag(c, commTypeFlag)
-	if !strings.HasSuffix(msg.CommTypeX, etl.CommTypeSeparator) {
+	if msg.CommTypeX != "" && !strings.HasSuffix(msg.CommTypeX, etl.CommTypeSeparator) {
 		msg.CommTypeX += etl.CommTypeSeparator
 	}
 	msg.ArgTypeX = parseStrFlag(c, argTypeFlag)
@@ -279,7 +300,22 @@ func etlInitCodeHandler(c *cli.Context) (err error) {
 	msg.Timeout = cos.Duration(parseDurationFlag(c, waitPodReadyTimeoutFlag))
 
 	// funcs
-	msg.Funcs.Transform = parseStrFlag(c, funcTransformFlag)
+	if !msg.InProcess() || flagIsSet(c, funcTransformFlag) {
+		msg.Funcs.Transform = parseStrFlag(c, funcTransformFlag)
+	}
+
+	// limits
+	if flagIsSet(c, etlMaxMemFlag) {
+		if msg.Limits.MaxMem, err = parseSizeFlag(c, etlMaxMemFlag); err != nil {
+			return err
+		}
+	}
+	msg.Limits.MaxConc = parseIntFlag(c, etlMaxConcFlag)
+	if flagIsSet(c, etlMaxOutFlag) {
+		if msg.Limits.MaxOut, err = parseSizeFlag(c, etlMaxOutFlag); err != nil {
+			return err
+		}
+	}
 
 	// validate
 	if err := msg.Validate(); err != nil {
@@ -348,22 +384,60 @@ func etlPrintDetails(c *cli.Context, id string) error {
 	fmt.Fprintln(c.App.Writer, fblue("COMMUNICATION TYPE: "), msg.CommType())
 	fmt.Fprintln(c.App.Writer, fblue("ARGUMENT TYPE: "), msg.ArgType())
 
-	if initMsg, ok := msg.(*etl.InitCodeMsg); ok {
+	switch initMsg := msg.(type) {
+	case *etl.InitCodeMsg:
 		fmt.Fprintln(c.App.Writer, fblue("RUNTIME: "), initMsg.Runtime)
 		fmt.Fprintln(c.App.Writer, fblue("CODE: "))
 		fmt.Fprintln(c.App.Writer, string(initMsg.Code))
 		fmt.Fprintln(c.App.Writer, fblue("DEPS: "), string(initMsg.Deps))
 		fmt.Fprintln(c.App.Writer, fblue("CHUNK SIZE: "), initMsg.ChunkSize)
-		return nil
-	}
-	if initMsg, ok := msg.(*etl.InitSpecMsg); ok {
+	case *etl.InitSpecMsg:
 		fmt.Fprintln(c.App.Writer, fblue("SPEC: "))
 		fmt.Fprintln(c.App.Writer, string(initMsg.Spec))
-		return nil
+	default:
+		err = fmt.Errorf("invalid response [%+v, %T]", msg, msg)
+		debug.AssertNoErr(err)
+		return err
 	}
-	err = fmt.Errorf("invalid response [%+v, %T]", msg, msg)
-	debug.AssertNoErr(err)
-	return err
+	return etlPrintStats(c, msg.Name())
+}
+
+// runtime stats and errors of the (running) ETL's xaction
+// - when used as a pipeline stage, include the stage's own objects, bytes, and errors
+func etlPrintStats(c *cli.Context, etlName string) error {
+	list, err := api.ETLList(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	var xid string
+	for _, info := range list {
+		if info.Name == etlName {
+			xid = info.XactID
+			break
+		}
+	}
+	if xid == "" {
+		return nil // not running
+	}
+	snaps, err := api.QueryXactionSnaps(apiBP, &xact.ArgsMsg{ID: xid})
+	if err != nil {
+		return V(err)
+	}
+	var (
+		_, outObjs, inObjs   = snaps.ObjCounts(xid)
+		_, outBytes, inBytes = snaps.ByteCounts(xid)
+	)
+	fmt.Fprintln(c.App.Writer, fblue("XACTION: "), xid)
+	fmt.Fprintln(c.App.Writer, fblue("OBJECTS (IN/OUT): "), inObjs, "/", outObjs)
+	fmt.Fprintln(c.App.Writer, fblue("BYTES (IN/OUT): "), teb.FmtSize(inBytes, "", 2), "/", teb.FmtSize(outBytes, "", 2))
+	for tid, tsnaps := range snaps {
+		for _, xsnap := range tsnaps {
+			if xsnap.ID == xid && xsnap.Err != "" {
+				fmt.Fprintln(c.App.Writer, fblue("ERRORS: "), meta.Tname(tid)+":", xsnap.Err)
+			}
+		}
+	}
+	return nil
 }
 
 // TODO: initial, see "download logs"
@@ -482,6 +556,6 @@ func etlObjectHandler(c *cli.Context) error {
 		defer f.Close()
 	}
 
-	err := api.ETLObject(apiBP, etlName, bck, objName, w)
+	err := api.ETLObjectWithArgs(apiBP, etlName, parseStrFlag(c, etlArgsFlag), bck, objName, w)
 	return handleETLHTTPError(err, etlName)
 }
@@ -33,10 +33,15 @@ var (
 // DurationFlagVar //
 /////////////////////
 
-// "s" (seconds) is the default time unit
+// "s" (seconds) is the default time unit; "d" (days) is also supported, e.g. "7d"
 func (f *DurationFlagVar) Set(s string) (err error) {
 	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
 		s += "s"
+	} else if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.ParseInt(days, 10, 64); err == nil {
+			f.Value = time.Duration(n) * 24 * time.Hour
+			return nil
+		}
 	}
 	f.Value, err = time.ParseDuration(s)
 	return err
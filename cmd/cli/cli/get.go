@@ -62,6 +62,9 @@ func getHandler(c *cli.Context) error {
 	if flagIsSet(c, lengthFlag) != flagIsSet(c, offsetFlag) {
 		return fmt.Errorf("%s and %s must be both present (or not)", qflprn(lengthFlag), qflprn(offsetFlag))
 	}
+	if flagIsSet(c, etlArgsFlag) && !flagIsSet(c, etlGetFlag) {
+		return fmt.Errorf("%s requires %s", qflprn(etlArgsFlag), qflprn(etlGetFlag))
+	}
 	if flagIsSet(c, latestVerFlag) {
 		if flagIsSet(c, headObjPresentFlag) {
 			return fmt.Errorf(errFmtExclusive, qflprn(latestVerFlag), qflprn(headObjPresentFlag))
@@ -660,6 +663,13 @@ func (a *qparamArch) getQuery(c *cli.Context, bck *cmn.Bck) (q url.Values) {
 		f()
 		q.Set(apc.QparamLatestVer, "true")
 	}
+	if flagIsSet(c, etlGetFlag) {
+		f()
+		q.Set(apc.QparamETLName, parseStrFlag(c, etlGetFlag))
+		if flagIsSet(c, etlArgsFlag) {
+			q.Set(apc.QparamETLArgs, parseStrFlag(c, etlArgsFlag))
+		}
+	}
 	return q
 }
 
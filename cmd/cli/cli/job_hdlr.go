@@ -58,6 +58,7 @@ var (
 	startCommonFlags = []cli.Flag{
 		waitFlag,
 		waitJobXactFinishedFlag,
+		queueJobFlag,
 		nonverboseFlag,
 	}
 	startSpecialFlags = map[string][]cli.Flag{
@@ -76,6 +77,7 @@ var (
 		},
 		cmdDsort: {
 			dsortSpecFlag,
+			dsortResumeFlag,
 			verboseFlag,
 		},
 		commandPrefetch: append(
@@ -365,10 +367,16 @@ func startXaction(c *cli.Context, xargs *xact.ArgsMsg, extra string) error {
 		return fmt.Errorf("%q requires bucket to run", xargs.Kind)
 	}
 
+	if flagIsSet(c, queueJobFlag) {
+		xargs.Queue = parseIntFlag(c, queueJobFlag)
+	}
 	xid, err := api.StartXaction(apiBP, xargs, extra)
 	if err != nil {
 		return V(err)
 	}
+	if xargs.Queue != 0 && isQueuedJob(c, xid) {
+		return nil
+	}
 	if xid == "" {
 		warn := fmt.Sprintf("The operation returned an empty UUID (a no-op?). %s\n",
 			toShowMsg(c, "", "To investigate", false))
@@ -793,6 +801,23 @@ func stopJobHandler(c *cli.Context) error {
 		return stopXactionKindOrAll(c, xactKind, xname, bck)
 	}
 
+	// queued job: cancel or, if already started, stop the resulting xaction
+	if j := findQueuedJob(xactID); j != nil {
+		switch j.State {
+		case cmn.JobQueued:
+			if err := api.AbortXaction(apiBP, &xact.ArgsMsg{ID: j.ID}); err != nil {
+				return V(err)
+			}
+			actionDone(c, fmt.Sprintf("Canceled queued %s job %s", j.Action, j.ID))
+			return nil
+		case cmn.JobStarted:
+			xactID = j.Xid
+		default:
+			fmt.Fprintf(c.App.Writer, "queued %s job %s is %s, nothing to do\n", j.Action, j.ID, j.State)
+			return nil
+		}
+	}
+
 	// query
 	msg := formatXactMsg(xactID, xname, bck)
 	xargs := xact.ArgsMsg{ID: xactID, Kind: xactKind}
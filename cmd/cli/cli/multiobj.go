@@ -66,13 +66,13 @@ func runTCO(c *cli.Context, bckFrom, bckTo cmn.Bck, listObjs, tmplObjs, etlName
 	{
 		msg.ListRange = lrMsg
 		msg.DryRun = flagIsSet(c, copyDryRunFlag)
-		if flagIsSet(c, etlBucketRequestTimeout) {
-			msg.Timeout = cos.Duration(etlBucketRequestTimeout.Value)
-		}
 		msg.LatestVer = flagIsSet(c, latestVerFlag)
 		msg.Sync = flagIsSet(c, syncFlag)
 		msg.ContinueOnError = flagIsSet(c, continueOnErrorFlag)
 	}
+	if err := _iniRenameFilter(c, &msg.CopyBckMsg); err != nil {
+		return err
+	}
 	// 3. start copying/transforming
 	var (
 		xid   string
@@ -81,7 +81,9 @@ func runTCO(c *cli.Context, bckFrom, bckTo cmn.Bck, listObjs, tmplObjs, etlName
 		text  = "Copying objects"
 	)
 	if etlName != "" {
-		msg.Name = etlName
+		if err := parseTransform(c, etlName, &msg.Transform); err != nil {
+			return err
+		}
 		text = "Transforming objects"
 		xkind = apc.ActETLObjects
 		xid, err = api.ETLMultiObj(apiBP, bckFrom, &msg)
@@ -52,6 +52,9 @@ var (
 			archmodeFlag,
 			// archive, client side
 			extractFlag,
+			// inline transformation
+			etlGetFlag,
+			etlArgsFlag,
 			// bucket inventory
 			useInventoryFlag,
 			invNameFlag,
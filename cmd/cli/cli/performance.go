@@ -44,7 +44,7 @@ var (
 		Name:      commandPerf,
 		Usage:     showPerfArgument,
 		ArgsUsage: optionalTargetIDArgument,
-		Flags:     showPerfFlags,
+		Flags:     append(showPerfFlags, perBucketFlag),
 		Action:    showPerfHandler,
 		Subcommands: []cli.Command{
 			showCounters,
@@ -75,7 +75,7 @@ var (
 	}
 	showLatency = cli.Command{
 		Name:         cmdShowLatency,
-		Usage:        "show GET, PUT, and APPEND latencies and average sizes",
+		Usage:        "show GET, PUT, and APPEND latencies (average and p50, p90, p99, p99.9 percentiles) and average sizes",
 		ArgsUsage:    optionalTargetIDArgument,
 		Flags:        showPerfFlags,
 		Action:       showLatencyHandler,
@@ -92,6 +92,9 @@ var (
 )
 
 func showPerfHandler(c *cli.Context) error {
+	if flagIsSet(c, perBucketFlag) {
+		return showPerfBucketsHandler(c)
+	}
 	allPerfTabs = true // global (TODO: consider passing as param)
 
 	if c.NArg() > 1 && strings.HasPrefix(c.Args().Get(1), "-") {
@@ -312,11 +315,43 @@ func _latency(c *cli.Context, metrics cos.StrKVs, mapBegin, mapEnd teb.StstMap,
 			v.Value = 0
 			begin.Tracker[name] = v
 		}
+		num += _percentiles(metrics, begin, end)
 	}
 	idle = num == 0
 	return
 }
 
+// add (interval) percentiles computed from cumulative latency histograms, e.g. "get.p99.ns"
+func _percentiles(metrics cos.StrKVs, begin, end *stats.NodeStatus) (num int) {
+	for name, hend := range end.Hists {
+		if _, ok := metrics[name]; !ok {
+			continue
+		}
+		v, ok := begin.Tracker[name]
+		if !ok {
+			continue
+		}
+		delta := hend
+		if hbegin, ok := begin.Hists[name]; ok {
+			delta = hend.Sub(hbegin)
+		}
+		nz := delta.Count() > 0
+		for _, p := range stats.Percentiles {
+			pname := stats.PercentileName(name, p)
+			metrics[pname] = stats.KindLatency
+			v.Value = 0
+			if nz {
+				v.Value = delta.Percentile(p)
+			}
+			begin.Tracker[pname] = v
+		}
+		if nz {
+			num++
+		}
+	}
+	return num
+}
+
 // (main method)
 func showPerfTab(c *cli.Context, metrics cos.StrKVs, cb perfcb, tag string, totals map[string]int64, inclAvgSize bool) error {
 	var (
@@ -460,6 +495,75 @@ func showPerfTab(c *cli.Context, metrics cos.StrKVs, cb perfcb, tag string, tota
 	return nil
 }
 
+// per-bucket breakdown (see feat.BucketMetrics)
+func showPerfBucketsHandler(c *cli.Context) error {
+	var (
+		tid         string
+		regex       *regexp.Regexp
+		regexStr    = parseStrFlag(c, regexColsFlag)
+		hideHeader  = flagIsSet(c, noHeaderFlag)
+		units, errU = parseUnitsFlag(c, unitsFlag)
+	)
+	if errU != nil {
+		return errU
+	}
+	node, _, err := arg0Node(c)
+	if err != nil {
+		return err
+	}
+	if node != nil {
+		tid = node.ID()
+	}
+	if regexStr != "" { // (here: filter bucket names)
+		if regex, err = regexp.Compile(regexStr); err != nil {
+			return err
+		}
+	}
+
+	// cumulative
+	if !flagIsSet(c, refreshFlag) {
+		setLongRunParams(c, 72)
+		smap, tstatusMap, _, err := fillNodeStatusMap(c, apc.Target)
+		if err != nil {
+			return err
+		}
+		ctx := teb.PerfTabCtx{Smap: smap, Sid: tid, Regex: regex, Units: units}
+		table, num := teb.NewBucketPerfTab(tstatusMap, nil, &ctx, 0)
+		if num == 0 {
+			actionNote(c, "no per-bucket statistics (hint: check 'Bucket-Metrics' feature flag)\n")
+			return nil
+		}
+		return teb.Print(tstatusMap, table.Template(hideHeader))
+	}
+
+	// computed over each refresh interval
+	sleep := _refreshRate(c)
+	if sleep < time.Second || sleep > time.Minute {
+		return fmt.Errorf("invalid %s value, got %v, expecting [1s - 1m]", qflprn(refreshFlag), sleep)
+	}
+	smap, err := getClusterMap(c)
+	if err != nil {
+		return err
+	}
+	cntRun := &longRun{}
+	cntRun.init(c, true /*run once unless*/)
+	for countdown := cntRun.count; countdown > 0 || cntRun.isForever(); countdown-- {
+		mapBegin, mapEnd, err := _cluStatusBeginEnd(c, cntRun.mapBegin, sleep)
+		if err != nil {
+			return err
+		}
+		cntRun.mapBegin = mapEnd
+
+		perfCptn(c, "buckets")
+		ctx := teb.PerfTabCtx{Smap: smap, Sid: tid, Regex: regex, Units: units}
+		table, _ := teb.NewBucketPerfTab(mapBegin, mapEnd, &ctx, sleep)
+		if err := teb.Print(mapEnd, table.Template(hideHeader)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func showMpathCapHandler(c *cli.Context) error {
 	var (
 		tid         string
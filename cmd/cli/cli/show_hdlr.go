@@ -24,6 +24,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/urfave/cli"
 )
@@ -47,6 +48,7 @@ var (
 			longRunFlags,
 			jsonFlag,
 			allJobsFlag,
+			sinceJobsFlag,
 			regexJobsFlag,
 			noHeaderFlag,
 			verboseJobFlag,
@@ -110,6 +112,7 @@ var (
 			showCmdRebalance,
 			showCmdConfig,
 			showCmdRemoteAIS,
+			showCmdRepl,
 			showCmdJob,
 			showCmdLog,
 		},
@@ -194,6 +197,16 @@ var (
 		Action:    showRemoteAISHandler,
 	}
 
+	showCmdRepl = cli.Command{
+		Name: cmdShowRepl,
+		Usage: "show buckets with continuous replication enabled (bucket property 'replication'), and\n" +
+			indent2 + "\tper-target replication backlog, lag, and counters",
+		ArgsUsage:    optionalTargetIDArgument,
+		Flags:        showPerfFlags,
+		Action:       showReplHandler,
+		BashComplete: suggestTargets,
+	}
+
 	showCmdJob = cli.Command{
 		Name:         commandJob,
 		Usage:        "show running and finished jobs ('--all' for all, or " + tabHelpOpt + ")",
@@ -220,7 +233,12 @@ func showJobsHandler(c *cli.Context) error {
 
 	var l int
 	l, err = showJobsDo(c, name, xid, daemonID, bck)
-	if err == nil && l == 0 && !flagIsSet(c, allJobsFlag) {
+	if err == nil && name == "" && xid == "" {
+		var n int
+		n, err = showJobQueue(c)
+		l += n
+	}
+	if err == nil && l == 0 && !flagIsSet(c, allJobsFlag) && !flagIsSet(c, sinceJobsFlag) {
 		n, h := qflprn(allJobsFlag), qflprn(cli.HelpFlag)
 		fmt.Fprintf(c.App.Writer, "No running jobs. "+
 			"Use %s to show all, %s <TAB-TAB> to select, %s for details.\n", n, n, h)
@@ -310,7 +328,7 @@ func _showJobs(c *cli.Context, name, xid, daemonID string, bck cmn.Bck, caption
 	default:
 		var (
 			// finished or not, always try to show when xid provided
-			all         = flagIsSet(c, allJobsFlag) || xact.IsValidUUID(xid)
+			all         = flagIsSet(c, allJobsFlag) || flagIsSet(c, sinceJobsFlag) || xact.IsValidUUID(xid)
 			onlyActive  = !all
 			xactKind, _ = xact.GetKindName(name)
 			regexStr    = parseStrFlag(c, regexJobsFlag)
@@ -322,6 +340,9 @@ func _showJobs(c *cli.Context, name, xid, daemonID string, bck cmn.Bck, caption
 				OnlyRunning: onlyActive,
 			}
 		)
+		if flagIsSet(c, sinceJobsFlag) {
+			xargs.Since = parseDurationFlag(c, sinceJobsFlag)
+		}
 		if regexStr != "" {
 			regex, err := regexp.Compile(regexStr)
 			if err != nil {
@@ -848,6 +869,33 @@ func showNodeConfig(c *cli.Context) error {
 	return err
 }
 
+func showReplHandler(c *cli.Context) error {
+	bmd, err := api.GetBMD(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	table, num := teb.NewReplTab(bmd)
+	if num == 0 {
+		actionNote(c, "no buckets with replication enabled "+
+			"(hint: 'ais bucket props set BUCKET replication.enabled true replication.dest DEST_BUCKET')\n")
+		return nil
+	}
+	if err := teb.Print(bmd, table.Template(flagIsSet(c, noHeaderFlag))); err != nil {
+		return err
+	}
+	fmt.Fprintln(c.App.Writer)
+
+	metrics := cos.StrKVs{
+		stats.ReplPending:  stats.KindGauge,
+		stats.ReplLag:      stats.KindGauge,
+		stats.ReplPutCount: stats.KindCounter,
+		stats.ReplPutSize:  stats.KindSize,
+		stats.ReplDelCount: stats.KindCounter,
+		stats.ErrReplCount: stats.KindCounter,
+	}
+	return showPerfTab(c, metrics, nil, cmdShowRepl, nil, false)
+}
+
 // TODO -- FIXME: check backend.conf <new JSON formatted value>
 func showRemoteAISHandler(c *cli.Context) error {
 	const (
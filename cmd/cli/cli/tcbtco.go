@@ -157,12 +157,95 @@ func _iniCopyBckMsg(c *cli.Context, msg *apc.CopyBckMsg) (err error) {
 		msg.Force = flagIsSet(c, forceFlag)
 		msg.LatestVer = flagIsSet(c, latestVerFlag)
 		msg.Sync = flagIsSet(c, syncFlag)
+		msg.Queue = parseIntFlag(c, queueJobFlag)
 	}
 	if msg.Sync && msg.Prepend != "" {
-		err = fmt.Errorf("prepend option (%q) is incompatible with %s (the latter requires identical source/destination naming)",
+		return fmt.Errorf("prepend option (%q) is incompatible with %s (the latter requires identical source/destination naming)",
 			msg.Prepend, qflprn(progressFlag))
 	}
-	return err
+	return _iniRenameFilter(c, msg)
+}
+
+var copyFilterFlags = []cli.Flag{
+	copyMinSizeFlag,
+	copyMaxSizeFlag,
+	copyMtimeAfterFlag,
+	copyMtimeBeforeFlag,
+	copyAtimeAfterFlag,
+	copyAtimeBeforeFlag,
+	copyCustomMDFlag,
+}
+
+// (common for x-tcb and x-tco)
+func _iniRenameFilter(c *cli.Context, msg *apc.CopyBckMsg) (err error) {
+	if flagIsSet(c, copyRenameRegexFlag) {
+		if !flagIsSet(c, copyRenameReplaceFlag) {
+			return fmt.Errorf("%s requires %s", qflprn(copyRenameRegexFlag), qflprn(copyRenameReplaceFlag))
+		}
+		rule := apc.RenameRule{Regex: parseStrFlag(c, copyRenameRegexFlag), Replace: parseStrFlag(c, copyRenameReplaceFlag)}
+		msg.Rename = []apc.RenameRule{rule}
+	} else if flagIsSet(c, copyRenameReplaceFlag) {
+		return fmt.Errorf("%s requires %s", qflprn(copyRenameReplaceFlag), qflprn(copyRenameRegexFlag))
+	}
+
+	var flt apc.ObjFilter
+	if flagIsSet(c, copyMinSizeFlag) {
+		if flt.MinSize, err = parseSizeFlag(c, copyMinSizeFlag); err != nil {
+			return err
+		}
+	}
+	if flagIsSet(c, copyMaxSizeFlag) {
+		maxSize, err := parseSizeFlag(c, copyMaxSizeFlag)
+		if err != nil {
+			return err
+		}
+		flt.MaxSize = &maxSize
+	}
+	for _, tf := range []struct {
+		flag cli.StringFlag
+		ptr  *int64
+	}{
+		{copyMtimeAfterFlag, &flt.MtimeAfter},
+		{copyMtimeBeforeFlag, &flt.MtimeBefore},
+		{copyAtimeAfterFlag, &flt.AtimeAfter},
+		{copyAtimeBeforeFlag, &flt.AtimeBefore},
+	} {
+		if !flagIsSet(c, tf.flag) {
+			continue
+		}
+		if *tf.ptr, err = parseTimeFlag(c, tf.flag); err != nil {
+			return err
+		}
+	}
+	if flagIsSet(c, copyCustomMDFlag) {
+		flt.CustomMD = cos.StrKVs{}
+		for _, kv := range splitCsv(parseStrFlag(c, copyCustomMDFlag)) {
+			k, v, _ := strings.Cut(kv, "=")
+			flt.CustomMD[k] = v
+		}
+	}
+	for _, f := range copyFilterFlags {
+		if flagIsSet(c, f) {
+			msg.Filter = &flt
+			break
+		}
+	}
+	return msg.Validate() // early (the cluster will validate it as well)
+}
+
+// RFC3339 timestamp or duration back from now, e.g. "7d"
+//
+//nolint:gocritic // ignoring hugeParam - following the orig. github.com/urfave style
+func parseTimeFlag(c *cli.Context, flag cli.StringFlag) (int64, error) {
+	val := parseStrFlag(c, flag)
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t.UnixNano(), nil
+	}
+	var d DurationFlagVar
+	if err := d.Set(val); err != nil {
+		return 0, fmt.Errorf("invalid %s=%s: expecting RFC3339 timestamp or duration (e.g. '36h', '7d')", flprn(flag), val)
+	}
+	return time.Now().Add(-d.Value).UnixNano(), nil
 }
 
 func copyBucket(c *cli.Context, bckFrom, bckTo cmn.Bck) error {
@@ -204,6 +287,9 @@ func copyBucket(c *cli.Context, bckFrom, bckTo cmn.Bck) error {
 	if err != nil {
 		return V(err)
 	}
+	if msg.Queue != 0 && isQueuedJob(c, xid) {
+		return nil
+	}
 	// NOTE: may've transitioned TCB => TCO
 	kind := apc.ActCopyBck
 	if !apc.IsFltPresent(fltPresence) {
@@ -238,6 +324,22 @@ func copyBucket(c *cli.Context, bckFrom, bckTo cmn.Bck) error {
 	return nil
 }
 
+// ETL name or comma-separated pipeline, e.g. "decode,augment:30s,encode" (see apc.ParseETLPipeline)
+func parseTransform(c *cli.Context, spec string, transform *apc.Transform) error {
+	stages, err := apc.ParseETLPipeline(spec)
+	if err != nil {
+		return err
+	}
+	transform.Name, transform.Timeout = stages[0].Name, stages[0].Timeout
+	transform.Pipeline = stages[1:]
+	transform.Args = parseStrFlag(c, etlArgsFlag)
+	transform.FanOut = flagIsSet(c, etlFanOutFlag)
+	if transform.Timeout == 0 && flagIsSet(c, etlBucketRequestTimeout) {
+		transform.Timeout = cos.Duration(etlBucketRequestTimeout.Value)
+	}
+	return nil
+}
+
 func tcbtcoCptn(action string, bckFrom, bckTo cmn.Bck) string {
 	from, to := bckFrom.Cname(""), bckTo.Cname("")
 	if bckFrom.Equal(&bckTo) {
@@ -263,8 +365,9 @@ func etlBucketHandler(c *cli.Context) error {
 }
 
 func etlBucket(c *cli.Context, etlName string, bckFrom, bckTo cmn.Bck) error {
-	var msg = apc.TCBMsg{
-		Transform: apc.Transform{Name: etlName},
+	var msg apc.TCBMsg
+	if err := parseTransform(c, etlName, &msg.Transform); err != nil {
+		return err
 	}
 	if err := _iniCopyBckMsg(c, &msg.CopyBckMsg); err != nil {
 		return err
@@ -302,6 +405,9 @@ func etlBucket(c *cli.Context, etlName string, bckFrom, bckTo cmn.Bck) error {
 	if errV := handleETLHTTPError(err, etlName); errV != nil {
 		return errV
 	}
+	if msg.Queue != 0 && isQueuedJob(c, xid) {
+		return nil
+	}
 
 	_, xname := xact.GetKindName(apc.ActETLBck)
 	text := fmt.Sprintf("%s %s => %s", xact.Cname(xname, xid), bckFrom, bckTo)
@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
@@ -356,3 +357,75 @@ func extractXactIDsForKind(xs xact.MultiSnap, xactKind string) (xactIDs []string
 	})
 	return
 }
+
+//
+// queued jobs (see queueJobFlag)
+//
+
+func findQueuedJob(id string) *cmn.QueuedJob {
+	jobs, err := api.GetJobQueue(apiBP)
+	if err != nil {
+		return nil
+	}
+	for i := range jobs {
+		if jobs[i].ID == id {
+			return &jobs[i]
+		}
+	}
+	return nil
+}
+
+// the job did not start right away (conflict) and got queued
+func isQueuedJob(c *cli.Context, id string) bool {
+	j := findQueuedJob(id)
+	if j == nil || j.State != cmn.JobQueued {
+		return false
+	}
+	actionDone(c, fmt.Sprintf("Queued %s job %s (priority %d): %s", j.Action, j.ID, j.Prio, j.Blocker))
+	return true
+}
+
+// (finished - that is, started, failed, or aborted - only with allJobsFlag)
+func showJobQueue(c *cli.Context) (int, error) {
+	jobs, err := api.GetJobQueue(apiBP)
+	if err != nil {
+		return 0, V(err)
+	}
+	var (
+		n   int
+		all = flagIsSet(c, allJobsFlag) || flagIsSet(c, sinceJobsFlag)
+		tw  = &tabwriter.Writer{}
+	)
+	for i := range jobs {
+		j := &jobs[i]
+		if j.Finished() && !all {
+			continue
+		}
+		if n == 0 {
+			fmt.Fprintln(c.App.Writer)
+			actionCptn(c, "queued", " jobs")
+			tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(tw, "ID\t JOB\t BUCKET\t PRIORITY\t STATE\t WHO\t QUEUED\t DETAILS")
+		}
+		n++
+		var (
+			bck    = j.Bck
+			detail = j.Blocker
+		)
+		if bck == "" {
+			bck = teb.NotSetVal
+		}
+		switch j.State {
+		case cmn.JobStarted:
+			detail = "xid: " + j.Xid
+		case cmn.JobFailed:
+			detail = j.Err
+		}
+		fmt.Fprintf(tw, "%s\t %s\t %s\t %d\t %s\t %s\t %s\t %s\n", j.ID, j.Action, bck, j.Prio, j.State, j.Who,
+			time.Unix(0, j.Time).Format(time.Stamp), detail)
+	}
+	if n > 0 {
+		err = tw.Flush()
+	}
+	return n, err
+}
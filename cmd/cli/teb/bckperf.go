@@ -0,0 +1,148 @@
+// Package teb contains templates and (templated) tables to format CLI output.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package teb
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// per-bucket performance (see feat.BucketMetrics)
+
+const (
+	colBucket    = "BUCKET"
+	colBckGet    = "GET"
+	colBckGetSz  = "GET SIZE"
+	colBckGetLat = "GET LATENCY"
+	colBckPut    = "PUT"
+	colBckPutSz  = "PUT SIZE"
+	colBckPutLat = "PUT LATENCY"
+	colBckDel    = "DELETE"
+	colBckErr    = "ERRORS"
+
+	// when computed over an interval
+	colBckGetBps = "GET/s"
+	colBckPutBps = "PUT/s"
+)
+
+// sum up per-bucket metrics across (selected) targets
+func sumBckStats(st StstMap, sid string) stats.BckTracker {
+	out := make(stats.BckTracker, 8)
+	for tid, ds := range st {
+		if (sid != "" && sid != tid) || ds.Status != NodeOnline {
+			continue
+		}
+		for cname, vals := range ds.Buckets {
+			sum, ok := out[cname]
+			if !ok {
+				sum = make(map[string]int64, len(vals))
+				out[cname] = sum
+			}
+			for name, v := range vals {
+				sum[name] += v
+			}
+		}
+	}
+	return out
+}
+
+// - mapEnd == nil: cumulative (since node startup or the last stats reset);
+// - otherwise: computed over the elapsed interval
+func NewBucketPerfTab(mapBegin, mapEnd StstMap, c *PerfTabCtx, elapsed time.Duration) (*Table, int) {
+	var (
+		cols    []*header
+		begin   = sumBckStats(mapBegin, c.Sid)
+		totals  = begin
+		seconds int64
+	)
+	if mapEnd != nil {
+		seconds = max(int64(elapsed.Seconds()), 1)
+		end := sumBckStats(mapEnd, c.Sid)
+		totals = make(stats.BckTracker, len(end))
+		for cname, vals := range end {
+			delta := make(map[string]int64, len(vals))
+			for name, v := range vals {
+				if d := v - begin[cname][name]; d > 0 {
+					delta[name] = d
+				}
+			}
+			if len(delta) > 0 {
+				totals[cname] = delta
+			}
+		}
+		cols = []*header{{name: colBucket}, {name: colBckGet}, {name: colBckGetBps}, {name: colBckGetLat},
+			{name: colBckPut}, {name: colBckPutBps}, {name: colBckPutLat}, {name: colBckDel}, {name: colBckErr}}
+	} else {
+		cols = []*header{{name: colBucket}, {name: colBckGet}, {name: colBckGetSz}, {name: colBckGetLat},
+			{name: colBckPut}, {name: colBckPutSz}, {name: colBckPutLat}, {name: colBckDel}, {name: colBckErr}}
+	}
+
+	// filter (buckets)
+	cnames := make([]string, 0, len(totals))
+	for cname := range totals {
+		if c.Regex == nil || c.Regex.MatchString(cname) {
+			cnames = append(cnames, cname)
+		}
+	}
+	// busiest first, with "other" always last
+	sort.Slice(cnames, func(i, j int) bool {
+		switch {
+		case cnames[i] == stats.BckOther:
+			return false
+		case cnames[j] == stats.BckOther:
+			return true
+		}
+		ni := totals[cnames[i]][stats.GetCount] + totals[cnames[i]][stats.PutCount]
+		nj := totals[cnames[j]][stats.GetCount] + totals[cnames[j]][stats.PutCount]
+		if ni != nj {
+			return ni > nj
+		}
+		return cnames[i] < cnames[j]
+	})
+
+	table := newTable(cols...)
+	for _, cname := range cnames {
+		var (
+			vals = totals[cname]
+			gets = vals[stats.GetCount]
+			puts = vals[stats.PutCount]
+			errs = vals["err."+stats.GetCount] + vals["err."+stats.PutCount] + vals["err."+stats.DeleteCount]
+		)
+		row := make(row, 0, len(cols))
+		row = append(row, cname, strconv.FormatInt(gets, 10))
+		row = append(row, _bckSize(vals[stats.GetSize], seconds, c.Units), _bckLat(vals[stats.GetLatencyTotal], gets, c.Units))
+		row = append(row, strconv.FormatInt(puts, 10))
+		row = append(row, _bckSize(vals[stats.PutSize], seconds, c.Units), _bckLat(vals[stats.PutLatencyTotal], puts, c.Units))
+		row = append(row, strconv.FormatInt(vals[stats.DeleteCount], 10))
+		if errs > 0 {
+			row = append(row, fred(strconv.FormatInt(errs, 10)))
+		} else {
+			row = append(row, "-")
+		}
+		table.addRow(row)
+	}
+	return table, len(cnames)
+}
+
+func _bckSize(size, seconds int64, units string) string {
+	if size == 0 {
+		return "-"
+	}
+	if seconds > 0 {
+		return FmtSize(size/seconds, units, 2) + "/s"
+	}
+	return FmtSize(size, units, 2)
+}
+
+// average latency = (total latency) / (num requests)
+func _bckLat(total, num int64, units string) string {
+	if num == 0 || total == 0 {
+		return "-"
+	}
+	return FmtDuration(total/num, units)
+}
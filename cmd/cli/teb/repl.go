@@ -0,0 +1,50 @@
+// Package teb contains templates and (templated) tables to format CLI output.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package teb
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// buckets with replication policy (bucket property 'replication')
+
+const (
+	colReplDest      = "DESTINATION"
+	colReplPrefix    = "PREFIX"
+	colReplDeletes   = "DELETES"
+	colReplReconcile = "RECONCILE"
+)
+
+func NewReplTab(bmd *meta.BMD) (*Table, int) {
+	var (
+		cols = []*header{{name: colBucket}, {name: colReplDest}, {name: colReplPrefix},
+			{name: colReplDeletes}, {name: colReplReconcile}}
+		table = newTable(cols...)
+		rows  = make([]row, 0, 4)
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		conf := &bck.Props.Replication
+		if !conf.Enabled {
+			return false
+		}
+		r := row{bck.Cname(""), conf.Dest, NotSetVal, strconv.FormatBool(conf.Deletes), NotSetVal}
+		if conf.Prefix != "" {
+			r[2] = conf.Prefix
+		}
+		if conf.ReconcileTime != 0 {
+			r[4] = conf.ReconcileTime.String()
+		}
+		rows = append(rows, r)
+		return false
+	})
+	sort.Slice(rows, func(i, j int) bool { return rows[i][0] < rows[j][0] })
+	for _, r := range rows {
+		table.addRow(r)
+	}
+	return table, len(rows)
+}
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -56,6 +57,62 @@ type (
 		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
 		Created     int64           `json:"created,string" list:"readonly"` // creation timestamp
 		Versioning  VersionConf     `json:"versioning"`                     // versioning (see "inherit")
+		Readahead   ReadaheadConf   `json:"readahead"`                      // sequential-access prefetch (remote buckets)
+		WriteBack   WriteBackConf   `json:"write_back"`                     // asynchronous PUT to remote backend
+		Replication ReplicationConf `json:"replication"`                    // continuous replication to remote AIS or cloud bucket
+		Inventory   InventoryConf   `json:"inventory"`                      // scheduled bucket inventory (ais:// buckets only)
+		ReadOnly    bool            `json:"read_only"`                      // fenced: reject writes, deletes, and props changes
+	}
+
+	// Readahead: upon detecting sequential access pattern (e.g., train-000001.tar, train-000002.tar, ...)
+	// target prefetches the next `Window` objects (that it owns), see ais/tgtreadahead.go
+	ReadaheadConf struct {
+		Window int `json:"window"` // zero disables readahead
+	}
+	ReadaheadConfToSet struct {
+		Window *int `json:"window,omitempty"`
+	}
+
+	// WriteBack: PUT is committed locally and acknowledged immediately, while uploading
+	// to the remote (cloud) backend is done asynchronously, see ais/tgtwriteback.go
+	WriteBackConf struct {
+		Enabled bool `json:"enabled"`
+	}
+	WriteBackConfToSet struct {
+		Enabled *bool `json:"enabled,omitempty"`
+	}
+
+	// Replication: continuous asynchronous replication of the bucket's content
+	// to a remote AIS or cloud bucket, see ais/tgtrepl.go
+	ReplicationConf struct {
+		Dest          string       `json:"dest"`           // destination bucket, e.g. "s3://abc" or "ais://@remais/abc"
+		Prefix        string       `json:"prefix"`         // replicate only objects with names starting with
+		ReconcileTime cos.Duration `json:"reconcile_time"` // periodic reconciliation scan (zero: never)
+		Deletes       bool         `json:"deletes"`        // propagate deletions
+		Enabled       bool         `json:"enabled"`
+	}
+	ReplicationConfToSet struct {
+		Dest          *string       `json:"dest,omitempty"`
+		Prefix        *string       `json:"prefix,omitempty"`
+		ReconcileTime *cos.Duration `json:"reconcile_time,omitempty"`
+		Deletes       *bool         `json:"deletes,omitempty"`
+		Enabled       *bool         `json:"enabled,omitempty"`
+	}
+
+	// Inventory: periodically generate bucket inventory (see InvMsg below and xact/xs/inventory.go)
+	InventoryConf struct {
+		Dest     string       `json:"dest"`     // destination bucket, e.g. "ais://inv" (empty: the bucket itself)
+		Prefix   string       `json:"prefix"`   // inventory only objects with names starting with
+		Format   string       `json:"format"`   // InvFormatCSV (default) or InvFormatParquet
+		Interval cos.Duration `json:"interval"` // how often to regenerate
+		Enabled  bool         `json:"enabled"`
+	}
+	InventoryConfToSet struct {
+		Dest     *string       `json:"dest,omitempty"`
+		Prefix   *string       `json:"prefix,omitempty"`
+		Format   *string       `json:"format,omitempty"`
+		Interval *cos.Duration `json:"interval,omitempty"`
+		Enabled  *bool         `json:"enabled,omitempty"`
 	}
 
 	ExtraProps struct {
@@ -126,6 +183,11 @@ type (
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
 		Extra       *ExtraToSet           `json:"extra,omitempty"`
+		Readahead   *ReadaheadConfToSet   `json:"readahead,omitempty"`
+		WriteBack   *WriteBackConfToSet   `json:"write_back,omitempty"`
+		Replication *ReplicationConfToSet `json:"replication,omitempty"`
+		Inventory   *InventoryConfToSet   `json:"inventory,omitempty"`
+		ReadOnly    *bool                 `json:"read_only,omitempty"`
 		Force       bool                  `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -218,12 +280,18 @@ func (bp *Bprops) Validate(targetCnt int) error {
 
 	// run assorted props validators
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Readahead, &bp.WriteBack, &bp.Replication, &bp.Inventory} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
 		} else if pv == &bp.Extra {
 			err = bp.Extra.ValidateAsProps(bp.Provider)
+		} else if pv == &bp.WriteBack {
+			err = bp.WriteBack.ValidateAsProps(bp.Provider, &bp.BackendBck)
+		} else if pv == &bp.Replication {
+			err = bp.Replication.ValidateAsProps(&bp.BackendBck)
+		} else if pv == &bp.Inventory {
+			err = bp.Inventory.ValidateAsProps(bp.Provider)
 		} else {
 			err = pv.ValidateAsProps()
 		}
@@ -285,6 +353,107 @@ func (c *ExtraProps) ValidateAsProps(arg ...any) error {
 	return nil
 }
 
+const MaxReadaheadWindow = 128
+
+func (c *ReadaheadConf) ValidateAsProps(...any) error {
+	if c.Window < 0 || c.Window > MaxReadaheadWindow {
+		return fmt.Errorf("invalid readahead.window %d (expecting range [0, %d])", c.Window, MaxReadaheadWindow)
+	}
+	return nil
+}
+
+// write-back is supported only for buckets backed by cloud storage
+// (and, in particular, not for remote AIS and HTTP buckets)
+func (c *WriteBackConf) ValidateAsProps(arg ...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	var (
+		provider, _ = arg[0].(string)
+		backendBck  = arg[1].(*Bck)
+	)
+	if apc.IsCloudProvider(provider) || (!backendBck.IsEmpty() && backendBck.IsCloud()) {
+		return nil
+	}
+	return fmt.Errorf("invalid write_back.enabled: write-back requires cloud bucket or ais bucket with cloud backend (have %q)",
+		provider)
+}
+
+const minReconcileTime = time.Minute
+
+// replication destination must be a remote AIS or cloud bucket other than
+// the bucket's own backend
+func (c *ReplicationConf) ValidateAsProps(arg ...any) error {
+	if c.ReconcileTime != 0 && c.ReconcileTime.D() < minReconcileTime {
+		return fmt.Errorf("invalid replication.reconcile_time %v (expecting zero or >= %v)", c.ReconcileTime, minReconcileTime)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.Dest == "" {
+		return errors.New("invalid replication.dest: destination bucket must be specified")
+	}
+	dst, err := c.DestBck()
+	if err != nil {
+		return fmt.Errorf("invalid replication.dest %q: %v", c.Dest, err)
+	}
+	if !dst.IsCloud() && !dst.IsRemoteAIS() {
+		return fmt.Errorf("invalid replication.dest %q: expecting remote AIS or cloud bucket", c.Dest)
+	}
+	if backendBck := arg[0].(*Bck); dst.Equal(backendBck) {
+		return fmt.Errorf("invalid replication.dest %q: cannot replicate bucket to its own backend", c.Dest)
+	}
+	// (optional) the bucket itself
+	if len(arg) > 1 {
+		if bck := arg[1].(*Bck); dst.Equal(bck) {
+			return fmt.Errorf("invalid replication.dest %q: cannot replicate bucket onto itself", c.Dest)
+		}
+	}
+	return nil
+}
+
+func (c *ReplicationConf) DestBck() (bck Bck, err error) {
+	bck, _, err = ParseBckObjectURI(c.Dest, ParseURIOpts{})
+	if err == nil && bck.Name == "" {
+		err = errors.New("missing bucket name")
+	}
+	return
+}
+
+const minInvInterval = 10 * time.Minute
+
+func (c *InventoryConf) ValidateAsProps(arg ...any) error {
+	if err := ValidateInvFormat(c.Format); err != nil {
+		return fmt.Errorf("invalid inventory.format: %v", err)
+	}
+	if c.Dest != "" {
+		if _, err := c.DestBck(); err != nil {
+			return fmt.Errorf("invalid inventory.dest %q: %v", c.Dest, err)
+		}
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if provider, _ := arg[0].(string); provider != apc.AIS {
+		return fmt.Errorf("invalid inventory.enabled: generating inventory requires ais:// bucket (have %q)", provider)
+	}
+	if c.Interval.D() < minInvInterval {
+		return fmt.Errorf("invalid inventory.interval %v (expecting >= %v)", c.Interval, minInvInterval)
+	}
+	return nil
+}
+
+func (c *InventoryConf) DestBck() (bck Bck, err error) {
+	bck, _, err = ParseBckObjectURI(c.Dest, ParseURIOpts{})
+	if err == nil && bck.Name == "" {
+		err = errors.New("missing bucket name")
+	}
+	if err == nil && !bck.IsAIS() {
+		err = errors.New("expecting ais:// bucket")
+	}
+	return
+}
+
 //
 // Bucket Summary - result for a given bucket, and all results -------------------------------------------------
 //
@@ -373,3 +542,68 @@ type (
 )
 
 func (msg *ArchiveBckMsg) Cname() string { return msg.ToBck.Cname(msg.ArchName) }
+
+//
+// Bucket inventory ---------------------------------------------------------------------------------------
+//
+
+const (
+	InvFormatCSV     = "csv"
+	InvFormatParquet = "parquet"
+)
+
+// InvMsg contains parameters to generate inventory of a given (ais://) bucket:
+// sharded manifest that lists objects along with their properties (see apc.GetProps*);
+// the result can then be used to list the bucket (see apc.HdrInventory).
+type InvMsg struct {
+	Name     string `json:"name,omitempty"`     // inventory name (default: s3.InvName)
+	ID       string `json:"id,omitempty"`       // inventory ID (optional)
+	Prefix   string `json:"prefix,omitempty"`   // inventory only objects with names starting with
+	Format   string `json:"format,omitempty"`   // InvFormatCSV (default) or InvFormatParquet
+	Props    string `json:"props,omitempty"`    // comma-separated object properties (default: InvPropsDefault)
+	ToBck    Bck    `json:"tobck,omitempty"`    // destination bucket (default: bucket's inventory.dest or the bucket itself)
+	PageSize int64  `json:"pagesize,omitempty"` // max number of records in a single shard (default: InvShardSize)
+}
+
+const (
+	InvPropsDefault = apc.GetPropsSize + apc.LsPropsSepa + apc.GetPropsChecksum + apc.LsPropsSepa +
+		apc.GetPropsVersion + apc.LsPropsSepa + apc.GetPropsAtime + apc.LsPropsSepa + apc.GetPropsCustom
+	InvShardSize = 1024 * 1024
+)
+
+func ValidateInvFormat(format string) error {
+	switch format {
+	case "", InvFormatCSV:
+		return nil
+	case InvFormatParquet:
+		return NewErrNotImpl("generate", "parquet inventory")
+	default:
+		return fmt.Errorf("unknown inventory format %q (expecting %q or %q)", format, InvFormatCSV, InvFormatParquet)
+	}
+}
+
+func (msg *InvMsg) Validate() error {
+	if err := ValidateInvFormat(msg.Format); err != nil {
+		return err
+	}
+	if msg.PageSize < 0 {
+		return fmt.Errorf("invalid inventory page size %d", msg.PageSize)
+	}
+	if !msg.ToBck.IsEmpty() && !msg.ToBck.IsAIS() {
+		return fmt.Errorf("invalid inventory destination %s: expecting ais:// bucket", msg.ToBck.Cname(""))
+	}
+	if msg.Props == "" {
+		msg.Props = InvPropsDefault
+	}
+	for _, prop := range strings.Split(msg.Props, apc.LsPropsSepa) {
+		switch prop {
+		case apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsVersion, apc.GetPropsAtime, apc.GetPropsCustom:
+		default:
+			return fmt.Errorf("invalid inventory property %q (expecting one of: %s)", prop, InvPropsDefault)
+		}
+	}
+	if msg.PageSize == 0 {
+		msg.PageSize = InvShardSize
+	}
+	return ValidatePrefix(msg.Prefix)
+}
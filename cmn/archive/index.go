@@ -0,0 +1,138 @@
+// Package archive: write, read, copy, append, list primitives
+// across all supported formats
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+// Random-access index ("sidecar") ---------------------------------------
+// Reading a single archived file out of a (large) TAR requires scanning
+// the latter from the very beginning. The index maps archived filenames
+// to their respective (offset, size) within the shard, so that readers
+// could seek directly to the file's data.
+//
+// The index is stored separately from the shard itself, as LOM-associated
+// content (see fs.ArchIndexType). The shard's size and mtime are recorded
+// at indexing time - a mismatch means the index is stale and must not
+// be used.
+//
+// Only uncompressed TAR is supported: compressed formats cannot be seeked
+// into, while ZIP carries its own central directory.
+// -----------------------------------------------------------------------
+
+const indexMetaver = 1
+
+type (
+	IdxEntry struct {
+		Name string `json:"n"`
+		Off  int64  `json:"o"` // offset of the archived file's data (ie., past its header(s))
+		Size int64  `json:"s"`
+	}
+	Index struct {
+		Entries []*IdxEntry `json:"e"` // sorted by name
+		Size    int64       `json:"z"` // size of the indexed shard
+		Mtime   int64       `json:"m"` // shard's mtime (ns)
+	}
+)
+
+var errIndexMime = errors.New("only (uncompressed) " + ExtTar + " shards can be indexed")
+
+// interface guard
+var _ jsp.Opts = (*Index)(nil)
+
+// counts consumed bytes to derive offsets
+type cntReader struct {
+	r   io.Reader
+	off int64
+}
+
+func (cr *cntReader) Read(b []byte) (n int, err error) {
+	n, err = cr.r.Read(b)
+	cr.off += int64(n)
+	return n, err
+}
+
+func Indexable(mime string) bool { return mime == ExtTar }
+
+// NewIndex reads the entire TAR and returns its (sorted) index;
+// the caller is expected to fill in shard's size and mtime
+func NewIndex(mime string, r io.Reader) (*Index, error) {
+	if !Indexable(mime) {
+		return nil, errIndexMime
+	}
+	var (
+		cr  = &cntReader{r: r}
+		tr  = tar.NewReader(cr)
+		idx = &Index{}
+	)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if hdr.FileInfo().IsDir() {
+			continue
+		}
+		// tar.Reader does not read ahead: upon Next() the underlying
+		// reader is positioned at the beginning of the file's data
+		idx.Entries = append(idx.Entries, &IdxEntry{Name: hdr.Name, Off: cr.off, Size: hdr.Size})
+	}
+	sort.Slice(idx.Entries, func(i, j int) bool { return idx.Entries[i].Name < idx.Entries[j].Name })
+	return idx, nil
+}
+
+func (*Index) JspOpts() jsp.Options { return jsp.CCSign(indexMetaver) }
+
+func (idx *Index) Valid(size, mtime int64) bool { return idx.Size == size && idx.Mtime == mtime }
+
+func (idx *Index) Find(filename string) *IdxEntry {
+	debug.Assert(filename != "", "missing archived filename (pathname)")
+	i := sort.Search(len(idx.Entries), func(i int) bool { return idx.Entries[i].Name >= filename })
+	if i < len(idx.Entries) && idx.Entries[i].Name == filename {
+		return idx.Entries[i]
+	}
+	// in re `--absolute-names` (see namesEq)
+	for _, e := range idx.Entries {
+		if namesEq(e.Name, filename) {
+			return e
+		}
+	}
+	return nil
+}
+
+// same as List() but without reading the shard
+func (idx *Index) List() []*Entry {
+	lst := make([]*Entry, len(idx.Entries))
+	for i, e := range idx.Entries {
+		lst[i] = &Entry{Name: e.Name, Size: e.Size}
+	}
+	return lst
+}
+
+// same as Reader.ReadOne() but seeking directly to the archived file;
+// returns (nil, nil) when not found
+func (idx *Index) ReadOne(ra io.ReaderAt, filename string) (cos.ReadCloseSizer, error) {
+	e := idx.Find(filename)
+	if e == nil {
+		return nil, nil
+	}
+	if e.Off+e.Size > idx.Size {
+		return nil, errors.New("archive index: entry " + e.Name + " is out of bounds")
+	}
+	csl := &cslLimited{LimitedReader: io.LimitedReader{R: io.NewSectionReader(ra, e.Off, e.Size), N: e.Size}}
+	return csl, nil
+}
@@ -0,0 +1,78 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"sort"
+)
+
+// cluster configuration and bucket-props history, as retained by the primary
+// (see also: apc.WhatConfigHistory, apc.WhatConfigDiff, apc.ActRollbackConfig)
+
+type (
+	// a single changed (leaf) field, e.g. {"ec.enabled", "false", "true"}
+	PropDelta struct {
+		Name string `json:"name"`
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	CfgHistEntry struct {
+		Bck     *Bck        `json:"bck,omitempty"` // nil for cluster config
+		Who     string      `json:"who"`           // user ID (when authenticated) or client address
+		Action  string      `json:"action"`        // apc.ActSetConfig, apc.ActSetBprops, et al.
+		Delta   []PropDelta `json:"delta,omitempty"`
+		Version int64       `json:"version,string"` // config or BMD version
+		Time    int64       `json:"time,string"`    // unix nano
+	}
+	CfgHistory struct {
+		Config []CfgHistEntry `json:"config"`
+		Bprops []CfgHistEntry `json:"bprops"`
+	}
+)
+
+// not counting as changes
+var diffSkipFields = map[string]struct{}{
+	"config_version":  {},
+	"lastupdate_time": {},
+	"uuid":            {},
+	"auth.secret":     {},
+}
+
+// DiffProps compares two values of the same (config or bucket props) type
+// field by field, and returns the changed leaves sorted by name
+func DiffProps(from, to any) (delta []PropDelta) {
+	var (
+		f = propsToMap(from)
+		t = propsToMap(to)
+	)
+	for name, vf := range f {
+		if vt := t[name]; vt != vf {
+			delta = append(delta, PropDelta{Name: name, From: vf, To: vt})
+		}
+	}
+	for name, vt := range t {
+		if _, ok := f[name]; !ok && vt != "" {
+			delta = append(delta, PropDelta{Name: name, To: vt})
+		}
+	}
+	sort.Slice(delta, func(i, j int) bool { return delta[i].Name < delta[j].Name })
+	return delta
+}
+
+func propsToMap(v any) map[string]string {
+	m := make(map[string]string, 128)
+	if v == nil {
+		return m
+	}
+	IterFields(v, func(tag string, field IterField) (error, bool) {
+		if _, ok := diffSkipFields[tag]; !ok {
+			m[tag] = fmt.Sprint(field.Value())
+		}
+		return nil, false
+	})
+	return m
+}
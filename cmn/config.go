@@ -122,10 +122,17 @@ type (
 		// metadata write policy: (immediate | delayed | never)
 		WritePolicy WritePolicyConf `json:"write_policy"`
 
+		// finished jobs (xactions) retained by each node across restarts
+		JobHistory JobHistoryConf `json:"job_history"`
+
 		// standalone enumerated features that can be configured
 		// to flip assorted global defaults (see cmn/feat/feat.go)
 		Features feat.Flags `json:"features,string" allow:"cluster"`
 
+		// cluster-wide fence: reject all writes, deletes, and bucket creation/removal
+		// (e.g., during migration); reads and listings proceed as usual
+		ReadOnly bool `json:"read_only" allow:"cluster"`
+
 		// read-only
 		LastUpdated string `json:"lastupdate_time"`       // timestamp
 		UUID        string `json:"uuid"`                  // UUID
@@ -157,8 +164,10 @@ type (
 		Memsys      *MemsysConfToSet      `json:"memsys,omitempty"`
 		TCB         *TCBConfToSet         `json:"tcb,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
+		JobHistory  *JobHistoryConfToSet  `json:"job_history,omitempty"`
 		Proxy       *ProxyConfToSet       `json:"proxy,omitempty"`
 		Features    *feat.Flags           `json:"features,string,omitempty"`
+		ReadOnly    *bool                 `json:"read_only,omitempty"`
 
 		// LocalConfig
 		FSP *FSPConf `json:"fspaths,omitempty"`
@@ -249,11 +258,15 @@ type (
 		StatsTime     cos.Duration `json:"stats_time"`      // collect and publish stats; other house-keeping
 		RetrySyncTime cos.Duration `json:"retry_sync_time"` // metasync retry
 		NotifTime     cos.Duration `json:"notif_time"`      // (IC notifications)
+		// latency histograms: comma-separated bucket upper bounds in increasing order,
+		// e.g. "1ms,10ms,100ms,1s"; empty - use defaults (see stats/histogram.go)
+		LatencyBuckets string `json:"latency_buckets,omitempty"`
 	}
 	PeriodConfToSet struct {
-		StatsTime     *cos.Duration `json:"stats_time,omitempty"`
-		RetrySyncTime *cos.Duration `json:"retry_sync_time,omitempty"`
-		NotifTime     *cos.Duration `json:"notif_time,omitempty"`
+		StatsTime      *cos.Duration `json:"stats_time,omitempty"`
+		RetrySyncTime  *cos.Duration `json:"retry_sync_time,omitempty"`
+		NotifTime      *cos.Duration `json:"notif_time,omitempty"`
+		LatencyBuckets *string       `json:"latency_buckets,omitempty"`
 	}
 
 	// maximum intra-cluster latencies (in the increasing order)
@@ -507,14 +520,21 @@ type (
 
 	// keepalive tracker
 	KeepaliveTrackerConf struct {
-		Name     string       `json:"name"`     // "heartbeat" (other enumerated values TBD)
+		Name     string       `json:"name"`     // KeepaliveHeartbeat or KeepalivePhi (phi-accrual failure detector)
 		Interval cos.Duration `json:"interval"` // keepalive interval
 		Factor   uint8        `json:"factor"`   // only average
+		// phi-accrual only:
+		// - suspicion threshold (default: 8);
+		// - time to remain "suspect" prior to getting removed from the cluster map (default: interval * factor)
+		PhiThreshold float64      `json:"phi_threshold,omitempty"`
+		SuspectTime  cos.Duration `json:"suspect_time,omitempty"`
 	}
 	KeepaliveTrackerConfToSet struct {
-		Interval *cos.Duration `json:"interval,omitempty"`
-		Name     *string       `json:"name,omitempty" list:"readonly"`
-		Factor   *uint8        `json:"factor,omitempty"`
+		Interval     *cos.Duration `json:"interval,omitempty"`
+		Name         *string       `json:"name,omitempty" list:"readonly"`
+		Factor       *uint8        `json:"factor,omitempty"`
+		PhiThreshold *float64      `json:"phi_threshold,omitempty"`
+		SuspectTime  *cos.Duration `json:"suspect_time,omitempty"`
 	}
 
 	KeepaliveConf struct {
@@ -540,6 +560,7 @@ type (
 		MissingShards       string       `json:"missing_shards"` // cmn.SupportedReactions enum
 		EKMMalformedLine    string       `json:"ekm_malformed_line"`
 		EKMMissingKey       string       `json:"ekm_missing_key"`
+		ETLError            string       `json:"etl_error,omitempty"` // per-record transformation (empty: abort)
 		DefaultMaxMemUsage  string       `json:"default_max_mem_usage"`
 		CallTimeout         cos.Duration `json:"call_timeout"`
 		DsorterMemThreshold string       `json:"dsorter_mem_threshold"`
@@ -551,6 +572,7 @@ type (
 		MissingShards       *string       `json:"missing_shards,omitempty"`
 		EKMMalformedLine    *string       `json:"ekm_malformed_line,omitempty"`
 		EKMMissingKey       *string       `json:"ekm_missing_key,omitempty"`
+		ETLError            *string       `json:"etl_error,omitempty"`
 		DefaultMaxMemUsage  *string       `json:"default_max_mem_usage,omitempty"`
 		CallTimeout         *cos.Duration `json:"call_timeout,omitempty"`
 		DsorterMemThreshold *string       `json:"dsorter_mem_threshold,omitempty"`
@@ -615,6 +637,15 @@ type (
 		Data *apc.WritePolicy `json:"data,omitempty" list:"readonly"` // NOTE: NIY
 		MD   *apc.WritePolicy `json:"md,omitempty"`
 	}
+
+	JobHistoryConf struct {
+		MaxAge   cos.Duration `json:"max_age"`   // remove finished jobs older than (0: default)
+		MaxCount int          `json:"max_count"` // keep at most (0: default)
+	}
+	JobHistoryConfToSet struct {
+		MaxAge   *cos.Duration `json:"max_age,omitempty"`
+		MaxCount *int          `json:"max_count,omitempty"`
+	}
 )
 
 // assorted named fields that require (cluster | node) restart for changes to make an effect
@@ -629,6 +660,15 @@ const (
 
 var SupportedReactions = []string{IgnoreReaction, WarnReaction, AbortReaction}
 
+// keepalive trackers
+const (
+	KeepaliveHeartbeat = "heartbeat"
+	KeepalivePhi       = "phi" // phi-accrual failure detector
+
+	dfltPhiThreshold = 8
+	maxPhiThreshold  = 32
+)
+
 //
 // config meta-versioning & serialization
 //
@@ -674,6 +714,7 @@ var (
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
+	_ Validator = (*JobHistoryConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
@@ -796,9 +837,36 @@ func (c *PeriodConf) Validate() error {
 		return fmt.Errorf("invalid periodic.notif_time=%s (expected range [1s, 1m])",
 			c.StatsTime)
 	}
+	if _, err := ParseLatencyBuckets(c.LatencyBuckets); err != nil {
+		return err
+	}
 	return nil
 }
 
+const maxLatencyBuckets = 64
+
+func ParseLatencyBuckets(s string) ([]time.Duration, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) > maxLatencyBuckets {
+		return nil, fmt.Errorf("invalid periodic.latency_buckets: too many (%d > %d)", len(parts), maxLatencyBuckets)
+	}
+	buckets := make([]time.Duration, 0, len(parts))
+	for _, p := range parts {
+		d, err := time.ParseDuration(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("invalid periodic.latency_buckets=%q: %v", s, err)
+		}
+		if d <= 0 || (len(buckets) > 0 && d <= buckets[len(buckets)-1]) {
+			return nil, fmt.Errorf("invalid periodic.latency_buckets=%q: expecting positive durations in increasing order", s)
+		}
+		buckets = append(buckets, d)
+	}
+	return buckets, nil
+}
+
 /////////////
 // LogConf //
 /////////////
@@ -1209,16 +1277,48 @@ func (c *WritePolicyConf) ValidateAsProps(...any) error { return c.Validate() }
 ///////////////////
 
 func (c *KeepaliveConf) Validate() (err error) {
-	if c.Proxy.Name != "heartbeat" {
-		err = fmt.Errorf("invalid keepalivetracker.proxy.name %s", c.Proxy.Name)
-	} else if c.Target.Name != "heartbeat" {
-		err = fmt.Errorf("invalid keepalivetracker.target.name %s", c.Target.Name)
-	} else if c.RetryFactor < 1 || c.RetryFactor > 10 {
+	if err = c.Proxy.validate("proxy"); err != nil {
+		return err
+	}
+	if err = c.Target.validate("target"); err != nil {
+		return err
+	}
+	if c.RetryFactor < 1 || c.RetryFactor > 10 {
 		err = fmt.Errorf("invalid keepalivetracker.retry_factor %d (expecting 1 thru 10)", c.RetryFactor)
 	}
 	return err
 }
 
+func (c *KeepaliveTrackerConf) validate(tag string) error {
+	if c.Name != KeepaliveHeartbeat && c.Name != KeepalivePhi {
+		return fmt.Errorf("invalid keepalivetracker.%s.name %q (expecting %q or %q)",
+			tag, c.Name, KeepaliveHeartbeat, KeepalivePhi)
+	}
+	if c.PhiThreshold < 0 || c.PhiThreshold > maxPhiThreshold {
+		return fmt.Errorf("invalid keepalivetracker.%s.phi_threshold %.2f (expecting 0 (default) thru %d)",
+			tag, c.PhiThreshold, maxPhiThreshold)
+	}
+	if c.SuspectTime < 0 {
+		return fmt.Errorf("invalid keepalivetracker.%s.suspect_time %v", tag, c.SuspectTime)
+	}
+	return nil
+}
+
+func (c *KeepaliveTrackerConf) Phi() float64 {
+	if c.PhiThreshold == 0 {
+		return dfltPhiThreshold
+	}
+	return c.PhiThreshold
+}
+
+// phi-accrual: for how long a node may remain "suspect" (before being removed from the cluster map)
+func (c *KeepaliveTrackerConf) Suspect() time.Duration {
+	if c.SuspectTime == 0 {
+		return c.Interval.D() * time.Duration(max(c.Factor, 1))
+	}
+	return c.SuspectTime.D()
+}
+
 func KeepaliveRetryDuration(c *Config) time.Duration {
 	d := c.Timeout.CplaneOperation.D() * time.Duration(c.Keepalive.RetryFactor)
 	return min(d, c.Timeout.MaxKeepalive.D()+time.Second/2)
@@ -1384,6 +1484,9 @@ func (c *DsortConf) ValidateWithOpts(allowEmpty bool) (err error) {
 	if !checkReaction(c.EKMMissingKey) {
 		return fmt.Errorf(_idsort+"ekm_missing_key: %s (expecting one of: %s)", c.EKMMissingKey, SupportedReactions)
 	}
+	if c.ETLError != "" && !checkReaction(c.ETLError) { // optional (empty: abort)
+		return fmt.Errorf(_idsort+"etl_error: %s (expecting one of: %s)", c.ETLError, SupportedReactions)
+	}
 	if !allowEmpty {
 		if _, err := cos.ParseQuantity(c.DefaultMaxMemUsage); err != nil {
 			return fmt.Errorf(_idsort+"default_max_mem_usage: %s (err: %s)", c.DefaultMaxMemUsage, err)
@@ -1615,6 +1718,40 @@ func (c *TCBConf) Validate() error {
 	return nil
 }
 
+////////////////////
+// JobHistoryConf //
+////////////////////
+
+const (
+	dfltJobHistAge   = 30 * 24 * time.Hour
+	dfltJobHistCount = 1000
+	maxJobHistCount  = 100_000
+)
+
+func (c *JobHistoryConf) Validate() error {
+	if c.MaxAge < 0 {
+		return fmt.Errorf("invalid job_history.max_age: %v", c.MaxAge)
+	}
+	if c.MaxCount < 0 || c.MaxCount > maxJobHistCount {
+		return fmt.Errorf("invalid job_history.max_count: %d (expected range [0, %d])", c.MaxCount, maxJobHistCount)
+	}
+	return nil
+}
+
+func (c *JobHistoryConf) Age() time.Duration {
+	if c.MaxAge == 0 {
+		return dfltJobHistAge
+	}
+	return c.MaxAge.D()
+}
+
+func (c *JobHistoryConf) Count() int {
+	if c.MaxCount == 0 {
+		return dfltJobHistCount
+	}
+	return c.MaxCount
+}
+
 /////////////////
 // TimeoutConf //
 /////////////////
@@ -27,6 +27,7 @@ const (
 	// range to read:
 	HdrRange          = "Range" // Ref: https://www.rfc-editor.org/rfc/rfc7233#section-2.1
 	HdrRangeValPrefix = "bytes="
+	HdrIfRange        = "If-Range" // Ref: https://www.rfc-editor.org/rfc/rfc7233#section-3.2
 	// range read response:
 	HdrContentRange          = "Content-Range"
 	HdrContentRangeValPrefix = "bytes " // Ref: https://tools.ietf.org/html/rfc7233#section-4.2
@@ -38,11 +39,15 @@ const (
 	HdrContentLength      = "Content-Length"
 
 	// misc. gen
-	HdrUserAgent = "User-Agent"
-	HdrAccept    = "Accept"
-	HdrLocation  = "Location"
-	HdrServer    = "Server"
-	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	HdrUserAgent    = "User-Agent"
+	HdrAccept       = "Accept"
+	HdrLocation     = "Location"
+	HdrServer       = "Server"
+	HdrETag         = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
+	HdrLastModified = "Last-Modified"
+	HdrRetryAfter   = "Retry-After"
+
+	HdrForwardedFor = "X-Forwarded-For" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Forwarded-For
 
 	HdrHSTS = "Strict-Transport-Security"
 )
@@ -0,0 +1,40 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// drain stages (target that is leaving the cluster - maintenance or decommission)
+const (
+	DrainCounting  = "counting"  // counting local objects and bytes to migrate
+	DrainMigrating = "migrating" // global rebalance: sending local objects to their new HRW owners
+	DrainVerifying = "verifying" // confirming that all migrated objects are present at their new owners
+	DrainDone      = "done"
+	DrainAborted   = "aborted"
+)
+
+// DrainStatus is reported by the leaving target (and then augmented by the proxy - see `Safe`).
+// Note that erasure-coded buckets are migrated by EC rebalance and are not included.
+type DrainStatus struct {
+	DaemonID   string       `json:"sid"`
+	RebID      string       `json:"reb_id"`
+	Stage      string       `json:"stage"` // enum above
+	Err        string       `json:"err,omitempty"`
+	TotalObjs  int64        `json:"total_objs,string"`  // at the start of the drain
+	TotalBytes int64        `json:"total_bytes,string"` // ditto
+	SentObjs   int64        `json:"sent_objs,string"`
+	SentBytes  int64        `json:"sent_bytes,string"`
+	RemObjs    int64        `json:"rem_objs,string"`   // remaining
+	RemBytes   int64        `json:"rem_bytes,string"`  // ditto
+	Throughput int64        `json:"throughput,string"` // bytes per second
+	ETA        cos.Duration `json:"eta"`
+	Unverified int64        `json:"unverified,string"` // objects not found at their new owners (verifying stage)
+	Safe       bool         `json:"safe"`              // all migrated and verified: the node can be powered off
+}
+
+func (ds *DrainStatus) Finished() bool { return ds.Stage == DrainDone || ds.Stage == DrainAborted }
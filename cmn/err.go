@@ -75,6 +75,11 @@ type (
 		what        string
 		detail      []string
 	}
+	// bucket or cluster is read-only ("fenced"): writers must back off and retry later
+	ErrReadOnly struct {
+		what   string // "bucket <name>" or "cluster"
+		action string
+	}
 
 	ErrFailedTo struct {
 		actor  string // most of the time it's this (target|proxy) node but may also be some other "actor"
@@ -426,6 +431,26 @@ func (e *ErrBusy) Error() string {
 	return fmt.Sprintf("%s %q is currently busy%s, please try again", e.whereOrType, e.what, s)
 }
 
+// ErrReadOnly
+
+// RetryAfterReadOnly is the Retry-After value (seconds) returned to fenced writers
+const RetryAfterReadOnly = "30"
+
+func NewErrReadOnly(what, action string) *ErrReadOnly { return &ErrReadOnly{what, action} }
+
+func (e *ErrReadOnly) Error() string {
+	return fmt.Sprintf("%s is read-only: %s is not permitted, please try again later", e.what, e.action)
+}
+
+// (also works on the client side - see api/client)
+func IsErrReadOnly(err error) bool {
+	if _, ok := err.(*ErrReadOnly); ok {
+		return true
+	}
+	herr, ok := err.(*ErrHTTP)
+	return ok && herr.TypeCode == "ErrReadOnly"
+}
+
 // errAccessDenied & ErrBucketAccessDenied
 
 func (e *errAccessDenied) String() string {
@@ -834,6 +859,15 @@ func (e *ErrLimitedCoexistence) Error() string {
 		e.node, e.xaction, e.action, e.detail)
 }
 
+// (also works on the client side - see api/client)
+func IsErrLimitedCoexistence(err error) bool {
+	if _, ok := err.(*ErrLimitedCoexistence); ok {
+		return true
+	}
+	herr, ok := err.(*ErrHTTP)
+	return ok && herr.TypeCode == "ErrLimitedCoexistence"
+}
+
 // ErrXactUsePrev
 
 func NewErrXactUsePrev(xaction string) *ErrXactUsePrev {
@@ -1178,8 +1212,13 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 			status = http.StatusRequestedRangeNotSatisfiable
 		case isErrUnsupp(err), isErrNotImpl(err):
 			status = http.StatusNotImplemented
+		case IsErrReadOnly(err):
+			status = http.StatusServiceUnavailable
 		}
 	}
+	if IsErrReadOnly(err) {
+		w.Header().Set(cos.HdrRetryAfter, RetryAfterReadOnly)
+	}
 
 	herr.init(r, err, status)
 	herr.write(w, r, l > 1)
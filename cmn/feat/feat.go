@@ -38,6 +38,8 @@ const (
 	StreamingColdGET          // write and transmit cold-GET content back to user in parallel, without _finalizing_ in-cluster object
 	S3ReverseProxy            // use reverse proxy calls instead of HTTP-redirect for S3 API
 	S3UsePathStyle            // use older path-style addressing (as opposed to virtual-hosted style), e.g., https://s3.amazonaws.com/BUCKET/KEY
+	ArchIndex                 // (*) generate random-access index sidecars for TAR shards upon PUT, APPEND, etc. (see cmn/archive/index.go)
+	BucketMetrics             // track per-bucket data-path metrics: GET, PUT, DELETE counts, sizes, latencies, and errors (see stats/bucket_stats.go)
 )
 
 var Cluster = [...]string{
@@ -56,6 +58,8 @@ var Cluster = [...]string{
 	"Streaming-Cold-GET",
 	"S3-Reverse-Proxy",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
+	"Archive-Index",
+	"Bucket-Metrics",
 	// "none" ====================
 }
 
@@ -66,6 +70,7 @@ var Bucket = [...]string{
 	"Disable-Cold-GET",
 	"Streaming-Cold-GET",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
+	"Archive-Index",
 	// "none" ====================
 }
 
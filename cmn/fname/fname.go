@@ -25,6 +25,15 @@ const (
 	// proxy aisnode ID
 	ProxyID = ".ais.proxy_id"
 
+	// primary proxy: cluster config and bucket props history (see ais/prxcfghist.go)
+	CfgHistory = ".ais.cfghist"
+
+	// finished jobs (xactions) retained across restarts (see xact/xreg/hist.go)
+	JobHistory = ".ais.jobhist"
+
+	// queued (conflicting) jobs waiting to start; replicated to all proxies (see ais/prxjobq.go)
+	JobQueue = ".ais.jobq"
+
 	// metadata
 	Smap        = ".ais.smap"   // Smap persistent file basename
 	Rmd         = ".ais.rmd"    // rmd persistent file basename
@@ -0,0 +1,32 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+// queued jobs: jobs that conflict with running xactions get accepted in the "queued"
+// state (see apc.QparamQueue) and started by the primary when the conflict clears
+// (see also: apc.WhatJobQueue, apc.ActXactStop)
+
+const (
+	JobQueued  = "queued"
+	JobStarted = "started" // see QueuedJob.Xid
+	JobFailed  = "failed"  // see QueuedJob.Err
+	JobAborted = "aborted" // canceled while queued
+)
+
+type QueuedJob struct {
+	ID      string `json:"id"`
+	Action  string `json:"action"`            // apc.ActCopyBck, apc.ActETLBck, ... or xaction kind (when started via apc.ActXactStart)
+	Bck     string `json:"bck,omitempty"`     // bucket (cname), if applicable
+	Who     string `json:"who"`               // user ID (when authenticated) or client address
+	State   string `json:"state"`             // enum above
+	Blocker string `json:"blocker,omitempty"` // the most recent conflict
+	Xid     string `json:"xid,omitempty"`     // started xaction ID
+	Err     string `json:"err,omitempty"`
+	Prio    int    `json:"prio"`        // higher priority starts first; same priority - in the order of submission
+	Time    int64  `json:"time,string"` // when queued (unix nano)
+}
+
+func (j *QueuedJob) Finished() bool { return j.State != JobQueued }
@@ -37,6 +37,16 @@ const (
 
 	// additional backend
 	LastModified = "LastModified"
+
+	// write-back: object is yet to be uploaded to its remote backend;
+	// the value is a unique (per PUT) token - see ais/tgtwriteback.go
+	WritebackObjMD = "wb-pending"
+	// write-back: gave up uploading the object (the value is the last error)
+	WritebackErrObjMD = "wb-failed"
+
+	// replication: object has been replicated to the destination bucket
+	// (the value) - see ais/tgtrepl.go
+	ReplicatedObjMD = "repl-done"
 )
 
 // object properties
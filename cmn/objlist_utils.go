@@ -73,6 +73,10 @@ func (be *LsoEnt) IsVerChanged() bool { return be.Flags&apc.EntryVerChanged != 0
 func (be *LsoEnt) SetVerRemoved()     { be.Flags |= apc.EntryVerRemoved }
 func (be *LsoEnt) IsVerRemoved() bool { return be.Flags&apc.EntryVerRemoved != 0 }
 
+// write-back (see also: WritebackObjMD)
+func (be *LsoEnt) SetPending()     { be.Flags |= apc.EntryIsPending }
+func (be *LsoEnt) IsPending() bool { return be.Flags&apc.EntryIsPending != 0 }
+
 func (be *LsoEnt) IsStatusOK() bool   { return be.Status() == 0 }
 func (be *LsoEnt) Status() uint16     { return be.Flags & apc.EntryStatusMask }
 func (be *LsoEnt) IsDir() bool        { return be.Flags&apc.EntryIsDir != 0 }
@@ -23,6 +23,7 @@ type readMostly struct {
 	level, modules int
 	testingEnv     bool
 	authEnabled    bool
+	readOnly       bool
 }
 
 var Rom readMostly
@@ -37,6 +38,7 @@ func (rom *readMostly) Set(cfg *ClusterConfig) {
 	rom.timeout.keepalive = cfg.Timeout.MaxKeepalive.D()
 	rom.features = cfg.Features
 	rom.authEnabled = cfg.Auth.Enabled
+	rom.readOnly = cfg.ReadOnly
 
 	// pre-parse for FastV (below)
 	rom.level, rom.modules = cfg.Log.Level.Parse()
@@ -47,6 +49,7 @@ func (rom *readMostly) MaxKeepalive() time.Duration    { return rom.timeout.keep
 func (rom *readMostly) Features() feat.Flags           { return rom.features }
 func (rom *readMostly) TestingEnv() bool               { return rom.testingEnv }
 func (rom *readMostly) AuthEnabled() bool              { return rom.authEnabled }
+func (rom *readMostly) ReadOnly() bool                 { return rom.readOnly }
 
 func (rom *readMostly) FastV(verbosity, fl int) bool {
 	return rom.level >= verbosity || rom.modules&fl != 0
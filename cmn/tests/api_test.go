@@ -5,8 +5,11 @@
 package tests_test
 
 import (
+	"time"
+
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -184,4 +187,61 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	Describe("Inventory", func() {
+		It("should set defaults", func() {
+			msg := &cmn.InvMsg{}
+			Expect(msg.Validate()).NotTo(HaveOccurred())
+			Expect(msg.Props).To(Equal(cmn.InvPropsDefault))
+			Expect(msg.PageSize).To(Equal(int64(cmn.InvShardSize)))
+		})
+		DescribeTable("should reject invalid inventory requests",
+			func(msg cmn.InvMsg) {
+				Expect(msg.Validate()).To(HaveOccurred())
+			},
+			Entry("unknown format", cmn.InvMsg{Format: "xml"}),
+			Entry("parquet (not implemented)", cmn.InvMsg{Format: cmn.InvFormatParquet}),
+			Entry("unsupported property", cmn.InvMsg{Props: apc.GetPropsSize + ",location"}),
+			Entry("remote destination", cmn.InvMsg{ToBck: cmn.Bck{Name: "inv", Provider: apc.AWS}}),
+			Entry("negative page size", cmn.InvMsg{PageSize: -1}),
+		)
+		DescribeTable("should validate inventory bucket props",
+			func(conf cmn.InventoryConf, provider string, valid bool) {
+				err := conf.ValidateAsProps(provider)
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(HaveOccurred())
+				}
+			},
+			Entry("disabled", cmn.InventoryConf{}, apc.AWS, true),
+			Entry("enabled", cmn.InventoryConf{Enabled: true, Interval: cos.Duration(time.Hour)}, apc.AIS, true),
+			Entry("enabled for remote bucket", cmn.InventoryConf{Enabled: true, Interval: cos.Duration(time.Hour)}, apc.AWS, false),
+			Entry("interval too short", cmn.InventoryConf{Enabled: true, Interval: cos.Duration(time.Second)}, apc.AIS, false),
+			Entry("ais destination", cmn.InventoryConf{Dest: "ais://inv"}, apc.AIS, true),
+			Entry("remote destination", cmn.InventoryConf{Dest: "s3://inv"}, apc.AIS, false),
+		)
+	})
+
+	Describe("Replication", func() {
+		DescribeTable("should validate replication bucket props",
+			func(conf cmn.ReplicationConf, backendBck, bck cmn.Bck, valid bool) {
+				err := conf.ValidateAsProps(&backendBck, &bck)
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(HaveOccurred())
+				}
+			},
+			Entry("disabled", cmn.ReplicationConf{}, cmn.Bck{}, cmn.Bck{Name: "src", Provider: apc.AIS}, true),
+			Entry("cloud destination", cmn.ReplicationConf{Enabled: true, Dest: "s3://dst"},
+				cmn.Bck{}, cmn.Bck{Name: "src", Provider: apc.AIS}, true),
+			Entry("ais destination", cmn.ReplicationConf{Enabled: true, Dest: "ais://dst"},
+				cmn.Bck{}, cmn.Bck{Name: "src", Provider: apc.AIS}, false),
+			Entry("own backend", cmn.ReplicationConf{Enabled: true, Dest: "s3://dst"},
+				cmn.Bck{Name: "dst", Provider: apc.AWS}, cmn.Bck{Name: "src", Provider: apc.AIS}, false),
+			Entry("onto itself", cmn.ReplicationConf{Enabled: true, Dest: "s3://src"},
+				cmn.Bck{}, cmn.Bck{Name: "src", Provider: apc.AWS}, false),
+		)
+	})
 })
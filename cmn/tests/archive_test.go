@@ -0,0 +1,75 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/tools/trand"
+)
+
+func TestArchIndex(t *testing.T) {
+	for _, format := range []tar.Format{tar.FormatUSTAR, tar.FormatPAX, tar.FormatGNU} {
+		t.Run(format.String(), func(t *testing.T) { testArchIndex(t, format) })
+	}
+}
+
+func testArchIndex(t *testing.T, format tar.Format) {
+	var (
+		buf   bytes.Buffer
+		tw    = tar.NewWriter(&buf)
+		files = make(map[string][]byte, 64)
+	)
+	for i := range 64 {
+		name := "dir/" + strconv.Itoa(i) + "/" + trand.String(8)
+		if i%8 == 0 && format != tar.FormatUSTAR {
+			name += "/" + strings.Repeat("x", 120) // long name => extended header(s)
+		}
+		content := []byte(trand.String(i * 100))
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(content)), Mode: 0o644, Format: format}
+		tassert.CheckFatal(t, tw.WriteHeader(hdr))
+		_, err := tw.Write(content)
+		tassert.CheckFatal(t, err)
+		files[name] = content
+	}
+	tassert.CheckFatal(t, tw.Close())
+
+	idx, err := archive.NewIndex(archive.ExtTar, bytes.NewReader(buf.Bytes()))
+	tassert.CheckFatal(t, err)
+	idx.Size = int64(buf.Len())
+	tassert.Fatalf(t, len(idx.Entries) == len(files), "expected %d entries, got %d", len(files), len(idx.Entries))
+
+	// list: same as reading the entire shard
+	lst := idx.List()
+	for i := 1; i < len(lst); i++ {
+		tassert.Errorf(t, lst[i-1].Name < lst[i].Name, "not sorted: %q vs %q", lst[i-1].Name, lst[i].Name)
+	}
+
+	// read: seek directly to each archived file
+	ra := bytes.NewReader(buf.Bytes())
+	for name, content := range files {
+		csl, err := idx.ReadOne(ra, name)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, csl != nil, "%q not found", name)
+		tassert.Errorf(t, csl.Size() == int64(len(content)), "%q: size %d vs %d", name, csl.Size(), len(content))
+		b, err := io.ReadAll(csl)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bytes.Equal(b, content), "%q: content mismatch", name)
+		csl.Close()
+	}
+	csl, err := idx.ReadOne(ra, "does-not-exist")
+	tassert.Errorf(t, csl == nil && err == nil, "expected (nil, nil), got (%v, %v)", csl, err)
+
+	// only uncompressed TAR
+	_, err = archive.NewIndex(archive.ExtTgz, bytes.NewReader(buf.Bytes()))
+	tassert.Errorf(t, err != nil, "expected error indexing %s", archive.ExtTgz)
+}
@@ -7,6 +7,7 @@ package tests_test
 import (
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"testing"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -72,3 +73,25 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigDiffProps(t *testing.T) {
+	confPath := filepath.Join(thisFileDir(t), "configs", "config.json")
+	localConfPath := filepath.Join(thisFileDir(t), "configs", "confignet.json")
+	from := cmn.Config{}
+	err := cmn.LoadConfig(confPath, localConfPath, apc.Proxy, &from)
+	tassert.CheckFatal(t, err)
+
+	to := from.ClusterConfig
+	to.Version++
+	to.LastUpdated = "now"
+	delta := cmn.DiffProps(&from.ClusterConfig, &to)
+	tassert.Fatalf(t, len(delta) == 0, "expected no changes, got %v", delta)
+
+	to.EC.Enabled = !from.EC.Enabled
+	to.Log.Level = "5"
+	delta = cmn.DiffProps(&from.ClusterConfig, &to)
+	tassert.Fatalf(t, len(delta) == 2, "expected 2 changes, got %v", delta)
+	tassert.Errorf(t, delta[0].Name == "ec.enabled" && delta[0].To == strconv.FormatBool(to.EC.Enabled),
+		"unexpected delta[0]: %+v", delta[0])
+	tassert.Errorf(t, delta[1].Name == "log.level" && delta[1].To == "5", "unexpected delta[1]: %+v", delta[1])
+}
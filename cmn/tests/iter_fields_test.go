@@ -102,6 +102,20 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"readahead.window":           0,
+					"write_back.enabled":         false,
+					"replication.dest":           "",
+					"replication.prefix":         "",
+					"replication.reconcile_time": cos.Duration(0),
+					"replication.deletes":        false,
+					"replication.enabled":        false,
+					"inventory.dest":             "",
+					"inventory.prefix":           "",
+					"inventory.format":           "",
+					"inventory.interval":         cos.Duration(0),
+					"inventory.enabled":          false,
+					"read_only":                  false,
 				},
 			),
 			Entry("list BpropsToSet fields",
@@ -162,6 +176,20 @@ var _ = Describe("IterFields", func() {
 					"extra.aws.profile":        (*string)(nil),
 					"extra.aws.max_pagesize":   (*int64)(nil),
 					"extra.http.original_url":  (*string)(nil),
+
+					"readahead.window":           (*int)(nil),
+					"write_back.enabled":         (*bool)(nil),
+					"replication.dest":           (*string)(nil),
+					"replication.prefix":         (*string)(nil),
+					"replication.reconcile_time": (*cos.Duration)(nil),
+					"replication.deletes":        (*bool)(nil),
+					"replication.enabled":        (*bool)(nil),
+					"inventory.dest":             (*string)(nil),
+					"inventory.prefix":           (*string)(nil),
+					"inventory.format":           (*string)(nil),
+					"inventory.interval":         (*cos.Duration)(nil),
+					"inventory.enabled":          (*bool)(nil),
+					"read_only":                  (*bool)(nil),
 				},
 			),
 			Entry("check for omit tag",
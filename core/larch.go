@@ -0,0 +1,76 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+//
+// LOM-associated random-access index of a TAR shard (see cmn/archive/index.go)
+//
+
+func (lom *LOM) ArchIdxFQN() string { return fs.CSM.Gen(lom, fs.ArchIdxType, "") }
+
+// GenArchIdx (re)generates and stores shard's index; no-op when the object
+// is not an (uncompressed) TAR
+// (automatic - asynchronous - indexing upon PUT, APPEND, etc. is controlled by feat.ArchIndex)
+func (lom *LOM) GenArchIdx() error {
+	if mime, err := archive.Mime("", lom.ObjName); err != nil || !archive.Indexable(mime) {
+		return nil
+	}
+	fh, err := os.Open(lom.FQN)
+	if err != nil {
+		return err
+	}
+	// stat the open file (rather than the path) to make sure that the recorded
+	// size and mtime belong to the indexed content
+	finfo, err := fh.Stat()
+	if err != nil {
+		cos.Close(fh)
+		return err
+	}
+	idx, err := archive.NewIndex(archive.ExtTar, fh)
+	cos.Close(fh)
+	if err != nil {
+		return err
+	}
+	idx.Size, idx.Mtime = finfo.Size(), finfo.ModTime().UnixNano()
+	return jsp.SaveMeta(lom.ArchIdxFQN(), idx, nil)
+}
+
+// LoadArchIdx returns (nil, nil) when the shard has no index or the index is stale
+func LoadArchIdx(objFQN, idxFQN string) (*archive.Index, error) {
+	finfo, err := os.Stat(objFQN)
+	if err != nil {
+		return nil, err
+	}
+	idx := &archive.Index{}
+	if _, err := jsp.LoadMeta(idxFQN, idx); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	if !idx.Valid(finfo.Size(), finfo.ModTime().UnixNano()) {
+		return nil, nil
+	}
+	return idx, nil
+}
+
+func (lom *LOM) RemoveArchIdx() {
+	if mime, err := archive.Mime("", lom.ObjName); err != nil || !archive.Indexable(mime) {
+		return
+	}
+	if err := cos.RemoveFile(lom.ArchIdxFQN()); err != nil {
+		nlog.Errorln("failed to remove archive index of", lom.Cname(), "err:", err)
+	}
+}
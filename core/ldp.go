@@ -22,6 +22,12 @@ type (
 	DP interface {
 		Reader(lom *LOM, latestVer, sync bool) (reader cos.ReadOpenCloser, oah cos.OAH, err error)
 	}
+	// optionally, one source object => multiple destination objects (e.g., ETL fan-out)
+	// in which case the reader is a TAR stream with member names relative to the destination name
+	FanOutDP interface {
+		DP
+		FanOut() bool
+	}
 
 	LDP struct{}
 
@@ -116,9 +122,13 @@ remote:
 // - [PRECONDITION]: `versioning.validate_warm_get` || QparamLatestVer
 // - [Sync] when Sync option is used (via bucket config and/or `sync` argument) caller MUST take wlock or rlock
 // - [MAY] delete remotely-deleted (non-existing) object and increment associated stats counter
+// - [NEVER] for objects pending write-back (cmn.WritebackObjMD): the in-cluster copy is the latest
 //
 // Returns NotFound also after having removed local replica (the Sync option)
 func (lom *LOM) CheckRemoteMD(locked, sync bool, origReq *http.Request) (res CRMD) {
+	if _, ok := lom.GetCustomKey(cmn.WritebackObjMD); ok {
+		return CRMD{Eq: true}
+	}
 	bck := lom.Bck()
 	if !bck.HasVersioningMD() {
 		// nothing to do with: in-cluster ais:// bucket, or a remote one
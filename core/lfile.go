@@ -111,6 +111,7 @@ func (lom *LOM) RemoveObj(force ...bool) (err error) {
 			err = erc
 		}
 	}
+	lom.RemoveArchIdx()
 	lom.md.lid = 0
 	return err
 }
@@ -43,6 +43,10 @@ const (
 	LcacheCollisionCount = "lcache.collision.n"
 	LcacheEvictedCount   = "lcache.evicted.n"
 	LcacheFlushColdCount = "lcache.flush.cold.n"
+
+	// latencies: erasure-coding (encode and distribute) and ETL (transform)
+	ECEncodeLatency = "ec.encode.ns"
+	ETLLatency      = "etl.ns"
 )
 
 type (
@@ -94,6 +98,13 @@ var (
 
 func Pinit() { bckLocker = newNameLocker() }
 
+// update target stats from packages that have no direct access to the stats runner (ec, etl, etc.)
+func StatsAdd(name string, val int64) {
+	if g.tstats != nil {
+		g.tstats.Add(name, val)
+	}
+}
+
 func Tinit(t Target, tstats cos.StatsUpdater, runHK bool) {
 	bckLocker = newNameLocker()
 	T = t
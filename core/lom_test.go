@@ -582,6 +582,29 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("CheckRemoteMD", func() {
+		testObject := "foldr/test-obj.ext"
+		cloudFQN := mis[0].MakePathFQN(&cloudBckA, fs.ObjectType, testObject)
+
+		// (no backend in this test - any attempt to HEAD remote object would panic)
+		It("should not check remote metadata of objects pending write-back", func() {
+			lom := filePut(cloudFQN, 0)
+			lom.SetCustomKey(cmn.WritebackObjMD, "token")
+			Expect(persist(lom)).NotTo(HaveOccurred())
+
+			lom.Lock(true)
+			res := lom.CheckRemoteMD(true /*locked*/, true /*sync*/, nil)
+			lom.Unlock(true)
+			Expect(res.Err).NotTo(HaveOccurred())
+			Expect(res.Eq).To(BeTrue())
+
+			// not removed
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			_, pending := lom.GetCustomKey(cmn.WritebackObjMD)
+			Expect(pending).To(BeTrue())
+		})
+	})
+
 	Describe("copy object methods", func() {
 		const (
 			testObjectName = "foldr/test-obj.ext"
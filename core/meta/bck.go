@@ -225,6 +225,9 @@ func (b *Bck) VersionConf() cmn.VersionConf {
 func (b *Bck) Allow(bit apc.AccessAttrs) error { return b.checkAccess(bit) }
 
 func (b *Bck) checkAccess(bit apc.AccessAttrs) (err error) {
+	if err = b.checkFence(bit); err != nil {
+		return
+	}
 	if b.Props.Access.Has(bit) {
 		return
 	}
@@ -233,6 +236,22 @@ func (b *Bck) checkAccess(bit apc.AccessAttrs) (err error) {
 	return
 }
 
+// read-only bucket or cluster: reject data-modifying ops (see apc.AccessMut)
+func (b *Bck) checkFence(bit apc.AccessAttrs) error {
+	mut := bit & apc.AccessMut
+	if mut == 0 {
+		return nil
+	}
+	mut &= -mut // name the first one
+	switch {
+	case cmn.Rom.ReadOnly():
+		return cmn.NewErrReadOnly("cluster", apc.AccessOp(mut))
+	case b.Props.ReadOnly:
+		return cmn.NewErrReadOnly("bucket "+b.String(), apc.AccessOp(mut))
+	}
+	return nil
+}
+
 func (b *Bck) MaxPageSize() int64 {
 	switch b.Provider {
 	case apc.AIS:
@@ -117,4 +117,43 @@ var _ = Describe("Bck", func() {
 			),
 		)
 	})
+
+	Describe("Allow", func() {
+		var bck *meta.Bck
+
+		BeforeEach(func() {
+			bck = meta.NewBck("a", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Access: apc.AccessAll})
+		})
+
+		It("should reject writes to read-only bucket", func() {
+			bck.Props.ReadOnly = true
+			for _, ace := range []apc.AccessAttrs{apc.AcePUT, apc.AceAPPEND, apc.AceObjDELETE, apc.AceObjMOVE, apc.AccessRW} {
+				err := bck.Allow(ace)
+				Expect(err).To(HaveOccurred())
+				Expect(cmn.IsErrReadOnly(err)).To(BeTrue())
+			}
+			for _, ace := range []apc.AccessAttrs{apc.AceGET, apc.AceObjHEAD, apc.AceObjLIST, apc.AceBckHEAD, apc.AccessRO} {
+				Expect(bck.Allow(ace)).NotTo(HaveOccurred())
+			}
+		})
+
+		It("should reject writes when the cluster is read-only", func() {
+			cfg := &cmn.ClusterConfig{ReadOnly: true}
+			cmn.Rom.Set(cfg)
+			defer func() {
+				cfg.ReadOnly = false
+				cmn.Rom.Set(cfg)
+			}()
+			Expect(cmn.IsErrReadOnly(bck.Allow(apc.AcePUT))).To(BeTrue())
+			Expect(bck.Allow(apc.AceGET)).NotTo(HaveOccurred())
+		})
+
+		It("should report access denied, not read-only, for reads", func() {
+			bck.Props.ReadOnly = true
+			bck.Props.Access = apc.AccessNone
+			err := bck.Allow(apc.AceGET)
+			Expect(err).To(HaveOccurred())
+			Expect(cmn.IsErrReadOnly(err)).To(BeFalse())
+		})
+	})
 })
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/core/meta"
 )
@@ -58,6 +59,7 @@ type (
 		Finish()
 		Abort(error) bool
 		AddNotif(n Notif)
+		SetOrigin(msg *apc.ActMsg, user string) // the originating request and user (see job history)
 
 		// common stats
 		Objs() int64
@@ -98,6 +100,11 @@ type (
 		// rebalance-only
 		RebID int64 `json:"glob.id,string"`
 
+		// origin (when known): the action message that started this xaction, and the user
+		// (user ID when authenticated, client address otherwise)
+		Msg  *apc.ActMsg `json:"msg,omitempty"`
+		User string      `json:"user,omitempty"`
+
 		// common runtime: stats counters (above) and state
 		Stats    Stats `json:"stats"`
 		AbortedX bool  `json:"aborted"`
@@ -162,6 +162,7 @@ cat > $AIS_CONF_FILE <<EOL
 		"missing_shards":        "ignore",
 		"ekm_malformed_line":    "abort",
 		"ekm_missing_key":       "abort",
+		"etl_error":             "abort",
 		"default_max_mem_usage": "80%",
 		"call_timeout":          "10m",
 		"dsorter_mem_threshold": "100GB",
@@ -176,6 +177,10 @@ cat > $AIS_CONF_FILE <<EOL
 		"data": "${WRITE_POLICY_DATA:-}",
 		"md": "${WRITE_POLICY_MD:-}"
 	},
+	"job_history": {
+		"max_age":	"720h",
+		"max_count":	1000
+	},
 	"features": "0"
 }
 EOL
@@ -171,7 +171,11 @@ func (c *putJogger) ec(req *request, lom *core.LOM) (err error) {
 			errRm := cos.RemoveFile(ctMeta.FQN())
 			debug.AssertNoErr(errRm)
 		}
-		c.parent.stats.updateEncodeTime(time.Since(req.tm), err != nil)
+		elapsed := time.Since(req.tm)
+		c.parent.stats.updateEncodeTime(elapsed, err != nil)
+		if err == nil {
+			core.StatsAdd(core.ECEncodeLatency, elapsed.Nanoseconds())
+		}
 	case ActDelete:
 		err = c.cleanup(lom)
 		c.parent.stats.updateDeleteTime(time.Since(req.tm), err != nil)
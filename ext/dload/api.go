@@ -5,6 +5,9 @@
 package dload
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -39,6 +42,13 @@ type (
 		json.RawMessage
 	}
 
+	// persistent (kvdb) record of a started job - to restart the latter upon target restart
+	JobRecord struct {
+		ID     string `json:"id"`
+		XactID string `json:"xaction_id"`
+		Body   Body   `json:"body"`
+	}
+
 	// Download POST result returned to the user
 	DlPostResp struct {
 		ID string `json:"id"`
@@ -74,11 +84,22 @@ type (
 	}
 
 	Base struct {
-		Description      string  `json:"description"`
-		Bck              cmn.Bck `json:"bucket"`
-		Timeout          string  `json:"timeout"`
-		ProgressInterval string  `json:"progress_interval"`
-		Limits           Limits  `json:"limits"`
+		Description      string   `json:"description"`
+		Bck              cmn.Bck  `json:"bucket"`
+		Timeout          string   `json:"timeout"`
+		ProgressInterval string   `json:"progress_interval"`
+		Limits           Limits   `json:"limits"`
+		Manifest         Manifest `json:"manifest,omitempty"`
+	}
+
+	// Manifest is an optional list of expected sizes and checksums of the
+	// content to download (by link); each downloaded file gets verified
+	// prior to storing the object - mismatches are reported as task errors
+	Manifest      map[string]*ManifestEntry // link => expected size and checksum(s)
+	ManifestEntry struct {
+		Size   int64  `json:"size,omitempty"`
+		MD5    string `json:"md5,omitempty"`
+		SHA256 string `json:"sha256,omitempty"`
 	}
 
 	SingleObj struct {
@@ -301,9 +322,36 @@ func (b *Base) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	return b.Manifest.Validate()
+}
+
+//////////////
+// Manifest //
+//////////////
+
+func (m Manifest) Validate() error {
+	for link, e := range m {
+		if link == "" || e == nil {
+			return fmt.Errorf("invalid manifest entry %q => %v", link, e)
+		}
+		if e.Size < 0 {
+			return fmt.Errorf("manifest: %q: size must be non-negative (got: %d)", link, e.Size)
+		}
+		if e.MD5 != "" && !_isHex(e.MD5, md5.Size) {
+			return fmt.Errorf("manifest: %q: invalid md5 %q", link, e.MD5)
+		}
+		if e.SHA256 != "" && !_isHex(e.SHA256, sha256.Size) {
+			return fmt.Errorf("manifest: %q: invalid sha256 %q", link, e.SHA256)
+		}
+	}
 	return nil
 }
 
+func _isHex(s string, size int) bool {
+	b, err := hex.DecodeString(s)
+	return err == nil && len(b) == size
+}
+
 ///////////////
 // SingleObj //
 ///////////////
@@ -12,11 +12,14 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderJobs       = "jobs"  // started (and not yet finished) jobs, see JobRecord
+	downloaderParts      = "parts" // partially downloaded objects, see partRecord
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -30,6 +33,15 @@ const (
 
 var errJobNotFound = errors.New("job not found")
 
+type (
+	// workfile that keeps partially downloaded content across retries and restarts
+	// (removed upon commit, or when the job finishes - see singleTask.downloadLocal)
+	partRecord struct {
+		FQN       string `json:"fqn"`
+		Validator string `json:"validator"` // see If-Range
+	}
+)
+
 type downloaderDB struct {
 	mtx    sync.RWMutex
 	driver kvdb.Driver
@@ -185,3 +197,92 @@ func (db *downloaderDB) delete(id string) {
 	db.driver.Delete(downloaderCollection, key)
 	db.mtx.Unlock()
 }
+
+//
+// job records
+//
+
+func (db *downloaderDB) persistJobRecord(rec *JobRecord) error {
+	key := path.Join(downloaderJobs, rec.ID)
+	return db.driver.Set(downloaderCollection, key, rec)
+}
+
+func (db *downloaderDB) delJobRecord(id string) {
+	key := path.Join(downloaderJobs, id)
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !cos.IsErrNotFound(err) {
+		nlog.Errorln(err)
+	}
+}
+
+func (db *downloaderDB) jobRecords() (recs []*JobRecord, err error) {
+	all, err := db.driver.GetAll(downloaderCollection, downloaderJobs+"/")
+	if err != nil {
+		if cos.IsErrNotFound(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	recs = make([]*JobRecord, 0, len(all))
+	for key, v := range all {
+		rec := &JobRecord{}
+		if err := jsoniter.Unmarshal([]byte(v), rec); err != nil {
+			nlog.Errorln("failed to unmarshal download job record", key+":", err)
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+//
+// partially downloaded objects
+//
+
+func (db *downloaderDB) persistPart(id, uname string, rec *partRecord) {
+	key := path.Join(downloaderParts, id, uname)
+	if err := db.driver.Set(downloaderCollection, key, rec); err != nil {
+		nlog.Errorln("failed to persist", rec.FQN+":", err) // (won't resume upon restart)
+	}
+}
+
+func (db *downloaderDB) getPart(id, uname string) (rec *partRecord) {
+	rec = &partRecord{}
+	key := path.Join(downloaderParts, id, uname)
+	if err := db.driver.Get(downloaderCollection, key, rec); err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln(err)
+		}
+		return nil
+	}
+	return rec
+}
+
+func (db *downloaderDB) delPart(id, uname string) {
+	key := path.Join(downloaderParts, id, uname)
+	if err := db.driver.Delete(downloaderCollection, key); err != nil && !cos.IsErrNotFound(err) {
+		nlog.Errorln(err)
+	}
+}
+
+// remove all workfiles of the job (that is done and won't resume)
+func (db *downloaderDB) delParts(id string) {
+	prefix := path.Join(downloaderParts, id) + "/"
+	all, err := db.driver.GetAll(downloaderCollection, prefix)
+	if err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln(err)
+		}
+		return
+	}
+	for key, v := range all {
+		rec := &partRecord{}
+		if err := jsoniter.Unmarshal([]byte(v), rec); err == nil {
+			if err := cos.RemoveFile(rec.FQN); err != nil {
+				nlog.Errorln(err)
+			}
+		}
+		if err := db.driver.Delete(downloaderCollection, key); err != nil && !cos.IsErrNotFound(err) {
+			nlog.Errorln(err)
+		}
+	}
+}
@@ -68,6 +68,13 @@ type (
 		// via tryAcquire and release
 		throttler() *throttler
 
+		// expected size and checksum(s), if provided (see Manifest)
+		manifest(link string) *ManifestEntry
+
+		// original request - to persist and, upon target restart, resume the job
+		setBody(dlb *Body)
+		record() *JobRecord
+
 		// job cleanup
 		cleanup()
 	}
@@ -80,6 +87,8 @@ type (
 		description string
 		timeout     time.Duration
 		throt       throttler
+		mfst        Manifest
+		body        *Body
 	}
 
 	sliceDlJob struct {
@@ -133,13 +142,14 @@ type (
 // baseDlJob //
 ///////////////
 
-func (j *baseDlJob) init(id string, bck *meta.Bck, timeout, desc string, limits Limits, xdl *Xact) {
+func (j *baseDlJob) init(id string, bck *meta.Bck, base *Base, desc string, xdl *Xact) {
+	limits := base.Limits
 	// TODO: this might be inaccurate if we download 1 or 2 objects because then
 	//  other targets will have limits but will not use them.
 	if limits.BytesPerHour > 0 {
 		limits.BytesPerHour /= core.T.Sowner().Get().CountActiveTs()
 	}
-	td, _ := time.ParseDuration(timeout)
+	td, _ := time.ParseDuration(base.Timeout)
 	{
 		j.id = id
 		j.bck = bck
@@ -148,6 +158,13 @@ func (j *baseDlJob) init(id string, bck *meta.Bck, timeout, desc string, limits
 		j.throt.init(limits)
 		j.xdl = xdl
 	}
+	if len(base.Manifest) > 0 {
+		// same normalization as dlObj.link (see makeDlObj)
+		j.mfst = make(Manifest, len(base.Manifest))
+		for link, e := range base.Manifest {
+			j.mfst[cmn.PrependProtocol(link)] = e
+		}
+	}
 }
 
 func (j *baseDlJob) ID() string             { return j.id }
@@ -190,6 +207,17 @@ func (j *baseDlJob) ActiveStats() (*StatusResp, error) {
 func (*baseDlJob) checkObj(string) bool    { debug.Assert(false); return false }
 func (j *baseDlJob) throttler() *throttler { return &j.throt }
 
+func (j *baseDlJob) manifest(link string) *ManifestEntry { return j.mfst[link] }
+
+func (j *baseDlJob) setBody(dlb *Body) { j.body = dlb }
+
+func (j *baseDlJob) record() *JobRecord {
+	if j.body == nil {
+		return nil
+	}
+	return &JobRecord{ID: j.id, XactID: j.XactID(), Body: *j.body}
+}
+
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
 	err, aborted := g.store.markFinished(j.ID())
@@ -198,6 +226,12 @@ func (j *baseDlJob) cleanup() {
 		nlog.Errorln(j.String()+":", err, aborted)
 	}
 	g.store.flush(j.ID())
+	// unless interrupted by this target's shutdown (in which case the job
+	// will be resumed upon restart - see PendingJobs)
+	if !nlog.Stopping() {
+		g.store.delJobRecord(j.ID())
+		g.store.delParts(j.ID())
+	}
 	nl.OnFinished(j.Notif(), err, aborted)
 }
 
@@ -235,7 +269,7 @@ func newMultiDlJob(id string, bck *meta.Bck, payload *MultiBody, xdl *Xact) (mj
 	var objs cos.StrKVs
 
 	mj = &multiDlJob{}
-	mj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	mj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -250,7 +284,7 @@ func newSingleDlJob(id string, bck *meta.Bck, payload *SingleBody, xdl *Xact) (s
 	var objs cos.StrKVs
 
 	sj = &singleDlJob{}
-	sj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	sj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -273,7 +307,7 @@ func newRangeDlJob(id string, bck *meta.Bck, payload *RangeBody, xdl *Xact) (rj
 	if rj.pt, err = cos.ParseBashTemplate(payload.Template); err != nil {
 		return nil, err
 	}
-	rj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	rj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if rj.count, err = countObjects(rj.pt, payload.Subdir, rj.bck); err != nil {
 		return nil, err
@@ -336,7 +370,7 @@ func newBackendDlJob(id string, bck *meta.Bck, payload *BackendBody, xdl *Xact)
 		return nil, errors.New("bucket download does not support HTTP buckets")
 	}
 	bj = &backendDlJob{}
-	bj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	bj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 	{
 		bj.sync = payload.Sync
 		bj.prefix = payload.Prefix
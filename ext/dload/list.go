@@ -28,3 +28,22 @@ ex:
 	rsp := req.response
 	return rsp.value, rsp.statusCode, rsp.err
 }
+
+// PendingJobs returns jobs that were started but not finished prior to this target's
+// shutdown (or crash); the caller is expected to resume them, or else call DelPendingJob
+func PendingJobs() ([]*JobRecord, error) {
+	if g.store == nil {
+		return nil, nil
+	}
+	recs, err := g.store.jobRecords()
+	for _, rec := range recs {
+		// resumed jobs start counting (tasks, errors) from scratch
+		g.store.delete(rec.ID)
+	}
+	return recs, err
+}
+
+func DelPendingJob(id string) {
+	g.store.delJobRecord(id)
+	g.store.delParts(id)
+}
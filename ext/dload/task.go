@@ -6,11 +6,18 @@ package dload
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -18,6 +25,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
 )
@@ -30,6 +38,10 @@ const (
 	retryCnt         = 10  // number of retries to external resource
 	reqTimeoutFactor = 1.2 // newTimeout = prevTimeout * reqTimeoutFactor
 	internalErrorMsg = "internal server error"
+
+	// exponential backoff between retries
+	retryBackoff    = time.Second
+	maxRetryBackoff = time.Minute
 )
 
 type singleTask struct {
@@ -43,6 +55,7 @@ type singleTask struct {
 	downloadCtx context.Context    // w/ cancel function
 	getCtx      context.Context    // w/ timeout and size
 	cancel      context.CancelFunc // to cancel in-progress download
+	validator   string             // (strong) ETag or Last-Modified of the content being downloaded - see If-Range
 }
 
 // List of HTTP status codes which we shouldn'task retry (just report the job failed).
@@ -103,16 +116,34 @@ func (task *singleTask) download(lom *core.LOM) {
 	task.xdl.ObjsAdd(1, task.currentSize.Load())
 }
 
-func (task *singleTask) _dlocal(lom *core.LOM, timeout time.Duration) (bool /*err is fatal*/, error) {
+// (one attempt) GET the link and append the response to the workfile;
+// when the latter is not empty resume the download via HTTP Range
+func (task *singleTask) _dlocal(lom *core.LOM, wfh *os.File, timeout time.Duration) (bool /*err is fatal*/, error) {
 	ctx, cancel := context.WithTimeout(task.downloadCtx, timeout)
 	defer cancel()
 
 	task.getCtx = ctx
 
+	off, err := wfh.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return true, err
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, task.obj.link, http.NoBody)
 	if err != nil {
 		return true, err
 	}
+	if off > 0 && task.validator == "" {
+		// cannot make sure the content hasn't changed in the meantime - start over
+		if err := task.truncate(wfh); err != nil {
+			return true, err
+		}
+		off = 0
+	}
+	if off > 0 {
+		// resume only if unchanged (otherwise, expecting 200 with the entire new content)
+		req.Header.Set(cos.HdrRange, fmt.Sprintf("bytes=%d-", off))
+		req.Header.Set(cos.HdrIfRange, task.validator)
+	}
 
 	// Set "User-Agent" header when doing requests to Google Cloud Storage.
 	// This should increase the number of connections to GCS.
@@ -125,54 +156,181 @@ func (task *singleTask) _dlocal(lom *core.LOM, timeout time.Duration) (bool /*er
 		return false, err
 	}
 
-	fatal, err := task._dput(lom, req, resp)
+	fatal, err := task._dwrite(lom, wfh, off, req, resp)
 	cos.Close(resp.Body)
 	return fatal, err
 }
 
-func (task *singleTask) _dput(lom *core.LOM, req *http.Request, resp *http.Response) (bool /*err is fatal*/, error) {
-	if resp.StatusCode >= http.StatusBadRequest {
-		if resp.StatusCode == http.StatusNotFound {
-			return false, cmn.NewErrHTTP(req, fmt.Errorf("%q does not exist", task.obj.link), http.StatusNotFound)
+func (task *singleTask) _dwrite(lom *core.LOM, wfh *os.File, off int64, req *http.Request, resp *http.Response) (bool /*err is fatal*/, error) {
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		// expecting "bytes <off>-<last>/<total>"
+		if cr := resp.Header.Get(cos.HdrContentRange); !strings.HasPrefix(cr, "bytes "+strconv.FormatInt(off, 10)+"-") {
+			if err := task.truncate(wfh); err != nil {
+				return true, err
+			}
+			return false, cmn.NewErrHTTP(req,
+				fmt.Errorf("%q: unexpected content range %q (offset %d) - restarting", task.obj.link, cr, off),
+				resp.StatusCode)
 		}
+		// (in case the server ignored If-Range)
+		if v := respValidator(resp); v != "" && v != task.validator {
+			if err := task.truncate(wfh); err != nil {
+				return true, err
+			}
+			return false, cmn.NewErrHTTP(req,
+				fmt.Errorf("%q: content changed (%q vs %q) - restarting", task.obj.link, v, task.validator),
+				resp.StatusCode)
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && off > 0:
+		if err := task.truncate(wfh); err != nil {
+			return true, err
+		}
+		return false, cmn.NewErrHTTP(req,
+			fmt.Errorf("%q: range not satisfiable (offset %d) - restarting", task.obj.link, off),
+			resp.StatusCode)
+	case resp.StatusCode == http.StatusNotFound:
+		return false, cmn.NewErrHTTP(req, fmt.Errorf("%q does not exist", task.obj.link), http.StatusNotFound)
+	case resp.StatusCode >= http.StatusBadRequest:
 		return false, cmn.NewErrHTTP(req,
 			fmt.Errorf("failed to download %q: status %d", task.obj.link, resp.StatusCode),
 			resp.StatusCode)
+	case off > 0:
+		// Range not supported or content changed (If-Range) - start over
+		if err := task.truncate(wfh); err != nil {
+			return true, err
+		}
+		off = 0
+	}
+	if off == 0 {
+		task.validator = respValidator(resp)
+		task.persistPart(lom, wfh.Name())
 	}
 
 	r := task.wrapReader(resp.Body)
-	size := attrsFromLink(task.obj.link, resp, lom)
-	task.setTotalSize(size)
-
-	params := core.AllocPutParams()
-	{
-		params.WorkTag = "dl"
-		params.Reader = r
-		params.OWT = cmn.OwtPut
-		params.Atime = task.started.Load()
-		params.Size = size
-		params.Xact = task.xdl
-	}
-	erp := core.T.PutObject(lom, params)
-	core.FreePutParams(params)
-	if erp != nil {
-		return true, erp
-	}
-	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
-		return true, err
+	if size := attrsFromLink(task.obj.link, resp, lom); size > 0 {
+		task.setTotalSize(off + size)
+	}
+	_, err := io.Copy(wfh, r)
+	return false, err // retrying will resume from where we stopped
+}
+
+// strong ETag or, otherwise, Last-Modified (see https://www.rfc-editor.org/rfc/rfc7233#section-3.2);
+// empty if neither
+func respValidator(resp *http.Response) string {
+	if etag := resp.Header.Get(cos.HdrETag); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
 	}
-	return false, nil
+	return resp.Header.Get(cos.HdrLastModified)
 }
 
+// (to resume upon restart)
+func (task *singleTask) persistPart(lom *core.LOM, wfqn string) {
+	if task.validator == "" {
+		g.store.delPart(task.jobID(), lom.Uname())
+		return
+	}
+	g.store.persistPart(task.jobID(), lom.Uname(), &partRecord{FQN: wfqn, Validator: task.validator})
+}
+
+func (task *singleTask) truncate(wfh *os.File) error {
+	task.reset()
+	task.validator = ""
+	if err := wfh.Truncate(0); err != nil {
+		return err
+	}
+	_, err := wfh.Seek(0, io.SeekStart)
+	return err
+}
+
+// verify downloaded content (see Manifest) and finalize the object
+func (task *singleTask) _dput(lom *core.LOM, wfqn string) error {
+	fh, err := os.Open(wfqn)
+	if err != nil {
+		return err
+	}
+	var (
+		mfst    = task.job.manifest(task.obj.link)
+		cksum   *cos.CksumHash
+		hmd5    hash.Hash
+		hsha256 hash.Hash
+		writers = make([]io.Writer, 0, 3)
+	)
+	if ty := lom.CksumType(); ty != cos.ChecksumNone {
+		cksum = cos.NewCksumHash(ty)
+		writers = append(writers, cksum.H)
+	}
+	if mfst != nil && mfst.MD5 != "" {
+		hmd5 = md5.New()
+		writers = append(writers, hmd5)
+	}
+	if mfst != nil && mfst.SHA256 != "" {
+		hsha256 = sha256.New()
+		writers = append(writers, hsha256)
+	}
+	buf, slab := core.T.PageMM().Alloc()
+	size, err := io.CopyBuffer(io.MultiWriter(writers...), fh, buf)
+	slab.Free(buf)
+	cos.Close(fh)
+	if err != nil {
+		return err
+	}
+
+	if mfst != nil {
+		switch {
+		case mfst.Size > 0 && mfst.Size != size:
+			err = fmt.Errorf("size mismatch: expected %d, got %d", mfst.Size, size)
+		case hmd5 != nil && !strings.EqualFold(mfst.MD5, hex.EncodeToString(hmd5.Sum(nil))):
+			err = fmt.Errorf("md5 mismatch: expected %s, got %s", mfst.MD5, hex.EncodeToString(hmd5.Sum(nil)))
+		case hsha256 != nil && !strings.EqualFold(mfst.SHA256, hex.EncodeToString(hsha256.Sum(nil))):
+			err = fmt.Errorf("sha256 mismatch: expected %s, got %s", mfst.SHA256, hex.EncodeToString(hsha256.Sum(nil)))
+		}
+		if err != nil {
+			return fmt.Errorf("%q: %w", task.obj.link, err)
+		}
+	}
+
+	lom.SetSize(size)
+	if cksum != nil {
+		cksum.Finalize()
+		lom.SetCksum(cksum.Clone())
+	}
+	if _, err := core.T.FinalizeObj(lom, wfqn, task.xdl, cmn.OwtPut); err != nil {
+		return err
+	}
+	return lom.Load(true /*cache it*/, false /*locked*/)
+}
+
+// The workfile is keyed by job and object, to resume the download upon restart
+// (when the persisted job resumes - see PendingJobs). It is removed upon commit,
+// or when the job finishes (see delParts) - but not on failure, unless there is
+// nothing to resume (no validator).
 func (task *singleTask) downloadLocal(lom *core.LOM) (err error) {
 	var (
 		timeout = task.initialTimeout()
+		backoff = retryBackoff
+		wfqn    = fs.CSM.GenKeyed(lom, fs.WorkfileDownload, task.jobID())
+		wfh     *os.File
 		fatal   bool
 	)
+	// downloaded content accumulates in the workfile across retries (and restarts)
+	if wfh, err = task.openWork(lom, wfqn); err != nil {
+		return err
+	}
+	defer func() {
+		if wfh != nil {
+			cos.Close(wfh)
+		}
+		if err != nil && task.validator == "" {
+			if errRm := cos.RemoveFile(wfqn); errRm != nil {
+				nlog.Errorln("nested err:", errRm)
+			}
+		}
+	}()
 	for i := range retryCnt {
-		fatal, err = task._dlocal(lom, timeout)
+		fatal, err = task._dlocal(lom, wfh, timeout)
 		if err == nil || fatal {
-			return err
+			break
 		}
 
 		// handle more
@@ -193,11 +351,58 @@ func (task *singleTask) downloadLocal(lom *core.LOM) (err error) {
 			}
 			nlog.Warningf("%s [retries: %d/%d]: connection failed with (%v), retrying...", task, i, retryCnt, err)
 		}
-		task.reset()
+		if i == retryCnt-1 {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+			backoff = min(2*backoff, maxRetryBackoff)
+		case <-task.downloadCtx.Done():
+			return task.downloadCtx.Err()
+		}
+	}
+	if err != nil {
+		return err
+	}
+	err = wfh.Close()
+	wfh = nil
+	if err != nil {
+		return err
+	}
+	err = task._dput(lom, wfqn)
+	if task.validator != "" {
+		g.store.delPart(task.jobID(), lom.Uname())
+	}
+	if err != nil {
+		task.validator = "" // (verification failed - nothing to resume)
 	}
 	return err
 }
 
+// open the workfile for appending; resume from the previously downloaded content
+// only if it was persisted along with its validator (see persistPart)
+func (task *singleTask) openWork(lom *core.LOM, wfqn string) (*os.File, error) {
+	if err := cos.CreateDir(filepath.Dir(wfqn)); err != nil {
+		return nil, err
+	}
+	wfh, err := os.OpenFile(wfqn, os.O_WRONLY|os.O_CREATE, cos.PermRWR)
+	if err != nil {
+		return nil, err
+	}
+	off, err := wfh.Seek(0, io.SeekEnd)
+	if err != nil {
+		cos.Close(wfh)
+		return nil, err
+	}
+	if off > 0 {
+		if rec := g.store.getPart(task.jobID(), lom.Uname()); rec != nil && rec.FQN == wfqn {
+			task.validator = rec.Validator
+			task.currentSize.Store(off)
+		}
+	}
+	return wfh, nil
+}
+
 func (task *singleTask) setTotalSize(size int64) {
 	if size > 0 {
 		task.totalSize.Store(size)
@@ -0,0 +1,328 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+type (
+	testJob struct {
+		jobif
+		mfst Manifest
+	}
+	testServer struct {
+		content     []byte
+		etag        string
+		ignoreRange bool // always 200 with the entire content
+		badRange    bool // 206 with a content range that does not start at the requested offset
+		status      int  // (when nonzero) respond with
+		hdr         http.Header
+	}
+)
+
+func (*testJob) ID() string                            { return "job" }
+func (*testJob) Timeout() time.Duration                { return time.Minute }
+func (*testJob) throttler() *throttler                 { return &throttler{} }
+func (*testJob) Notif() core.Notif                     { return nil }
+func (j *testJob) manifest(link string) *ManifestEntry { return j.mfst[link] }
+
+func (ts *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.hdr = r.Header.Clone()
+	w.Header().Set(cos.HdrETag, ts.etag)
+	switch {
+	case ts.status != 0:
+		w.WriteHeader(ts.status)
+	case ts.ignoreRange:
+		w.Write(ts.content)
+	case ts.badRange && r.Header.Get(cos.HdrRange) != "":
+		w.Header().Set(cos.HdrContentRange, "bytes 0-0/1")
+		w.WriteHeader(http.StatusPartialContent)
+	default:
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(ts.content)) // (handles Range and If-Range)
+	}
+}
+
+var testBck = cmn.Bck{
+	Name:     "dload-bck",
+	Provider: apc.AIS,
+	Ns:       cmn.NsGlobal,
+	Props:    &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, BID: 0xa5b6e7d8},
+}
+
+func testInit(t *testing.T) {
+	fs.TestNew(mock.NewIOS())
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	if _, err := fs.Add(t.TempDir(), "daeID"); err != nil {
+		t.Fatal(err)
+	}
+	core.T = mock.NewTarget(mock.NewBaseBownerMock((*meta.Bck)(&testBck)))
+	if errs := fs.CreateBucket(&testBck, false /*nilbmd*/); len(errs) > 0 {
+		t.Fatal(errs[0])
+	}
+	driver, err := kvdb.NewBuntDB(filepath.Join(t.TempDir(), "dl.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { driver.Close() })
+	g.store = &infoStore{downloaderDB: newDownloadDB(driver), dljobs: make(map[string]*dljob)}
+}
+
+func testLom(t *testing.T, name string) *core.LOM {
+	lom := core.AllocLOM(name)
+	if err := lom.InitBck(&testBck); err != nil {
+		t.Fatal(err)
+	}
+	return lom
+}
+
+func TestDwriteResume(t *testing.T) {
+	var (
+		content = []byte(strings.Repeat("0123456789", 10))
+		changed = []byte(strings.Repeat("abcdefghij", 12))
+		ts      = &testServer{}
+		srv     = httptest.NewServer(ts)
+		dir     = t.TempDir()
+	)
+	defer srv.Close()
+	g.clientH = srv.Client()
+	testInit(t)
+
+	tests := []struct {
+		name        string
+		prefix      int    // already downloaded (workfile size)
+		validator   string // of the already downloaded content
+		etag        string
+		content     []byte
+		ignoreRange bool
+		badRange    bool
+		expect      []byte // workfile content upon _dlocal (nil when expecting error)
+		rangeReq    bool   // expecting Range request
+	}{
+		{name: "fresh", etag: `"v1"`, content: content, expect: content},
+		{name: "resume", prefix: 10, validator: `"v1"`, etag: `"v1"`, content: content, expect: content, rangeReq: true},
+		{name: "changed", prefix: 10, validator: `"v1"`, etag: `"v2"`, content: changed, expect: changed, rangeReq: true},
+		{name: "no-validator", prefix: 10, etag: `"v1"`, content: content, expect: content},
+		{name: "range-ignored", prefix: 10, validator: `"v1"`, etag: `"v1"`, content: content, ignoreRange: true, expect: content, rangeReq: true},
+		{name: "bad-range", prefix: 10, validator: `"v1"`, etag: `"v1"`, content: content, badRange: true, rangeReq: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts.content, ts.etag, ts.ignoreRange, ts.badRange = test.content, test.etag, test.ignoreRange, test.badRange
+			wfh, err := os.Create(filepath.Join(dir, test.name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer wfh.Close()
+			if _, err := wfh.Write(content[:test.prefix]); err != nil {
+				t.Fatal(err)
+			}
+			task := &singleTask{
+				job:         &testJob{},
+				obj:         dlObj{link: srv.URL + "/obj"},
+				downloadCtx: context.Background(),
+				validator:   test.validator,
+			}
+			lom := testLom(t, test.name)
+			defer core.FreeLOM(lom)
+			_, err = task._dlocal(lom, wfh, time.Minute)
+
+			if rng := ts.hdr.Get(cos.HdrRange); test.rangeReq != (rng != "") {
+				t.Fatalf("expecting range request: %t, got %q", test.rangeReq, rng)
+			} else if test.rangeReq && (rng != "bytes=10-" || ts.hdr.Get(cos.HdrIfRange) != test.validator) {
+				t.Fatalf("unexpected range %q (if-range %q)", rng, ts.hdr.Get(cos.HdrIfRange))
+			}
+			b, errR := os.ReadFile(wfh.Name())
+			if errR != nil {
+				t.Fatal(errR)
+			}
+			if test.expect == nil {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if len(b) != 0 || task.validator != "" {
+					t.Fatalf("expected workfile to be truncated, got size %d (validator %q)", len(b), task.validator)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(b, test.expect) {
+				t.Fatalf("expected %q, got %q", test.expect, b)
+			}
+			if task.validator != test.etag {
+				t.Fatalf("expected validator %q, got %q", test.etag, task.validator)
+			}
+			if rec := g.store.getPart("job", lom.Uname()); test.prefix == 0 && (rec == nil || rec.Validator != test.etag) {
+				t.Fatalf("expected persisted validator %q, got %+v", test.etag, rec)
+			}
+		})
+	}
+}
+
+func TestDputManifest(t *testing.T) {
+	var (
+		content = []byte(strings.Repeat("0123456789", 10))
+		md5sum  = md5.Sum(content)
+		sha     = sha256.Sum256(content)
+		md5hex  = hex.EncodeToString(md5sum[:])
+		shahex  = hex.EncodeToString(sha[:])
+		link    = "https://example.com/obj"
+	)
+	testInit(t)
+
+	tests := []struct {
+		name  string
+		entry *ManifestEntry
+		errs  string // expected error (substring), empty when expecting success
+	}{
+		{"none", nil, ""},
+		{"match", &ManifestEntry{Size: int64(len(content)), MD5: md5hex, SHA256: shahex}, ""},
+		{"size", &ManifestEntry{Size: int64(len(content)) + 1, MD5: md5hex}, "size mismatch"},
+		{"md5", &ManifestEntry{MD5: strings.Repeat("0", 32), SHA256: shahex}, "md5 mismatch"},
+		{"sha256", &ManifestEntry{Size: int64(len(content)), SHA256: strings.Repeat("0", 64)}, "sha256 mismatch"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lom := testLom(t, test.name)
+			defer core.FreeLOM(lom)
+			// (the mock target does not finalize - pre-create the object for the subsequent load)
+			if err := os.WriteFile(lom.FQN, content, cos.PermRWR); err != nil {
+				t.Fatal(err)
+			}
+			lom.SetSize(int64(len(content)))
+			if err := lom.PersistMain(); err != nil {
+				t.Fatal(err)
+			}
+			wfqn := fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileDownload)
+			if err := os.WriteFile(wfqn, content, cos.PermRWR); err != nil {
+				t.Fatal(err)
+			}
+			task := &singleTask{
+				job: &testJob{mfst: Manifest{link: test.entry}},
+				obj: dlObj{link: link},
+			}
+			err := task._dput(lom, wfqn)
+			switch {
+			case test.errs == "" && err != nil:
+				t.Fatal(err)
+			case test.errs != "" && (err == nil || !strings.Contains(err.Error(), test.errs)):
+				t.Fatalf("expected %q, got %v", test.errs, err)
+			}
+		})
+	}
+}
+
+// restart in the middle of downloading: the resumed job picks up the workfile
+func TestDownloadLocalResume(t *testing.T) {
+	var (
+		content = []byte(strings.Repeat("0123456789", 10))
+		ts      = &testServer{content: content, etag: `"v1"`}
+		srv     = httptest.NewServer(ts)
+	)
+	defer srv.Close()
+	g.clientH = srv.Client()
+	testInit(t)
+
+	tests := []struct {
+		name     string
+		rec      bool // validator persisted
+		notFound bool // fails
+	}{
+		{name: "resume", rec: true},
+		{name: "no-record"},
+		{name: "fail", rec: true, notFound: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lom := testLom(t, test.name)
+			defer core.FreeLOM(lom)
+			// (the mock target does not finalize - pre-create the object for the subsequent load)
+			if err := os.WriteFile(lom.FQN, content, cos.PermRWR); err != nil {
+				t.Fatal(err)
+			}
+			lom.SetSize(int64(len(content)))
+			if err := lom.PersistMain(); err != nil {
+				t.Fatal(err)
+			}
+
+			// previously downloaded (prior to restart)
+			wfqn := fs.CSM.GenKeyed(lom, fs.WorkfileDownload, "job")
+			if err := cos.CreateDir(filepath.Dir(wfqn)); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(wfqn, content[:10], cos.PermRWR); err != nil {
+				t.Fatal(err)
+			}
+			if test.rec {
+				g.store.persistPart("job", lom.Uname(), &partRecord{FQN: wfqn, Validator: ts.etag})
+			}
+			ts.hdr, ts.status = nil, 0
+			if test.notFound {
+				ts.status = http.StatusNotFound
+			}
+			task := &singleTask{
+				job:         &testJob{},
+				obj:         dlObj{link: srv.URL + "/obj"},
+				downloadCtx: context.Background(),
+			}
+			err := task.downloadLocal(lom)
+
+			if rng := ts.hdr.Get(cos.HdrRange); (rng == "bytes=10-") != test.rec {
+				t.Fatalf("expecting range request: %t, got %q", test.rec, rng)
+			}
+			rec := g.store.getPart("job", lom.Uname())
+			if test.notFound {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				// kept, to resume
+				if b, errR := os.ReadFile(wfqn); errR != nil || !bytes.Equal(b, content[:10]) {
+					t.Fatalf("expected workfile to be kept, got %q (%v)", b, errR)
+				}
+				if rec == nil {
+					t.Fatal("expected persisted validator")
+				}
+				g.store.delParts("job")
+				if _, errS := os.Stat(wfqn); !os.IsNotExist(errS) {
+					t.Fatalf("expected workfile to be removed when the job is done, got %v", errS)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// (not finalized - see above)
+			if b, errR := os.ReadFile(wfqn); errR != nil || !bytes.Equal(b, content) {
+				t.Fatalf("expected %q, got %q (%v)", content, b, errR)
+			}
+			if rec != nil {
+				t.Fatalf("expected no persisted record upon commit, got %+v", rec)
+			}
+		})
+	}
+}
@@ -117,51 +117,56 @@ func NormalizeObjName(objName string) (string, error) {
 	return url.PathUnescape(u.Path)
 }
 
-func ParseStartRequest(bck *meta.Bck, id string, dlb Body, xdl *Xact) (jobif, error) {
+func ParseStartRequest(bck *meta.Bck, id string, dlb Body, xdl *Xact) (job jobif, err error) {
 	switch dlb.Type {
 	case TypeBackend:
 		dp := &BackendBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		err = jsoniter.Unmarshal(dlb.RawMessage, dp)
 		if err != nil {
 			return nil, err
 		}
 		if err := dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newBackendDlJob(id, bck, dp, xdl)
+		job, err = newBackendDlJob(id, bck, dp, xdl)
 	case TypeMulti:
 		dp := &MultiBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		err = jsoniter.Unmarshal(dlb.RawMessage, dp)
 		if err != nil {
 			return nil, err
 		}
 		if err := dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newMultiDlJob(id, bck, dp, xdl)
+		job, err = newMultiDlJob(id, bck, dp, xdl)
 	case TypeRange:
 		dp := &RangeBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		err = jsoniter.Unmarshal(dlb.RawMessage, dp)
 		if err != nil {
 			return nil, err
 		}
 		if err := dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newRangeDlJob(id, bck, dp, xdl)
+		job, err = newRangeDlJob(id, bck, dp, xdl)
 	case TypeSingle:
 		dp := &SingleBody{}
-		err := jsoniter.Unmarshal(dlb.RawMessage, dp)
+		err = jsoniter.Unmarshal(dlb.RawMessage, dp)
 		if err != nil {
 			return nil, err
 		}
 		if err := dp.Validate(); err != nil {
 			return nil, err
 		}
-		return newSingleDlJob(id, bck, dp, xdl)
+		job, err = newSingleDlJob(id, bck, dp, xdl)
 	default:
 		return nil, errors.New("input does not match any of the supported formats (single, range, multi, backend)")
 	}
+	if err != nil {
+		return nil, err
+	}
+	job.setBody(&dlb)
+	return job, nil
 }
 
 // Given URL (link) and response header parse object attrs for GCP, S3 and Azure.
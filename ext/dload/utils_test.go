@@ -114,3 +114,29 @@ func prepareObject(t *testing.T) *core.LOM {
 	tassert.CheckFatal(t, err)
 	return lom
 }
+
+func TestManifestValidate(t *testing.T) {
+	const (
+		md5sum    = "7b01d3eacc5869db6eb9137f15335d27"
+		sha256sum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	)
+	tests := []struct {
+		entry *dload.ManifestEntry
+		valid bool
+	}{
+		{&dload.ManifestEntry{Size: 1024}, true},
+		{&dload.ManifestEntry{MD5: md5sum}, true},
+		{&dload.ManifestEntry{SHA256: sha256sum}, true},
+		{&dload.ManifestEntry{Size: 1024, MD5: md5sum, SHA256: sha256sum}, true},
+		{&dload.ManifestEntry{Size: -1}, false},
+		{&dload.ManifestEntry{MD5: sha256sum}, false},
+		{&dload.ManifestEntry{SHA256: md5sum}, false},
+		{&dload.ManifestEntry{MD5: "not-a-hex-string-of-32-chars-ok!"}, false},
+		{nil, false},
+	}
+	for _, test := range tests {
+		mfst := dload.Manifest{"https://example.com/file": test.entry}
+		err := mfst.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got err=%v", test.entry, test.valid, err)
+	}
+}
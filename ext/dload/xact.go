@@ -211,6 +211,11 @@ func (xld *Xact) Download(job jobif) (resp any, statusCode int, err error) {
 	defer xld.DecPending()
 
 	dljob := g.store.setJob(job)
+	if rec := job.record(); rec != nil {
+		if err := g.store.persistJobRecord(rec); err != nil {
+			nlog.Errorln(job.String(), "failed to persist:", err) // (won't resume upon restart)
+		}
+	}
 
 	select {
 	case xld.dispatcher.workCh <- job:
@@ -220,6 +225,7 @@ func (xld *Xact) Download(job jobif) (resp any, statusCode int, err error) {
 		case xld.dispatcher.workCh <- job:
 			return dljob.id, http.StatusOK, nil
 		case <-time.After(cmn.Rom.CplaneOperation()):
+			g.store.delJobRecord(job.ID())
 			return "downloader job queue is full", http.StatusTooManyRequests, nil
 		}
 	}
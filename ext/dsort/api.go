@@ -8,6 +8,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
 )
 
 const DefaultExt = archive.ExtTar // default shard extension/format/MIME when spec's input_extension is empty
@@ -41,6 +42,37 @@ type Algorithm struct {
 	// ditto: Content only
 	// `shard.contentKeyTypes` enum values: {"int", "string", "float" }
 	ContentKeyType string `json:"content_key_type"`
+
+	// ditto: Content only - structured (JSON, CBOR, msgpack) record members
+	// one or more keys, each selected by its path inside the member, e.g.:
+	// [{"path": "label", "type": "int"}, {"path": "meta.ts", "type": "float"}]
+	// multiple keys are compared in the specified order (compound sorting);
+	// empty key type defaults to `ContentKeyType`
+	ContentKeys []shard.ContentKey `json:"content_keys,omitempty"`
+
+	// ditto: one of {"json", "cbor", "msgpack"}; default: inferred from the `Ext`
+	ContentFormat string `json:"content_format,omitempty"`
+}
+
+// RetryPolicy applies to per-shard failures during extraction and creation
+type RetryPolicy struct {
+	// Default: 0 (no retries)
+	MaxRetries int `json:"max_retries" yaml:"max_retries"`
+	// Default: "1s" - delay before the first retry, doubled with each subsequent one
+	Backoff string `json:"backoff" yaml:"backoff"`
+}
+
+// ETLSpec: transforming records (record files), one by one, during shard creation
+type ETLSpec struct {
+	// Default: "" - ETL to transform each record file with (unless routed otherwise - see next)
+	Name string `json:"name" yaml:"name"`
+	// Default: none - record file extension (e.g. ".jpg") => ETL name, to override `Name`
+	// for the files with this extension; empty ETL name: do not transform those files
+	Routes map[string]string `json:"routes,omitempty" yaml:"routes,omitempty"`
+	// Default: "" - transformer-specific arguments (passed to all ETLs above)
+	Args string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Default: no timeout - max time to transform a single record file
+	Timeout string `json:"request_timeout,omitempty" yaml:"request_timeout,omitempty"`
 }
 
 // RequestSpec defines the user specification for requests to the endpoint /v1/sort.
@@ -74,6 +106,19 @@ type RequestSpec struct {
 	// Default: calcMaxLimit()
 	CreateConcMaxLimit int `json:"create_concurrency_max_limit" yaml:"create_concurrency_max_limit"`
 
+	// Default: false - checkpoint the progress (extracted records, sorted and distributed
+	// output shards, created shards) to be able to resume the job upon failure
+	Checkpoint bool `json:"checkpoint" yaml:"checkpoint"`
+	// Default: "" - ID of a failed (or aborted) checkpointed job to resume, skipping its
+	// completed work; requires the same spec and the same set of targets (implies Checkpoint)
+	Resume string `json:"resume,omitempty" yaml:"resume,omitempty"`
+	// Default: no retries
+	Retry RetryPolicy `json:"retry" yaml:"retry"`
+
+	// Default: none - transform records during shard creation; transformation errors
+	// are handled as per (configured) `distributed_sort.etl_error` reaction
+	ETL ETLSpec `json:"etl" yaml:"etl"`
+
 	// debug
 	DsorterType string `json:"dsorter_type"`
 	DryRun      bool   `json:"dry_run"` // Default: false
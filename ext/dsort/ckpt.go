@@ -0,0 +1,424 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ext/dsort/ct"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/OneOfOne/xxhash"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Resumable jobs
+//
+// With `checkpoint` enabled, each target persists its progress in the input bucket
+// (as dsort content type - see ct.DsortFileType):
+//   1. extracted records upon finishing the extraction phase (note that checkpointed jobs
+//      always extract to disk - see Manager.extractToDisk);
+//   2. its portion of the sorted and distributed output shards (see shardsHandler);
+//   3. names of the created output shards, one at a time.
+//
+// Resuming a job (spec's `resume`) starts a new job that adopts (renames) the checkpoint of
+// the resumed one, provided neither the spec nor the set of targets have changed.
+// Extraction is then skipped on a per-target basis, while skipping the sorting phase requires
+// all targets to have checkpointed their distributed shards, in which case the already
+// created shards are skipped as well (see PstartHandler).
+//
+// A failed (or aborted) checkpointed job keeps both its checkpoint and extracted contents
+// until resumed to completion or removed.
+
+// checkpointed phases
+const (
+	ckptNone = iota
+	ckptExtracted
+	ckptDistributed
+)
+
+const (
+	ckptPrefix     = ".ckpt."
+	ckptMetaSfx    = ".meta"
+	ckptRecordsSfx = ".records"
+	ckptShardsSfx  = ".shards"
+	ckptCreatedSfx = ".created"
+)
+
+var ckptSuffixes = [...]string{ckptMetaSfx, ckptRecordsSfx, ckptShardsSfx, ckptCreatedSfx}
+
+type (
+	ckptMeta struct {
+		Digest        string `json:"digest"` // spec and targets
+		Phase         int    `json:"phase"`
+		ShardSize     int64  `json:"shard_size,string"`
+		ExtractedSize int64  `json:"extracted_size,string"`
+	}
+
+	// resuming: target's init response and, cluster-wide, proxy's start request
+	ckptState struct {
+		Created  []string         `json:"created,omitempty"`  // output shards
+		Consumed map[string]int64 `json:"consumed,omitempty"` // tid => number of record objects in `Created`
+		Phase    int              `json:"phase"`
+	}
+
+	ckpt struct {
+		id      string // job ID
+		bck     cmn.Bck
+		digest  string
+		meta    ckptMeta
+		created struct {
+			f  *os.File
+			mu sync.Mutex
+		}
+	}
+)
+
+func newCkpt(id string, pars *parsedReqSpec, smap *meta.Smap) *ckpt {
+	c := &ckpt{id: id, bck: pars.InputBck}
+	if smap != nil {
+		c.digest = _ckptDigest(pars, smap)
+	}
+	return c
+}
+
+// everything that determines extracted records and output shards
+func _ckptDigest(pars *parsedReqSpec, smap *meta.Smap) string {
+	var (
+		spec RequestSpec
+		tids = make([]string, 0, len(smap.Tmap))
+	)
+	if pars.Spec != nil {
+		spec = *pars.Spec
+	}
+	spec.Description, spec.Resume, spec.Retry = "", "", RetryPolicy{}
+	for tid, tsi := range smap.Tmap {
+		if !smap.InMaintOrDecomm(tsi) {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Strings(tids)
+
+	h := xxhash.NewS64(cos.MLCG32)
+	h.Write(cos.MustMarshal(&spec))
+	h.WriteString(pars.DsorterType)
+	h.WriteString(strings.Join(tids, ","))
+	return strconv.FormatUint(h.Sum64(), 36)
+}
+
+// all checkpoint files of a given bucket share the same mountpath (which is what makes `adopt` possible)
+func (c *ckpt) fqn(id, sfx string) string {
+	lct, err := core.NewCTFromBO(&c.bck, ckptPrefix, nil)
+	if err != nil {
+		nlog.Errorln(err)
+		return ""
+	}
+	return lct.Mountpath().MakePathFQN(&c.bck, ct.DsortFileType, ckptPrefix+id+sfx)
+}
+
+// take over the checkpoint of the job that's being resumed
+func (c *ckpt) adopt(resumeID string) {
+	var md ckptMeta
+	if _, err := jsp.Load(c.fqn(resumeID, ckptMetaSfx), &md, jsp.Plain()); err != nil {
+		if !os.IsNotExist(err) {
+			nlog.Warningf("%s: [dsort] %s failed to load %s checkpoint: %v", core.T, c.id, resumeID, err)
+		}
+		return
+	}
+	if md.Digest != c.digest {
+		nlog.Warningf("%s: [dsort] %s cannot resume %s: job specification and/or cluster map have changed",
+			core.T, c.id, resumeID)
+		return
+	}
+	for _, sfx := range ckptSuffixes {
+		if err := os.Rename(c.fqn(resumeID, sfx), c.fqn(c.id, sfx)); err != nil && !os.IsNotExist(err) {
+			nlog.Warningf("%s: [dsort] %s failed to adopt %s checkpoint: %v", core.T, c.id, resumeID, err)
+			return
+		}
+	}
+	c.meta = md
+	nlog.Infof("%s: [dsort] %s resuming %s, checkpointed phase %d", core.T, c.id, resumeID, md.Phase)
+}
+
+// (resuming) report local state to the proxy
+func (c *ckpt) state() *ckptState {
+	cs := &ckptState{Phase: c.meta.Phase}
+	if cs.Phase < ckptDistributed {
+		return cs
+	}
+	md, err := c.loadDistributed()
+	if err != nil {
+		nlog.Warningf("%s: [dsort] %s failed to load distributed shards: %v", core.T, c.id, err)
+		c.meta.Phase = ckptExtracted
+		cs.Phase = ckptExtracted
+		return cs
+	}
+	created := c.loadCreated()
+	cs.Consumed = make(map[string]int64, 4)
+	for _, s := range md.Shards {
+		if _, ok := created[s.Name]; !ok {
+			continue
+		}
+		cs.Created = append(cs.Created, s.Name)
+		for _, rec := range s.Records.All() {
+			cs.Consumed[rec.DaemonID] += int64(len(rec.Objects))
+		}
+	}
+	return cs
+}
+
+func (c *ckpt) saveMeta(phase int) error {
+	c.meta.Digest, c.meta.Phase = c.digest, phase
+	return jsp.Save(c.fqn(c.id, ckptMetaSfx), &c.meta, jsp.Plain(), nil)
+}
+
+func (c *ckpt) saveExtracted(records *shard.Records, shardSize, extractedSize int64) error {
+	if err := c.saveMsg(ckptRecordsSfx, records); err != nil {
+		return err
+	}
+	c.meta.ShardSize, c.meta.ExtractedSize = shardSize, extractedSize
+	return c.saveMeta(ckptExtracted)
+}
+
+// (re)distributed shards invalidate previously created ones, if any
+func (c *ckpt) saveDistributed(md *CreationPhaseMetadata) error {
+	c.closeCreated()
+	if err := cos.RemoveFile(c.fqn(c.id, ckptCreatedSfx)); err != nil {
+		return err
+	}
+	if err := c.saveMsg(ckptShardsSfx, md); err != nil {
+		return err
+	}
+	return c.saveMeta(ckptDistributed)
+}
+
+func (c *ckpt) saveMsg(sfx string, v msgp.Encodable) error {
+	var (
+		fqn       = c.fqn(c.id, sfx)
+		tmp       = fqn + ".tmp"
+		buf, slab = g.mm.AllocSize(serializationBufSize)
+	)
+	defer slab.Free(buf)
+	f, err := cos.CreateFile(tmp)
+	if err != nil {
+		return err
+	}
+	w := msgp.NewWriterBuf(f, buf)
+	if err = v.EncodeMsg(w); err == nil {
+		err = w.Flush()
+	}
+	if errC := f.Close(); err == nil {
+		err = errC
+	}
+	if err == nil {
+		err = os.Rename(tmp, fqn)
+	}
+	if err != nil {
+		_ = cos.RemoveFile(tmp)
+	}
+	return err
+}
+
+func (c *ckpt) loadMsg(sfx string, v msgp.Decodable) error {
+	f, err := os.Open(c.fqn(c.id, sfx))
+	if err != nil {
+		return err
+	}
+	buf, slab := g.mm.AllocSize(serializationBufSize)
+	err = v.DecodeMsg(msgp.NewReaderBuf(f, buf))
+	slab.Free(buf)
+	cos.Close(f)
+	return err
+}
+
+func (c *ckpt) loadRecords() (*shard.Records, error) {
+	records := shard.NewRecords(0)
+	return records, c.loadMsg(ckptRecordsSfx, records)
+}
+
+func (c *ckpt) loadDistributed() (*CreationPhaseMetadata, error) {
+	md := &CreationPhaseMetadata{}
+	return md, c.loadMsg(ckptShardsSfx, md)
+}
+
+func (c *ckpt) addCreated(shardName string) (err error) {
+	c.created.mu.Lock()
+	if c.created.f == nil {
+		c.created.f, err = os.OpenFile(c.fqn(c.id, ckptCreatedSfx), os.O_CREATE|os.O_APPEND|os.O_WRONLY, cos.PermRWR)
+	}
+	if err == nil {
+		_, err = c.created.f.WriteString(shardName + "\n")
+	}
+	c.created.mu.Unlock()
+	return err
+}
+
+// (a torn last line, if any, is ignored)
+func (c *ckpt) loadCreated() map[string]struct{} {
+	b, err := os.ReadFile(c.fqn(c.id, ckptCreatedSfx))
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(string(b), "\n")
+	created := make(map[string]struct{}, len(lines))
+	for _, name := range lines[:len(lines)-1] {
+		created[name] = struct{}{}
+	}
+	return created
+}
+
+func (c *ckpt) closeCreated() {
+	c.created.mu.Lock()
+	if c.created.f != nil {
+		cos.Close(c.created.f)
+		c.created.f = nil
+	}
+	c.created.mu.Unlock()
+}
+
+func (c *ckpt) remove() {
+	c.closeCreated()
+	for _, sfx := range ckptSuffixes {
+		if err := cos.RemoveFile(c.fqn(c.id, sfx)); err != nil {
+			nlog.Errorln(err)
+		}
+	}
+}
+
+// remove checkpoint and extracted contents of a finished (archived) job
+func removeCkpt(id string, pars *parsedReqSpec) {
+	c := newCkpt(id, pars, nil)
+	if records, err := c.loadRecords(); err == nil {
+		recm := shard.NewRecordManager(pars.InputBck, nil, nil, nil, nil)
+		recm.Restore(records)
+		recm.Cleanup(false /*keep files*/)
+	} else if !os.IsNotExist(err) {
+		nlog.Errorln(err)
+	}
+	c.remove()
+}
+
+/////////////
+// Manager //
+/////////////
+
+// in lieu of phase 1 (extraction)
+func (m *Manager) restoreExtracted() error {
+	metrics := m.Metrics.Extraction
+	metrics.begin()
+	defer func() {
+		m.dsorter.postExtraction()
+		metrics.finish()
+	}()
+
+	records, err := m.ckpt.loadRecords()
+	if err != nil {
+		return err
+	}
+	m.recm.Restore(records)
+	m.compression.totalShardSize.Store(m.ckpt.meta.ShardSize)
+	m.compression.totalExtractedSize.Store(m.ckpt.meta.ExtractedSize)
+	m.incrementRef(int64(m.recm.Records.TotalObjectCount()))
+
+	metrics.mu.Lock()
+	metrics.ExtractedRecordCnt = int64(m.recm.Records.Len())
+	metrics.ExtractedSize = m.ckpt.meta.ExtractedSize
+	metrics.mu.Unlock()
+
+	nlog.Infof("%s: %s restored %d extracted records", core.T, m.ManagerUUID, m.recm.Records.Len())
+
+	// not all targets have distributed shards - sorting (and distribution) will be redone
+	if m.resume.Phase < ckptDistributed && m.ckpt.meta.Phase > ckptExtracted {
+		return m.ckpt.saveMeta(ckptExtracted)
+	}
+	return nil
+}
+
+// in lieu of phases 2 and 3 (sorting and distribution)
+func (m *Manager) restoreDistributed() error {
+	md, err := m.ckpt.loadDistributed()
+	if err != nil {
+		return err
+	}
+	created := make(map[string]struct{}, len(m.resume.Created))
+	for _, name := range m.resume.Created {
+		created[name] = struct{}{}
+	}
+	shards := md.Shards[:0]
+	for _, s := range md.Shards {
+		if _, ok := created[s.Name]; !ok {
+			shards = append(shards, s)
+		}
+	}
+	md.Shards = shards
+	for name := range md.SendOrder {
+		if _, ok := created[name]; ok {
+			delete(md.SendOrder, name)
+		}
+	}
+	m.creationPhase.metadata = *md
+
+	m.Metrics.Sorting.begin()
+	m.dsorter.postRecordDistribution()
+	m.Metrics.Sorting.finish()
+
+	// records of the already created shards won't be requested
+	m.decrementRef(m.resume.Consumed[core.T.SID()])
+	m.recm.Records.Drain()
+
+	nlog.Infof("%s: %s skipping %d created shards, %d to create", core.T, m.ManagerUUID,
+		len(m.resume.Created), len(md.Shards))
+	return nil
+}
+
+func (m *Manager) ckptExtracted() {
+	err := m.ckpt.saveExtracted(m.recm.Records, m.totalShardSize(), m.totalExtractedSize())
+	if err != nil {
+		nlog.Warningf("%s: [dsort] %s failed to checkpoint extracted records: %v", core.T, m.ManagerUUID, err)
+	}
+}
+
+func (m *Manager) ckptDistributed(md *CreationPhaseMetadata) {
+	if err := m.ckpt.saveDistributed(md); err != nil {
+		nlog.Warningf("%s: [dsort] %s failed to checkpoint distributed shards: %v", core.T, m.ManagerUUID, err)
+	}
+}
+
+func (m *Manager) ckptCreated(shardName string) {
+	if err := m.ckpt.addCreated(shardName); err != nil {
+		nlog.Warningf("%s: [dsort] %s failed to checkpoint %s: %v", core.T, m.ManagerUUID, shardName, err)
+	}
+}
+
+// aggregate targets' states (see tinitHandler): the cluster-wide phase is the minimum
+// of the locally checkpointed ones
+func resumeState(responses []response) *ckptState {
+	rs := &ckptState{Phase: ckptDistributed, Consumed: make(map[string]int64, len(responses))}
+	for _, resp := range responses {
+		var cs ckptState
+		if err := js.Unmarshal(resp.res, &cs); err != nil {
+			nlog.Warningln("[dsort] invalid checkpoint state from", resp.si.StringEx()+":", err)
+			cs.Phase = ckptNone
+		}
+		rs.Phase = min(rs.Phase, cs.Phase)
+		rs.Created = append(rs.Created, cs.Created...)
+		for tid, n := range cs.Consumed {
+			rs.Consumed[tid] += n
+		}
+	}
+	if rs.Phase < ckptDistributed {
+		rs.Created, rs.Consumed = nil, nil
+	}
+	return rs
+}
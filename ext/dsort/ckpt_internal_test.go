@@ -0,0 +1,181 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ext/dsort/ct"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		pars = &parsedReqSpec{
+			InputBck:    cmn.Bck{Name: "ckpt", Provider: apc.AIS, Ns: cmn.NsGlobal},
+			Spec:        &RequestSpec{OutputShardSize: "10KB"},
+			DsorterType: GeneralType,
+		}
+		smap = newTestSmap("t1", "t2")
+	)
+
+	BeforeEach(func() {
+		err := cos.CreateDir(testingConfigDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		fs.TestNew(nil)
+		_, err = fs.Add(testingConfigDir, "daeID")
+		Expect(err).ShouldNot(HaveOccurred())
+		fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+		fs.CSM.Reg(ct.DsortFileType, &ct.DsortFile{}, true)
+		if g.mm == nil {
+			g.mm = memsys.PageMM()
+		}
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(testingConfigDir)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should save and load extracted records", func() {
+		records := shard.NewRecords(2)
+		records.Insert(
+			&shard.Record{Key: "a", Name: "a", DaemonID: "t1", Objects: []*shard.RecordObj{
+				{ContentPath: "a.dsort", StoreType: shard.DiskStoreType, Size: 10, MetadataSize: 512, Extension: ".jpg"},
+				{ContentPath: "a.dsort", StoreType: shard.DiskStoreType, Size: 3, MetadataSize: 512, Extension: ".cls"},
+			}},
+			&shard.Record{Key: "b", Name: "b", DaemonID: "t1", Objects: []*shard.RecordObj{
+				{ContentPath: "shard-1.tar", StoreType: shard.OffsetStoreType, Offset: 1024, Size: 7, MetadataSize: 512, Extension: ".jpg"},
+			}},
+		)
+		c := newCkpt("job1", pars, smap)
+		Expect(c.saveExtracted(records, 100, 200)).NotTo(HaveOccurred())
+
+		loaded, err := newCkpt("job1", pars, smap).loadRecords()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Len()).To(Equal(2))
+		for i, rec := range loaded.All() {
+			exp := records.All()[i]
+			Expect(rec.Name).To(Equal(exp.Name))
+			Expect(rec.Key).To(Equal(exp.Key))
+			Expect(rec.DaemonID).To(Equal(exp.DaemonID))
+			Expect(rec.Objects).To(HaveLen(len(exp.Objects)))
+			for j, obj := range rec.Objects {
+				Expect(*obj).To(Equal(*exp.Objects[j]))
+			}
+		}
+		recm := shard.NewRecordManager(pars.InputBck, nil, nil, nil, nil)
+		recm.Restore(loaded)
+		Expect(recm.Records.TotalObjectCount()).To(Equal(3))
+
+		// resuming job adopts the checkpoint (and its meta)
+		c2 := newCkpt("job2", pars, smap)
+		c2.adopt("job1")
+		Expect(c2.meta.Phase).To(Equal(ckptExtracted))
+		Expect(c2.meta.ShardSize).To(Equal(int64(100)))
+		Expect(c2.meta.ExtractedSize).To(Equal(int64(200)))
+		loaded, err = c2.loadRecords()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Len()).To(Equal(2))
+		Expect(c2.fqn("job1", ckptRecordsSfx)).NotTo(BeAnExistingFile())
+	})
+
+	It("should not adopt checkpoint upon digest mismatch", func() {
+		c := newCkpt("job1", pars, smap)
+		Expect(c.saveExtracted(shard.NewRecords(0), 1, 1)).NotTo(HaveOccurred())
+
+		for _, other := range []*ckpt{
+			newCkpt("job2", pars, newTestSmap("t1", "t2", "t3")), // targets
+			newCkpt("job2", &parsedReqSpec{ // spec
+				InputBck:    pars.InputBck,
+				Spec:        &RequestSpec{OutputShardSize: "20KB"},
+				DsorterType: pars.DsorterType,
+			}, smap),
+		} {
+			Expect(other.digest).NotTo(Equal(c.digest))
+			other.adopt("job1")
+			Expect(other.meta.Phase).To(Equal(ckptNone))
+			Expect(other.fqn("job2", ckptMetaSfx)).NotTo(BeAnExistingFile())
+			Expect(c.fqn("job1", ckptMetaSfx)).To(BeAnExistingFile())
+			Expect(c.fqn("job1", ckptRecordsSfx)).To(BeAnExistingFile())
+		}
+
+		// (description, resume, and retry policy don't matter)
+		spec := *pars.Spec
+		spec.Description, spec.Resume, spec.Retry = "resumed", "job1", RetryPolicy{MaxRetries: 3}
+		same := newCkpt("job2", &parsedReqSpec{InputBck: pars.InputBck, Spec: &spec, DsorterType: pars.DsorterType}, smap)
+		Expect(same.digest).To(Equal(c.digest))
+	})
+
+	It("should ignore torn last line of created shards", func() {
+		c := newCkpt("job1", pars, smap)
+		Expect(c.saveDistributed(&CreationPhaseMetadata{})).NotTo(HaveOccurred())
+		Expect(c.loadCreated()).To(BeEmpty())
+		Expect(c.addCreated("shard-1.tar")).NotTo(HaveOccurred())
+		Expect(c.addCreated("shard-2.tar")).NotTo(HaveOccurred())
+		c.closeCreated()
+
+		f, err := os.OpenFile(c.fqn("job1", ckptCreatedSfx), os.O_APPEND|os.O_WRONLY, cos.PermRWR)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.WriteString("shard-3.t")
+		Expect(err).NotTo(HaveOccurred())
+		f.Close()
+
+		created := c.loadCreated()
+		Expect(created).To(HaveLen(2))
+		Expect(created).To(HaveKey("shard-1.tar"))
+		Expect(created).To(HaveKey("shard-2.tar"))
+	})
+
+	It("should resume from the cluster-wide minimum phase", func() {
+		resp := func(tid string, cs *ckptState) response {
+			return response{si: &meta.Snode{DaeID: tid}, res: cos.MustMarshal(cs)}
+		}
+		responses := []response{
+			resp("t1", &ckptState{Phase: ckptDistributed, Created: []string{"s1"}, Consumed: map[string]int64{"t1": 3}}),
+			resp("t2", &ckptState{Phase: ckptDistributed, Created: []string{"s2"}, Consumed: map[string]int64{"t1": 1, "t2": 2}}),
+		}
+		rs := resumeState(responses)
+		Expect(rs.Phase).To(Equal(ckptDistributed))
+		Expect(rs.Created).To(ConsistOf("s1", "s2"))
+		Expect(rs.Consumed).To(Equal(map[string]int64{"t1": 4, "t2": 2}))
+
+		rs = resumeState(append(responses, resp("t3", &ckptState{Phase: ckptExtracted})))
+		Expect(rs.Phase).To(Equal(ckptExtracted))
+		Expect(rs.Created).To(BeNil())
+		Expect(rs.Consumed).To(BeNil())
+
+		rs = resumeState(append(responses, response{si: &meta.Snode{DaeID: "t3"}, res: []byte("invalid")}))
+		Expect(rs.Phase).To(Equal(ckptNone))
+	})
+
+	It("should ref-count retried loads once", func() {
+		m := &Manager{Pars: &parsedReqSpec{MaxRetries: 2}}
+		m.state.inProgress.Store(true)
+		m.incrementRef(2)
+		for range 3 { // failed attempts and a retry
+			m.decrementRefOnce("a.jpg")
+		}
+		Expect(m.refCount.Load()).To(Equal(int64(1)))
+		m.decrementRefOnce("b.jpg")
+		Expect(m.refCount.Load()).To(Equal(int64(0)))
+	})
+})
+
+func newTestSmap(tids ...string) *meta.Smap {
+	smap := &meta.Smap{Tmap: make(meta.NodeMap, len(tids))}
+	for _, tid := range tids {
+		smap.Tmap[tid] = &meta.Snode{DaeID: tid, DaeType: apc.Target}
+	}
+	return smap
+}
@@ -16,6 +16,7 @@ const (
 
 	WorkfileRecvShard   = "recv-shard"
 	WorkfileCreateShard = "create-shard"
+	WorkfileStageShard  = "stage-shard"
 )
 
 // interface guard
@@ -85,11 +85,40 @@ func (m *Manager) start() (err error) {
 	}
 
 	// Phase 1.
-	nlog.Infof("%s: %s started extraction stage", core.T, m.ManagerUUID)
-	if err := m.extractLocalShards(); err != nil {
+	if m.ckpt != nil && m.ckpt.meta.Phase >= ckptExtracted {
+		nlog.Infof("%s: %s skipping extraction stage (checkpointed)", core.T, m.ManagerUUID)
+		err = m.restoreExtracted()
+	} else {
+		nlog.Infof("%s: %s started extraction stage", core.T, m.ManagerUUID)
+		err = m.extractLocalShards()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Phases 2 and 3.
+	if m.resume.Phase >= ckptDistributed {
+		nlog.Infof("%s: %s skipping sort stage (checkpointed)", core.T, m.ManagerUUID)
+		err = m.restoreDistributed()
+	} else {
+		err = m.sortAndDistribute()
+	}
+	if err != nil {
 		return err
 	}
 
+	// After each target participates in the cluster-wide record distribution,
+	// start listening for the signal to start creating shards locally.
+	nlog.Infof("%s: %s started creation stage", core.T, m.ManagerUUID)
+	if err := m.dsorter.createShardsLocally(); err != nil {
+		return err
+	}
+
+	nlog.Infof("%s: %s finished successfully", core.T, m.ManagerUUID)
+	return nil
+}
+
+func (m *Manager) sortAndDistribute() error {
 	s := binary.BigEndian.Uint64(m.Pars.TargetOrderSalt)
 	targetOrder := _torder(s, m.smap.Tmap)
 	if cmn.Rom.FastV(4, cos.SmoduleDsort) {
@@ -127,20 +156,10 @@ func (m *Manager) start() (err error) {
 	// notice that the specification for shards to be created locally was received.
 	select {
 	case <-m.startShardCreation:
-		break
+		return nil
 	case <-m.listenAborted():
 		return m.newErrAborted()
 	}
-
-	// After each target participates in the cluster-wide record distribution,
-	// start listening for the signal to start creating shards locally.
-	nlog.Infof("%s: %s started creation stage", core.T, m.ManagerUUID)
-	if err := m.dsorter.createShardsLocally(); err != nil {
-		return err
-	}
-
-	nlog.Infof("%s: %s finished successfully", core.T, m.ManagerUUID)
-	return nil
 }
 
 // returns a slice of targets in a pseudorandom order
@@ -196,6 +215,9 @@ func (m *Manager) extractLocalShards() (err error) {
 	m.extractionPhase.adjuster.stop()
 	if err == nil {
 		m.incrementRef(int64(m.recm.Records.TotalObjectCount()))
+		if m.ckpt != nil {
+			m.ckptExtracted()
+		}
 	}
 	return
 }
@@ -276,6 +298,17 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 		return
 	}
 
+	// when transforming: stage (load and transform) all record files prior to creating the shard
+	var loader shard.ContentLoader = m.dsorter
+	if m.xform != nil {
+		xs, xl, err := m.xform.stage(s, lom, loader)
+		if err != nil {
+			return err
+		}
+		defer xl.free()
+		s, loader = xs, xl
+	}
+
 	beforeCreation := time.Now()
 
 	var (
@@ -321,7 +354,7 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 		debug.Assert(shardRW != nil, m.Pars.OutputExtension)
 	}
 
-	_, err = shardRW.Create(s, w, m.dsorter)
+	_, err = shardRW.Create(s, w, loader)
 	w.CloseWithError(err)
 	if err != nil {
 		r.CloseWithError(err)
@@ -400,6 +433,9 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 	}
 
 exit:
+	if m.ckpt != nil {
+		m.ckptCreated(shardName)
+	}
 	metrics.mu.Lock()
 	metrics.CreatedCnt++
 	if si.ID() != core.T.SID() {
@@ -898,11 +934,16 @@ func (es *extractShard) do() (err error) {
 			shardName = es.name + m.Pars.InputExtension
 		}
 	}
-	lom := core.AllocLOM(shardName)
-
-	err = es._do(lom)
+	err = m.retry(shardName, func() error {
+		lom := core.AllocLOM(shardName)
+		err := es._do(lom)
+		core.FreeLOM(lom)
+		if err != nil && m.Pars.MaxRetries > 0 {
+			m.recm.Records.DeleteShard(shardName) // partially extracted, if any
+		}
+		return err
+	})
 
-	core.FreeLOM(lom)
 	phaseInfo := &m.extractionPhase
 	phaseInfo.adjuster.releaseGoroutineSema()
 	return
@@ -963,12 +1004,13 @@ func (es *extractShard) _do(lom *core.LOM) error {
 
 	expectedExtractedSize := uint64(float64(lom.Lsize()) / m.compressionRatio())
 	toDisk := m.dsorter.preShardExtraction(expectedExtractedSize)
+	if m.extractToDisk() {
+		toDisk = true
+	}
 
 	extractedSize, extractedCount, err := shardRW.Extract(lom, fh, m.recm, toDisk)
 	cos.Close(fh)
 
-	m.addSizes(lom.Lsize(), extractedSize) // update compression rate
-
 	phaseInfo.adjuster.releaseSema(lom.Mountpath())
 	lom.Unlock(false)
 
@@ -977,6 +1019,8 @@ func (es *extractShard) _do(lom *core.LOM) error {
 		return errors.Errorf("failed to extract shard %s: %v", lom.Cname(), err)
 	}
 
+	m.addSizes(lom.Lsize(), extractedSize) // update compression rate
+
 	if toDisk {
 		g.tstats.Add(stats.DsortExtractShardDskCnt, 1)
 	} else {
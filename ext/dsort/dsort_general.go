@@ -271,10 +271,10 @@ func (ds *dsorterGeneral) Load(w io.Writer, rec *shard.Record, obj *shard.Record
 	if rec.DaemonID != core.T.SID() {
 		return ds.loadRemote(w, rec, obj)
 	}
-	return ds.loadLocal(w, obj)
+	return ds.loadLocal(w, rec, obj)
 }
 
-func (ds *dsorterGeneral) loadLocal(w io.Writer, obj *shard.RecordObj) (written int64, err error) {
+func (ds *dsorterGeneral) loadLocal(w io.Writer, rec *shard.Record, obj *shard.RecordObj) (written int64, err error) {
 	var (
 		slab      *memsys.Slab
 		buf       []byte
@@ -289,7 +289,7 @@ func (ds *dsorterGeneral) loadLocal(w io.Writer, obj *shard.RecordObj) (written
 		if storeType != shard.SGLStoreType {
 			slab.Free(buf)
 		}
-		ds.m.decrementRef(1)
+		ds.m.decrementRefOnce(rec.MakeUniqueName(obj))
 	}()
 
 	fullContentPath := ds.m.recm.FullContentPath(obj)
@@ -517,7 +517,7 @@ func (ds *dsorterGeneral) responseCallback(hdr *transport.ObjHdr, rc io.ReadClos
 	if sgl, ok := rc.(*memsys.SGL); ok {
 		sgl.Free()
 	}
-	ds.m.decrementRef(1)
+	ds.m.decrementRefOnce(hdr.ObjName) // (see recvReq)
 	if err != nil {
 		nlog.Errorf("%s: [dsort] %s failed to send rsp %s (size %d): %v - aborting...",
 			core.T, ds.m.ManagerUUID, hdr.ObjName, hdr.ObjAttrs.Size, err)
@@ -585,10 +585,17 @@ type dsgCreateShard struct {
 	shard *shard.Shard
 }
 
+// NOTE: retrying is possible because records are extracted to disk (see extractToDisk);
+// records loaded (locally or remotely) by a failed attempt are loaded again, and are
+// therefore ref-counted only once (see decrementRefOnce)
 func (cs *dsgCreateShard) do() (err error) {
-	lom := core.AllocLOM(cs.shard.Name)
-	err = cs.ds.m.createShard(cs.shard, lom)
-	core.FreeLOM(lom)
+	m := cs.ds.m
+	err = m.retry(cs.shard.Name, func() error {
+		lom := core.AllocLOM(cs.shard.Name)
+		err := m.createShard(cs.shard, lom)
+		core.FreeLOM(lom)
+		return err
+	})
 	cs.ds.creationPhase.adjuster.releaseGoroutineSema()
 	return
 }
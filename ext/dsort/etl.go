@@ -0,0 +1,279 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/ext/dsort/ct"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/ext/etl"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Transforming records during shard creation (RequestSpec.ETL)
+// - before being created, each output shard gets staged: its record files are loaded
+//   (the same way shard.RW.Create would load them) and pushed through the corresponding ETL;
+// - the shard is then created from the staged content, with the record sizes (and, therefore,
+//   format-specific headers - see shard.RW.Resize) reflecting the transformed sizes;
+// - staging reserves memory the same way shard extraction does (see dsorter.preShardExtraction);
+//   when the reservation exceeds `max_mem_usage` (or when the dsorter itself needs the memory),
+//   the shard is staged on disk - in a single work file on the shard's mountpath;
+// - the shard's creation metadata remains unmodified, so that failed creation can be retried;
+// - per-record transformation errors are handled as per `distributed_sort.etl_error` reaction,
+//   whereby "ignore" and "warn" keep the original (untransformed) record file.
+
+type (
+	xformer struct {
+		m      *Manager
+		dflt   etl.Pipeline            // nil: no default ETL
+		routes map[string]etl.Pipeline // by record file extension; nil: keep as is
+	}
+	// staged record files, transformed or otherwise
+	xformLoader struct {
+		m        *Manager
+		objs     map[*shard.RecordObj]*xformObj
+		wfh      *os.File // staging on disk; nil when in memory
+		wfqn     string
+		woff     int64  // work file: next offset
+		reserved uint64 // memory reserved via dsorter (to unreserve when done)
+	}
+	xformObj struct {
+		sgl  *memsys.SGL // in-memory content; nil when staged on disk
+		off  int64       // work file section (when staged on disk)
+		size int64
+		md   []byte // (new) metadata, if transformed; otherwise, metadata is included in the content
+	}
+)
+
+// interface guard
+var _ shard.ContentLoader = (*xformLoader)(nil)
+
+/////////////
+// xformer //
+/////////////
+
+func newXformer(m *Manager) (x *xformer, err error) {
+	pe := m.Pars.ETL
+	x = &xformer{m: m, routes: make(map[string]etl.Pipeline, len(pe.Routes))}
+	if pe.Name != "" {
+		if x.dflt, err = x._pipeline(pe.Name); err != nil {
+			return nil, err
+		}
+	}
+	for ext, name := range pe.Routes {
+		var pl etl.Pipeline
+		if name != "" {
+			if pl, err = x._pipeline(name); err != nil {
+				return nil, err
+			}
+		}
+		x.routes[ext] = pl
+	}
+	return x, nil
+}
+
+func (x *xformer) _pipeline(name string) (etl.Pipeline, error) {
+	pe := x.m.Pars.ETL
+	return etl.NewPipeline([]apc.ETLStage{{Name: name, Args: pe.Args, Timeout: pe.Timeout}})
+}
+
+func (x *xformer) pipeline(ext string) etl.Pipeline {
+	if pl, ok := x.routes[ext]; ok {
+		return pl
+	}
+	return x.dflt
+}
+
+// returns the shard to create (with transformed sizes) and the loader to create it with
+func (x *xformer) stage(s *shard.Shard, lom *core.LOM, loader shard.ContentLoader) (*shard.Shard, *xformLoader, error) {
+	var (
+		recs = s.Records.All()
+		xs   = &shard.Shard{Name: s.Name, Size: s.Size, Records: shard.NewRecords(len(recs))}
+		xl   = &xformLoader{m: x.m, objs: make(map[*shard.RecordObj]*xformObj, len(recs))}
+	)
+	// both original and transformed record files (the latter of unknown size) may be staged at
+	// the same time - hence, the (rough) estimate
+	xl.reserved = uint64(2 * s.Size)
+	if toDisk := x.m.dsorter.preShardExtraction(xl.reserved); toDisk {
+		if err := xl.create(fs.CSM.Gen(lom, ct.DsortWorkfileType, ct.WorkfileStageShard)); err != nil {
+			xl.free()
+			return nil, nil, err
+		}
+	}
+
+	for _, rec := range recs {
+		xrec := *rec
+		xrec.Objects = make([]*shard.RecordObj, 0, len(rec.Objects))
+		for _, obj := range rec.Objects {
+			xobj := *obj
+			xo, err := x.do(s.Name, rec, &xobj, loader, xl)
+			if err != nil {
+				xl.free()
+				return nil, nil, err
+			}
+			xl.objs[&xobj] = xo
+			xrec.Objects = append(xrec.Objects, &xobj)
+			xs.Size += xobj.Size - obj.Size
+		}
+		xs.Records.Insert(&xrec)
+	}
+	return xs, xl, nil
+}
+
+// load and transform a single record file; upon success, update its (copied) size and metadata size
+func (x *xformer) do(shardName string, rec *shard.Record, obj *shard.RecordObj, loader shard.ContentLoader, xl *xformLoader) (*xformObj, error) {
+	xo, err := xl.stage(obj.MetadataSize+obj.Size, func(w io.Writer) (int64, error) { return loader.Load(w, rec, obj) })
+	if err != nil {
+		return nil, err
+	}
+	pl := x.pipeline(obj.Extension)
+	if pl == nil {
+		return xo, nil
+	}
+
+	xt, md, err := x.transform(pl, rec, obj, xl, xo)
+	if err == nil {
+		xo.free()
+		xt.md = md
+		obj.Size, obj.MetadataSize = xt.size, int64(len(md))
+		return xt, nil
+	}
+
+	msg := fmt.Sprintf("failed to transform %q (output shard %q): %v", rec.MakeUniqueName(obj), shardName, err)
+	if err := x.m.react(x.m.Pars.ETLError, msg); err != nil {
+		xo.free()
+		return nil, err
+	}
+	return xo, nil // keep the original
+}
+
+func (x *xformer) transform(pl etl.Pipeline, rec *shard.Record, obj *shard.RecordObj, xl *xformLoader, xo *xformObj) (*xformObj, []byte, error) {
+	var (
+		md = make([]byte, obj.MetadataSize)
+		rd = xl.reader(xo) // (leaving the original intact)
+	)
+	if _, err := io.ReadFull(rd, md); err != nil {
+		return nil, nil, err
+	}
+
+	// the record file is not an object - the name is only used to name the transformation request
+	lom := core.AllocLOM(rec.MakeUniqueName(obj))
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(&x.m.Pars.OutputBck); err != nil {
+		return nil, nil, err
+	}
+
+	in := cos.NewReaderWithArgs(cos.ReaderArgs{R: rd, Size: obj.Size})
+	r, failed, err := pl.Push(in, lom)
+	if err != nil {
+		return nil, nil, pl.Err(failed, err)
+	}
+	xt, err := xl.stage(max(r.Size(), 0), func(w io.Writer) (int64, error) { return io.Copy(w, r) })
+	r.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	if md, err = x.m.shardRW.Resize(obj, md, xt.size); err != nil {
+		xt.free()
+		return nil, nil, err
+	}
+	return xt, md, nil
+}
+
+/////////////////
+// xformLoader //
+/////////////////
+
+// create work file to stage on disk (NOTE: staged content is written and read back)
+func (xl *xformLoader) create(fqn string) (err error) {
+	if err = cos.CreateDir(filepath.Dir(fqn)); err != nil {
+		return err
+	}
+	xl.wfh, err = os.OpenFile(fqn, os.O_RDWR|os.O_CREATE|os.O_TRUNC, cos.PermRWR)
+	if err == nil {
+		xl.wfqn = fqn
+	}
+	return err
+}
+
+// stage content (in memory or on disk), as written by the callback
+func (xl *xformLoader) stage(size int64, write func(w io.Writer) (int64, error)) (*xformObj, error) {
+	if xl.wfh == nil {
+		sgl := g.mm.NewSGL(size)
+		if _, err := write(sgl); err != nil {
+			sgl.Free()
+			return nil, err
+		}
+		return &xformObj{sgl: sgl, size: sgl.Size()}, nil
+	}
+	off := xl.woff
+	n, err := write(xl.wfh)
+	xl.woff += n // (on error, the section remains unused)
+	if err != nil {
+		return nil, err
+	}
+	return &xformObj{off: off, size: n}, nil
+}
+
+func (xl *xformLoader) reader(xo *xformObj) io.Reader {
+	if xo.sgl != nil {
+		return memsys.NewReader(xo.sgl)
+	}
+	return io.NewSectionReader(xl.wfh, xo.off, xo.size)
+}
+
+func (xl *xformLoader) Load(w io.Writer, _ *shard.Record, obj *shard.RecordObj) (written int64, err error) {
+	xo, ok := xl.objs[obj]
+	if !ok {
+		return 0, fmt.Errorf("record file %q (%s) is not staged", obj.ContentPath, obj.Extension)
+	}
+	if len(xo.md) > 0 {
+		var n int
+		n, err = w.Write(xo.md)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	n, err := io.Copy(w, xl.reader(xo))
+	return written + n, err
+}
+
+func (xl *xformLoader) free() {
+	for _, xo := range xl.objs {
+		xo.free()
+	}
+	clear(xl.objs)
+	if xl.wfh != nil {
+		cos.Close(xl.wfh)
+		if err := cos.RemoveFile(xl.wfqn); err != nil {
+			nlog.Errorln(err)
+		}
+		xl.wfh = nil
+	}
+	if xl.reserved > 0 {
+		xl.m.dsorter.postShardExtraction(xl.reserved)
+		xl.reserved = 0
+	}
+}
+
+//////////////
+// xformObj //
+//////////////
+
+func (xo *xformObj) free() {
+	if xo.sgl != nil {
+		xo.sgl.Free()
+	}
+}
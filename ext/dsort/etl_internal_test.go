@@ -0,0 +1,109 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/memsys"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// (only memory reservation is used by the staging)
+type stageDsorter struct {
+	dsorter
+	unreserved uint64
+}
+
+func (ds *stageDsorter) postShardExtraction(size uint64) { ds.unreserved += size }
+
+var _ = Describe("Staging", func() {
+	var (
+		ds   *stageDsorter
+		xl   *xformLoader
+		objs = []*shard.RecordObj{
+			{ContentPath: "a", Size: 5, Extension: ".txt"},
+			{ContentPath: "b", Size: 11, Extension: ".txt"},
+		}
+		content = [][]byte{[]byte("first"), []byte("second-file")}
+	)
+
+	BeforeEach(func() {
+		err := cos.CreateDir(testingConfigDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		if g.mm == nil {
+			g.mm = memsys.PageMM()
+		}
+		ds = &stageDsorter{}
+		xl = &xformLoader{m: &Manager{dsorter: ds}, objs: make(map[*shard.RecordObj]*xformObj), reserved: 100}
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(testingConfigDir)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	stageAll := func() {
+		for i, obj := range objs {
+			b := content[i]
+			xo, err := xl.stage(int64(len(b)), func(w io.Writer) (int64, error) {
+				n, err := w.Write(b)
+				return int64(n), err
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(xo.size).To(BeEquivalentTo(len(b)))
+			xl.objs[obj] = xo
+		}
+		// (loading more than once, in any order)
+		for range 2 {
+			for i := len(objs) - 1; i >= 0; i-- {
+				var buf bytes.Buffer
+				n, err := xl.Load(&buf, nil, objs[i])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(n).To(BeEquivalentTo(len(content[i])))
+				Expect(buf.Bytes()).To(Equal(content[i]))
+			}
+		}
+	}
+
+	It("should stage in memory", func() {
+		stageAll()
+		for _, xo := range xl.objs {
+			Expect(xo.sgl).NotTo(BeNil())
+		}
+		xl.free()
+		Expect(xl.objs).To(BeEmpty())
+		Expect(ds.unreserved).To(BeEquivalentTo(100))
+	})
+
+	It("should stage on disk and remove the work file", func() {
+		wfqn := filepath.Join(testingConfigDir, "stage", "stage-shard")
+		err := xl.create(wfqn)
+		Expect(err).NotTo(HaveOccurred())
+
+		stageAll()
+		for _, xo := range xl.objs {
+			Expect(xo.sgl).To(BeNil())
+		}
+		Expect(xl.woff).To(BeEquivalentTo(len(content[0]) + len(content[1])))
+
+		xl.free()
+		Expect(xl.wfh).To(BeNil())
+		Expect(ds.unreserved).To(BeEquivalentTo(100))
+		_, err = os.Stat(wfqn)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should fail to load record files that are not staged", func() {
+		_, err := xl.Load(io.Discard, nil, objs[0])
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -48,6 +48,7 @@ func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
 		pars = parsc.pars
 	)
 	pars.TargetOrderSalt = []byte(cos.FormatNowStamp())
+	pars.User = parsc.User
 
 	// TODO: handle case when bucket was removed during dsort job - this should
 	// stop whole operation. Maybe some listeners as we have on smap change?
@@ -97,8 +98,13 @@ func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
 	if cmn.Rom.FastV(4, cos.SmoduleDsort) {
 		nlog.Infof("[dsort] %s broadcasting start request to all targets", managerUUID)
 	}
+	var body []byte
+	if pars.Resume != "" {
+		// agree on the checkpointed state to resume from (see ckpt.go)
+		body = cos.MustMarshal(resumeState(responses))
+	}
 	path = apc.URLPathdSortStart.Join(managerUUID)
-	responses = bcast(http.MethodPost, path, nil, nil, smap)
+	responses = bcast(http.MethodPost, path, nil, body, smap)
 	if err := _handleResp(w, r, smap, managerUUID, responses); err != nil {
 		return
 	}
@@ -455,6 +461,12 @@ func tinitHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	managerUUID := apiItems[0]
+	if pars.Resume != "" {
+		if rm, exists := Managers.Get(pars.Resume, false /*incl. archived*/); exists && !rm.Metrics.Archived.Load() {
+			cmn.WriteErrMsg(w, r, fmt.Sprintf("cannot resume [dsort] %s: still in progress", pars.Resume))
+			return
+		}
+	}
 	m, err := Managers.Add(managerUUID) // NOTE: returns manager locked iff err == nil
 	if err != nil {
 		cmn.WriteErr(w, r, err)
@@ -470,8 +482,13 @@ func tinitHandler(w http.ResponseWriter, r *http.Request) {
 		debug.AssertNoErr(rns.Err)
 		xctn := rns.Entry.Get()
 		debug.Assert(xctn.ID() == managerUUID, xctn.ID()+" vs "+managerUUID)
+		xctn.SetOrigin(&apc.ActMsg{Action: apc.ActDsort, Value: pars.Spec}, pars.User)
 
 		m.xctn = xctn.(*xaction)
+
+		if pars.Resume != "" {
+			w.Write(cos.MustMarshal(m.ckpt.state()))
+		}
 	}
 	m.unlock()
 }
@@ -497,6 +514,16 @@ func tstartHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, s, http.StatusNotFound)
 		return
 	}
+	// resuming (see PstartHandler)
+	if b, err := io.ReadAll(r.Body); err != nil || len(b) > 0 {
+		if err == nil {
+			err = js.Unmarshal(b, &m.resume)
+		}
+		if err != nil {
+			cmn.WriteErr(w, r, fmt.Errorf(cmn.FmtErrUnmarshal, apc.ActDsort, "resume state", cos.BHead(b), err))
+			return
+		}
+	}
 
 	go m.startDsort()
 }
@@ -578,6 +605,9 @@ func (managers *ManagerGroup) shardsHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if m.ckpt != nil {
+		m.ckptDistributed(tmpMetadata)
+	}
 	m.creationPhase.metadata = *tmpMetadata
 	m.startShardCreation <- struct{}{}
 }
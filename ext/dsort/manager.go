@@ -49,6 +49,9 @@ const (
 const (
 	// Size of the buffer used for serialization of the shards/records.
 	serializationBufSize = 10 * cos.MiB
+
+	// Upper bound for the (doubling) per-shard retry backoff.
+	maxRetryBackoff = time.Minute
 )
 
 type (
@@ -96,6 +99,7 @@ type (
 			ch    chan int32
 		}
 		refCount        atomic.Int64 // Refcount to cleanup.
+		consumed        sync.Map     // record objects loaded at least once (when retrying - see decrementRefOnce)
 		inFlight        atomic.Int64 // Refcount in-flight stream requests
 		state           progressState
 		extractionPhase struct {
@@ -116,6 +120,9 @@ type (
 		callTimeout    time.Duration // max time to wait for another node to respond
 		config         *cmn.Config
 		xctn           *xaction
+		ckpt           *ckpt     // when checkpointing (see ckpt.go)
+		resume         ckptState // when resuming: cluster-wide checkpointed state
+		xform          *xformer  // when transforming records (see etl.go)
 	}
 )
 
@@ -239,6 +246,20 @@ func (m *Manager) init(pars *parsedReqSpec) error {
 	m.state.cleanWait = sync.NewCond(&m.mu)
 
 	m.callTimeout = m.config.Dsort.CallTimeout.D()
+
+	if pars.ETL != nil {
+		var err error
+		if m.xform, err = newXformer(m); err != nil {
+			return err
+		}
+	}
+
+	if pars.Checkpoint {
+		m.ckpt = newCkpt(m.ManagerUUID, pars, m.smap)
+		if pars.Resume != "" {
+			m.ckpt.adopt(pars.Resume)
+		}
+	}
 	return nil
 }
 
@@ -365,7 +386,16 @@ func (m *Manager) finalCleanup() {
 	// and we may have race between in-flight request and cleanup.
 	// Also, NOTE:
 	// recm.Cleanup => gmm.freeMemToOS => cos.FreeMemToOS to forcefully free memory to the OS
-	m.recm.Cleanup()
+	// (when checkpointing, failed job keeps extracted contents to be able to resume)
+	keepFiles := m.ckpt != nil && m.aborted() && m.ckpt.meta.Phase >= ckptExtracted
+	m.recm.Cleanup(keepFiles)
+	if m.ckpt != nil {
+		if m.aborted() {
+			m.ckpt.closeCreated()
+		} else {
+			m.ckpt.remove()
+		}
+	}
 
 	m.creationPhase.metadata.SendOrder = nil
 	m.creationPhase.metadata.Shards = nil
@@ -445,12 +475,25 @@ func (m *Manager) markStarted()               { m.dsorterStarted.Done() }
 func (m *Manager) waitToStart()               { m.dsorterStarted.Wait() }
 func (m *Manager) onDupRecs(msg string) error { return m.react(m.Pars.DuplicatedRecords, msg) }
 
+// missing and mistyped structured content keys
+func (m *Manager) onKeyErr(err *shard.ErrContentKey) error {
+	if err.Missing {
+		return m.react(m.Pars.EKMMissingKey, err.Error())
+	}
+	return m.react(m.Pars.EKMMalformedLine, err.Error())
+}
+
 // setRW sets what type of file extraction and creation is used based on the RequestSpec.
 func (m *Manager) setRW() (err error) {
 	var ke shard.KeyExtractor
 	switch m.Pars.Algorithm.Kind {
 	case Content:
-		ke, err = shard.NewContentKeyExtractor(m.Pars.Algorithm.ContentKeyType, m.Pars.Algorithm.Ext)
+		alg := m.Pars.Algorithm
+		if len(alg.ContentKeys) > 0 {
+			ke, err = shard.NewStructKeyExtractor(alg.ContentFormat, alg.Ext, alg.ContentKeys)
+		} else {
+			ke, err = shard.NewContentKeyExtractor(alg.ContentKeyType, alg.Ext)
+		}
 	case MD5:
 		ke, err = shard.NewMD5KeyExtractor()
 	default:
@@ -470,7 +513,7 @@ func (m *Manager) setRW() (err error) {
 		m.shardRW = shard.NopRW(m.shardRW)
 	}
 
-	m.recm = shard.NewRecordManager(m.Pars.InputBck, m.shardRW, ke, m.onDupRecs)
+	m.recm = shard.NewRecordManager(m.Pars.InputBck, m.shardRW, ke, m.onDupRecs, m.onKeyErr)
 	return nil
 }
 
@@ -539,6 +582,18 @@ func (m *Manager) decrementRef(by int64) {
 	}
 }
 
+// Shard creation can be retried (see dsgCreateShard.do), whereby the records loaded by the failed
+// attempt(s) get loaded again - decrement only once per record object, so that (the target that has
+// already finished creating its own shards) won't clean up while other shards still need its records.
+func (m *Manager) decrementRefOnce(uname string) {
+	if m.Pars.MaxRetries > 0 {
+		if _, loaded := m.consumed.LoadOrStore(uname, struct{}{}); loaded {
+			return
+		}
+	}
+	m.decrementRef(1)
+}
+
 func (m *Manager) inFlightInc()     { m.inFlight.Inc() }
 func (m *Manager) inFlightDec()     { m.inFlight.Dec() }
 func (m *Manager) inProgress() bool { return m.state.inProgress.Load() }
@@ -656,6 +711,28 @@ func (m *Manager) freeMemory() uint64 {
 	return maxMemoryToUse - mem.ActualUsed
 }
 
+// records must remain on disk (rather than memory) to be re-read upon retry or resume
+func (m *Manager) extractToDisk() bool { return m.ckpt != nil || m.Pars.MaxRetries > 0 }
+
+// retry per-shard failure (extraction or creation) as per the spec's retry policy
+func (m *Manager) retry(shardName string, cb func() error) (err error) {
+	backoff := m.Pars.RetryBackoff.D()
+	for i := 0; ; i++ {
+		err = cb()
+		if err == nil || i >= m.Pars.MaxRetries || cmn.IsErrAborted(err) || cos.IsErrOOS(err) || m.aborted() {
+			return err
+		}
+		nlog.Warningf("%s: [dsort] %s %q failed (retrying in %v, %d/%d): %v", core.T, m.ManagerUUID,
+			shardName, backoff, i+1, m.Pars.MaxRetries, err)
+		select {
+		case <-time.After(backoff):
+		case <-m.listenAborted():
+			return m.newErrAborted()
+		}
+		backoff = max(min(backoff*2, maxRetryBackoff), backoff) // (never below user-specified)
+	}
+}
+
 func (m *Manager) react(reaction, msg string) error {
 	switch reaction {
 	case cmn.IgnoreReaction:
@@ -143,10 +143,22 @@ func (mg *ManagerGroup) Remove(managerUUID string) error {
 	}
 
 	key := path.Join(managersKey, managerUUID)
+	mg.removeCkpt(key)
 	_ = mg.db.Delete(dsortCollection, key) // Delete only returns err when record does not exist, which should be ignored
 	return nil
 }
 
+// failed checkpointed job may still have its checkpoint and extracted contents (see ckpt.go)
+func (mg *ManagerGroup) removeCkpt(key string) {
+	var m Manager
+	if err := mg.db.Get(dsortCollection, key, &m); err != nil {
+		return
+	}
+	if m.Pars != nil && m.Pars.Checkpoint {
+		removeCkpt(m.ManagerUUID, m.Pars)
+	}
+}
+
 // persist removes manager from manager group (memory) and moves all information
 // about it to persistent storage (file). This operation allows for later access
 // of old managers (including managers' metrics).
@@ -205,6 +217,9 @@ func (mg *ManagerGroup) housekeep() time.Duration {
 		}
 		if time.Since(m.Metrics.Extraction.End) > regularInterval {
 			key := path.Join(managersKey, m.ManagerUUID)
+			if m.Pars != nil && m.Pars.Checkpoint {
+				removeCkpt(m.ManagerUUID, m.Pars)
+			}
 			_ = mg.db.Delete(dsortCollection, key)
 		}
 	}
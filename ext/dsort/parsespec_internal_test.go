@@ -8,6 +8,7 @@ import (
 	"errors"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -290,6 +291,60 @@ var _ = Describe("RequestSpec", func() {
 			_, err = rs.parse()
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("should parse resume and retry policy", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				Resume:          PrefixJobID + "abc",
+				Retry:           RetryPolicy{MaxRetries: 3},
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.Checkpoint).To(BeTrue())
+			Expect(pars.Resume).To(Equal(PrefixJobID + "abc"))
+			Expect(pars.MaxRetries).To(Equal(3))
+			Expect(pars.RetryBackoff.D()).To(Equal(time.Second))
+
+			rs.Retry.Backoff = "200ms"
+			pars, err = rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.RetryBackoff.D()).To(Equal(200 * time.Millisecond))
+		})
+
+		It("should parse etl spec", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.ETL).To(BeNil())
+
+			rs.ETL = ETLSpec{Name: "xform-jpg", Routes: map[string]string{".cls": ""}, Timeout: "10s"}
+			pars, err = rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.ETL).NotTo(BeNil())
+			Expect(pars.ETL.Name).To(Equal("xform-jpg"))
+			Expect(pars.ETL.Routes).To(HaveKeyWithValue(".cls", ""))
+			Expect(pars.ETL.Timeout.D()).To(Equal(10 * time.Second))
+			Expect(pars.ETLError).To(Equal(cmn.AbortReaction))
+
+			rs.ETL = ETLSpec{Routes: map[string]string{".jpg": "xform-jpg"}}
+			rs.Config.ETLError = cmn.WarnReaction
+			pars, err = rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.ETL.Name).To(BeEmpty())
+			Expect(pars.ETLError).To(Equal(cmn.WarnReaction))
+		})
 	})
 
 	Context("request specs which shall NOT pass", func() {
@@ -478,6 +533,62 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).Should(HaveOccurred())
 		})
 
+		It("should fail due to invalid resume and retry policy", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				Resume:          "abc",
+			}
+			_, err := rs.parse()
+			Expect(err).Should(HaveOccurred())
+
+			rs.Resume = ""
+			rs.Retry = RetryPolicy{MaxRetries: 1, Backoff: "-1s"}
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+
+			rs.Retry = RetryPolicy{}
+			rs.Checkpoint, rs.DryRun = true, true
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should fail due to invalid etl spec", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111..2}-suffix"),
+				OutputFormat:    "prefix-{10..111}-suffix",
+				OutputShardSize: "10KB",
+				MaxMemUsage:     "80%",
+				ETL:             ETLSpec{Routes: map[string]string{"jpg": "xform-jpg"}},
+			}
+			_, err := rs.parse()
+			Expect(err).Should(HaveOccurred())
+
+			rs.ETL = ETLSpec{Name: "xform", Timeout: "-1s"}
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+
+			rs.ETL = ETLSpec{Args: "quality=80"}
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+
+			rs.ETL = ETLSpec{Name: "xform"}
+			rs.DryRun = true
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+
+			rs.DryRun = false
+			rs.Config.ETLError = "something"
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
 		It("should fail when output shard size is empty and output format is %06d", func() {
 			rs := RequestSpec{
 				InputBck:       cmn.Bck{Name: "test"},
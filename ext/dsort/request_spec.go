@@ -5,11 +5,13 @@
 package dsort
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -29,9 +31,17 @@ type parsedOutputTemplate struct {
 	Template cos.ParsedTemplate
 }
 
+type parsedETL struct {
+	Name    string            `json:"name"`
+	Routes  map[string]string `json:"routes,omitempty"`
+	Args    string            `json:"args,omitempty"`
+	Timeout cos.Duration      `json:"request_timeout,omitempty"`
+}
+
 type ParsedReq struct {
 	InputBck  cmn.Bck
 	OutputBck cmn.Bck
+	User      string // originating user or client (recorded in the job history)
 	pars      *parsedReqSpec
 }
 
@@ -53,10 +63,23 @@ type parsedReqSpec struct {
 	CreateConcMaxLimit  int                   `json:"create_concurrency_max_limit"`
 	SbundleMult         int                   `json:"bundle_multiplier"`
 
+	// resumable jobs and retries (see ckpt.go)
+	Checkpoint   bool         `json:"checkpoint"`
+	Resume       string       `json:"resume,omitempty"`
+	MaxRetries   int          `json:"max_retries"`
+	RetryBackoff cos.Duration `json:"retry_backoff"`
+
+	// per-record transformation (see etl.go)
+	ETL *parsedETL `json:"etl,omitempty"`
+
 	// debug
 	DsorterType string `json:"dsorter_type"`
 	DryRun      bool   `json:"dry_run"`
 
+	// origin (job history)
+	Spec *RequestSpec `json:"spec,omitempty"`
+	User string       `json:"user,omitempty"`
+
 	cmn.DsortConf
 }
 
@@ -68,7 +91,8 @@ func specErr(s string, err error) error { return fmt.Errorf("[dsort] parse-spec:
 
 func (rs *RequestSpec) ParseCtx() (*ParsedReq, error) {
 	pars, err := rs.parse()
-	return &ParsedReq{pars.InputBck, pars.OutputBck, pars}, err
+	pars.Spec = rs
+	return &ParsedReq{InputBck: pars.InputBck, OutputBck: pars.OutputBck, pars: pars}, err
 }
 
 func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
@@ -211,6 +235,39 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	pars.DsorterType = rs.DsorterType
 	pars.DryRun = rs.DryRun
 
+	// checkpoint & retry
+	if rs.Resume != "" {
+		if !strings.HasPrefix(rs.Resume, PrefixJobID) {
+			return nil, fmt.Errorf("resume: invalid job ID %q", rs.Resume)
+		}
+		rs.Checkpoint = true
+	}
+	if rs.Checkpoint && rs.DryRun {
+		return nil, errors.New("checkpoint (resume) is not supported with dry-run")
+	}
+	pars.Checkpoint = rs.Checkpoint
+	pars.Resume = rs.Resume
+	if rs.Retry.MaxRetries < 0 {
+		return nil, fmt.Errorf("retry: invalid max_retries %d", rs.Retry.MaxRetries)
+	}
+	pars.MaxRetries = rs.Retry.MaxRetries
+	pars.RetryBackoff = cos.Duration(time.Second)
+	if rs.Retry.Backoff != "" {
+		backoff, err := time.ParseDuration(rs.Retry.Backoff)
+		if err != nil || backoff <= 0 {
+			return nil, fmt.Errorf("retry: invalid backoff %q", rs.Retry.Backoff)
+		}
+		pars.RetryBackoff = cos.Duration(backoff)
+	}
+
+	// etl
+	if pars.ETL, err = parseETL(&rs.ETL); err != nil {
+		return nil, specErr("etl", err)
+	}
+	if pars.ETL != nil && rs.DryRun {
+		return nil, errors.New("etl is not supported with dry-run")
+	}
+
 	// `cfg` here contains inherited (aka global) part of the dsort config -
 	// apply this request's rs.Config values to override or assign defaults
 
@@ -235,6 +292,12 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	if pars.DuplicatedRecords == "" {
 		pars.DuplicatedRecords = cfg.DuplicatedRecords
 	}
+	if pars.ETLError == "" {
+		pars.ETLError = cfg.ETLError
+		if pars.ETLError == "" {
+			pars.ETLError = cmn.AbortReaction
+		}
+	}
 	if pars.DsorterMemThreshold == "" {
 		pars.DsorterMemThreshold = cfg.DsorterMemThreshold
 	}
@@ -256,7 +319,11 @@ func parseAlgorithm(alg Algorithm) (*Algorithm, error) {
 		if alg.Ext == "" || alg.Ext[0] != '.' {
 			return nil, fmt.Errorf("%w %q", errAlgExt, alg.Ext)
 		}
-		if err := shard.ValidateContentKeyTy(alg.ContentKeyType); err != nil {
+		if len(alg.ContentKeys) > 0 {
+			if err := parseContentKeys(&alg); err != nil {
+				return nil, err
+			}
+		} else if err := shard.ValidateContentKeyTy(alg.ContentKeyType); err != nil {
 			return nil, err
 		}
 	} else {
@@ -266,6 +333,64 @@ func parseAlgorithm(alg Algorithm) (*Algorithm, error) {
 	return &alg, nil
 }
 
+// structured content keys (see shard/keypath.go)
+func parseContentKeys(alg *Algorithm) error {
+	if alg.ContentFormat == "" {
+		if alg.ContentFormat = shard.ContentFormat(alg.Ext); alg.ContentFormat == "" {
+			return fmt.Errorf("cannot infer content format from the extension %q (specify 'content_format')", alg.Ext)
+		}
+	}
+	if err := shard.ValidateContentFormat(alg.ContentFormat); err != nil {
+		return err
+	}
+	keys := make([]shard.ContentKey, len(alg.ContentKeys))
+	for i, key := range alg.ContentKeys {
+		if key.Type == "" {
+			key.Type = alg.ContentKeyType
+		}
+		if err := shard.ValidateContentKeyTy(key.Type); err != nil {
+			return err
+		}
+		if _, err := shard.ParseKeyPath(key.Path); err != nil {
+			return err
+		}
+		keys[i] = key
+	}
+	alg.ContentKeys = keys
+	return nil
+}
+
+// returns nil when there's nothing to transform
+func parseETL(spec *ETLSpec) (*parsedETL, error) {
+	var (
+		pe     = &parsedETL{Name: spec.Name, Args: spec.Args}
+		routed bool
+	)
+	for ext, name := range spec.Routes {
+		if ext == "" || ext[0] != '.' {
+			return nil, fmt.Errorf("invalid route extension %q (expecting e.g. \".jpg\")", ext)
+		}
+		routed = routed || name != ""
+	}
+	if pe.Name == "" && !routed {
+		if spec.Args != "" || spec.Timeout != "" {
+			return nil, errors.New("missing ETL name")
+		}
+		return nil, nil
+	}
+	if len(spec.Routes) > 0 {
+		pe.Routes = spec.Routes
+	}
+	if spec.Timeout != "" {
+		timeout, err := time.ParseDuration(spec.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid request_timeout %q", spec.Timeout)
+		}
+		pe.Timeout = cos.Duration(timeout)
+	}
+	return pe, nil
+}
+
 func validateOrderFileURL(orderURL string) (empty bool, err error) {
 	if orderURL == "" {
 		return true, nil
@@ -0,0 +1,282 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Minimal CBOR (RFC 8949) decoder - enough to select sorting keys (see keypath.go).
+// Decodes into: uint64 and int64 (integers), float64, string, []byte, bool,
+// []any (arrays), and map[string]any (maps; non-string keys are formatted as strings).
+// Tags are skipped (the tagged item is decoded as is).
+
+const cborMaxDepth = 64
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+type cborDecoder struct {
+	b   []byte
+	off int
+}
+
+func decodeCBOR(b []byte) (any, error) {
+	d := &cborDecoder{b: b}
+	return d.item(0)
+}
+
+func (d *cborDecoder) byte() (byte, error) {
+	if d.off >= len(d.b) {
+		return 0, errCBORTruncated
+	}
+	c := d.b[d.off]
+	d.off++
+	return c, nil
+}
+
+func (d *cborDecoder) next(n uint64) ([]byte, error) {
+	if n > uint64(len(d.b)-d.off) {
+		return nil, errCBORTruncated
+	}
+	p := d.b[d.off : d.off+int(n)]
+	d.off += int(n)
+	return p, nil
+}
+
+// returns the argument that follows the initial byte; indefinite == (info == 31)
+func (d *cborDecoder) arg(info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info == 24:
+		c, err := d.byte()
+		return uint64(c), err
+	case info == 25:
+		p, err := d.next(2)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint16(p)), nil
+	case info == 26:
+		p, err := d.next(4)
+		if err != nil {
+			return 0, err
+		}
+		return uint64(binary.BigEndian.Uint32(p)), nil
+	case info == 27:
+		p, err := d.next(8)
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint64(p), nil
+	}
+	return 0, fmt.Errorf("cbor: invalid additional info %d", info)
+}
+
+func (d *cborDecoder) item(depth int) (any, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("cbor: nesting too deep")
+	}
+	c, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := c>>5, c&0x1f
+	if major == 7 {
+		return d.simple(info)
+	}
+	if info == 31 {
+		return d.indefinite(major, depth)
+	}
+	n, err := d.arg(info)
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case 0:
+		if n <= math.MaxInt64 {
+			return int64(n), nil
+		}
+		return n, nil
+	case 1:
+		if n > math.MaxInt64 {
+			return nil, errors.New("cbor: negative integer overflow")
+		}
+		return -1 - int64(n), nil
+	case 2:
+		p, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), p...), nil
+	case 3:
+		p, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(p), nil
+	case 4:
+		if n > uint64(len(d.b)) {
+			return nil, errCBORTruncated
+		}
+		a := make([]any, 0, n)
+		for range n {
+			v, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		return a, nil
+	case 5:
+		if n > uint64(len(d.b)) {
+			return nil, errCBORTruncated
+		}
+		m := make(map[string]any, n)
+		for range n {
+			if err := d.entry(m, depth); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	default: // 6: tag
+		return d.item(depth + 1)
+	}
+}
+
+func (d *cborDecoder) entry(m map[string]any, depth int) error {
+	k, err := d.item(depth + 1)
+	if err != nil {
+		return err
+	}
+	v, err := d.item(depth + 1)
+	if err != nil {
+		return err
+	}
+	if s, ok := k.(string); ok {
+		m[s] = v
+	} else {
+		m[fmt.Sprint(k)] = v
+	}
+	return nil
+}
+
+func (d *cborDecoder) isBreak() bool {
+	if d.off < len(d.b) && d.b[d.off] == 0xff {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func (d *cborDecoder) indefinite(major byte, depth int) (any, error) {
+	switch major {
+	case 2, 3:
+		var p []byte
+		for !d.isBreak() {
+			chunk, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch v := chunk.(type) {
+			case []byte:
+				p = append(p, v...)
+			case string:
+				p = append(p, v...)
+			default:
+				return nil, errors.New("cbor: invalid indefinite-length chunk")
+			}
+		}
+		if major == 3 {
+			return string(p), nil
+		}
+		return p, nil
+	case 4:
+		var a []any
+		for !d.isBreak() {
+			v, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		return a, nil
+	case 5:
+		m := make(map[string]any)
+		for !d.isBreak() {
+			if err := d.entry(m, depth); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("cbor: invalid indefinite length for major type %d", major)
+}
+
+func (d *cborDecoder) simple(info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23: // null, undefined
+		return nil, nil
+	case 25:
+		p, err := d.next(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat(binary.BigEndian.Uint16(p)), nil
+	case 26:
+		p, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(p))), nil
+	case 27:
+		p, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(p)), nil
+	}
+	if info < 24 {
+		return uint64(info), nil // unassigned simple value
+	}
+	if info == 24 {
+		c, err := d.byte()
+		return uint64(c), err
+	}
+	return nil, fmt.Errorf("cbor: invalid simple value %d", info)
+}
+
+// IEEE 754 half-precision
+func halfToFloat(h uint16) float64 {
+	var (
+		exp  = int(h>>10) & 0x1f
+		mant = float64(h & 0x3ff)
+		val  float64
+	)
+	switch exp {
+	case 0:
+		val = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			val = math.Inf(1)
+		} else {
+			val = math.NaN()
+		}
+	default:
+		val = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -val
+	}
+	return val
+}
@@ -0,0 +1,324 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Structured content keys: sorting key(s) selected from inside a structured (JSON, CBOR,
+// or msgpack) record member, e.g. given "sample.json" containing {"label": 7, "meta": {"ts": 1.5}}:
+// - "label"       => 7
+// - "meta.ts"     => 1.5
+// - "labels[0]"   => first element of the "labels" array
+//
+// Multiple keys are compared in the order specified (compound sorting).
+
+const (
+	ContentFormatJSON    = "json"
+	ContentFormatCBOR    = "cbor"
+	ContentFormatMsgpack = "msgpack"
+
+	// (internal) key type of the structured content keys
+	ContentKeyCompound = "compound"
+)
+
+type (
+	ContentKey struct {
+		Path string `json:"path"` // e.g. "label", "meta.ts", "labels[0]"
+		Type string `json:"type"` // one of: "int", "float", "string"
+	}
+
+	structKeyExtractor struct {
+		ext    string
+		format string
+		keys   []ContentKey
+		paths  [][]any // parsed: string (map key) or int (array index)
+	}
+
+	// missing or mistyped key (subject to EKMMissingKey and EKMMalformedLine reactions, respectively)
+	ErrContentKey struct {
+		name    string
+		path    string
+		err     error
+		Missing bool
+	}
+)
+
+// interface guard
+var _ KeyExtractor = (*structKeyExtractor)(nil)
+
+// infer format from the member's extension
+func ContentFormat(ext string) string {
+	switch strings.ToLower(ext) {
+	case ".json":
+		return ContentFormatJSON
+	case ".cbor":
+		return ContentFormatCBOR
+	case ".msgpack", ".msgp", ".mpk":
+		return ContentFormatMsgpack
+	default:
+		return ""
+	}
+}
+
+func ValidateContentFormat(format string) error {
+	switch format {
+	case ContentFormatJSON, ContentFormatCBOR, ContentFormatMsgpack:
+		return nil
+	default:
+		return fmt.Errorf("invalid content format %q, expecting one of: '%s', '%s', '%s'",
+			format, ContentFormatJSON, ContentFormatCBOR, ContentFormatMsgpack)
+	}
+}
+
+func NewStructKeyExtractor(format, ext string, keys []ContentKey) (KeyExtractor, error) {
+	if err := ValidateContentFormat(format); err != nil {
+		return nil, err
+	}
+	ke := &structKeyExtractor{ext: ext, format: format, keys: keys, paths: make([][]any, len(keys))}
+	for i, key := range keys {
+		if err := ValidateContentKeyTy(key.Type); err != nil {
+			return nil, err
+		}
+		path, err := ParseKeyPath(key.Path)
+		if err != nil {
+			return nil, err
+		}
+		ke.paths[i] = path
+	}
+	return ke, nil
+}
+
+func (ke *structKeyExtractor) PrepareExtractor(name string, r cos.ReadSizer, ext string) (cos.ReadSizer, *SingleKeyExtractor, bool) {
+	if ke.ext != ext {
+		return r, nil, false
+	}
+	buf := &bytes.Buffer{}
+	tee := cos.NewSizedReader(io.TeeReader(r, buf), r.Size())
+	return tee, &SingleKeyExtractor{name: name, buf: buf}, true
+}
+
+func (ke *structKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (any, error) {
+	if ske == nil {
+		return nil, nil
+	}
+	b, err := io.ReadAll(ske.buf)
+	ske.buf = nil
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeContent(ke.format, b)
+	if err != nil {
+		return nil, &ErrContentKey{name: ske.name, err: err}
+	}
+	key := make([]any, len(ke.keys))
+	for i, path := range ke.paths {
+		v, ok := lookupKey(doc, path)
+		if !ok {
+			return nil, &ErrContentKey{name: ske.name, path: ke.keys[i].Path, Missing: true}
+		}
+		if key[i], err = convertKey(v, ke.keys[i].Type); err != nil {
+			return nil, &ErrContentKey{name: ske.name, path: ke.keys[i].Path, err: err}
+		}
+	}
+	return key, nil
+}
+
+// parse "a.b[0].c" into ["a", "b", 0, "c"] (optional leading "$" is ignored)
+func ParseKeyPath(s string) (path []any, _ error) {
+	orig := s
+	s = strings.TrimPrefix(s, "$")
+	s = strings.TrimPrefix(s, ".")
+	if s == "" {
+		return nil, fmt.Errorf("invalid content key path %q: empty", orig)
+	}
+	for _, seg := range strings.Split(s, ".") {
+		name, rest, _ := strings.Cut(seg, "[")
+		if name != "" {
+			path = append(path, name)
+		} else if rest == "" {
+			return nil, fmt.Errorf("invalid content key path %q: empty segment", orig)
+		}
+		for rest != "" {
+			idx, tail, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, fmt.Errorf("invalid content key path %q: missing ']'", orig)
+			}
+			n, err := strconv.Atoi(idx)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid content key path %q: bad index %q", orig, idx)
+			}
+			path = append(path, n)
+			if tail != "" && tail[0] != '[' {
+				return nil, fmt.Errorf("invalid content key path %q: unexpected %q", orig, tail)
+			}
+			rest = strings.TrimPrefix(tail, "[")
+		}
+	}
+	return path, nil
+}
+
+func decodeContent(format string, b []byte) (doc any, err error) {
+	switch format {
+	case ContentFormatJSON:
+		dec := jsoniter.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		err = dec.Decode(&doc)
+	case ContentFormatMsgpack:
+		doc, _, err = msgp.ReadIntfBytes(b)
+	case ContentFormatCBOR:
+		doc, err = decodeCBOR(b)
+	default:
+		err = ValidateContentFormat(format)
+	}
+	return
+}
+
+func lookupKey(doc any, path []any) (any, bool) {
+	v := doc
+	for _, seg := range path {
+		switch s := seg.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[s]; !ok {
+				return nil, false
+			}
+		case int:
+			a, ok := v.([]any)
+			if !ok || s >= len(a) {
+				return nil, false
+			}
+			v = a[s]
+		}
+	}
+	return v, v != nil
+}
+
+func convertKey(v any, ty string) (any, error) {
+	switch ty {
+	case ContentKeyInt:
+		switch n := v.(type) {
+		case json.Number:
+			if i, err := n.Int64(); err == nil {
+				return i, nil
+			}
+			f, err := n.Float64()
+			if err == nil && f == math.Trunc(f) {
+				return int64(f), nil
+			}
+		case int64:
+			return n, nil
+		case uint64:
+			if n <= math.MaxInt64 {
+				return int64(n), nil
+			}
+		case float64:
+			if n == math.Trunc(n) {
+				return int64(n), nil
+			}
+		case float32:
+			if f := float64(n); f == math.Trunc(f) {
+				return int64(f), nil
+			}
+		}
+	case ContentKeyFloat:
+		switch n := v.(type) {
+		case json.Number:
+			return n.Float64()
+		case int64:
+			return float64(n), nil
+		case uint64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		case float32:
+			return float64(n), nil
+		}
+	case ContentKeyString:
+		switch s := v.(type) {
+		case string:
+			return s, nil
+		case []byte:
+			return string(s), nil
+		}
+	default:
+		return nil, &ErrSortingKeyType{ty}
+	}
+	return nil, fmt.Errorf("expecting %s, got %T(%v)", ty, v, v)
+}
+
+// compare two (compound) key elements; numbers of different types are compared as floats
+func cmpKey(lhs, rhs any) (int, error) {
+	switch l := lhs.(type) {
+	case string:
+		if r, ok := rhs.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	case int64:
+		if r, ok := rhs.(int64); ok {
+			switch {
+			case l < r:
+				return -1, nil
+			case l > r:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	fl, lok := toFloat(lhs)
+	fr, rok := toFloat(rhs)
+	if !lok || !rok {
+		return 0, fmt.Errorf("cannot compare content keys %v(%T) and %v(%T)", lhs, lhs, rhs, rhs)
+	}
+	switch {
+	case fl < fr:
+		return -1, nil
+	case fl > fr:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+///////////////////
+// ErrContentKey //
+///////////////////
+
+func (e *ErrContentKey) Error() string {
+	switch {
+	case e.Missing:
+		return fmt.Sprintf("record %q: missing content key %q", e.name, e.path)
+	case e.path == "":
+		return fmt.Sprintf("record %q: malformed content: %v", e.name, e.err)
+	default:
+		return fmt.Sprintf("record %q: malformed content key %q: %v", e.name, e.path, e.err)
+	}
+}
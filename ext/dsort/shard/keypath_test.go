@@ -0,0 +1,142 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard_test
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/tinylib/msgp/msgp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContentKeys", func() {
+	extract := func(format, ext string, keys []shard.ContentKey, content []byte) (any, error) {
+		ke, err := shard.NewStructKeyExtractor(format, ext, keys)
+		Expect(err).NotTo(HaveOccurred())
+		r, ske, needRead := ke.PrepareExtractor("sample", cos.NewSizedReader(bytes.NewReader(content), int64(len(content))), ext)
+		Expect(needRead).To(BeTrue())
+		_, err = io.Copy(io.Discard, r)
+		Expect(err).NotTo(HaveOccurred())
+		return ke.ExtractKey(ske)
+	}
+
+	DescribeTable("parse key path",
+		func(path string, expected []any, ok bool) {
+			parsed, err := shard.ParseKeyPath(path)
+			if !ok {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(expected))
+		},
+		Entry("simple", "label", []any{"label"}, true),
+		Entry("nested", "meta.ts", []any{"meta", "ts"}, true),
+		Entry("jsonpath prefix", "$.meta.ts", []any{"meta", "ts"}, true),
+		Entry("index", "labels[1]", []any{"labels", 1}, true),
+		Entry("nested index", "a[0][2].b", []any{"a", 0, 2, "b"}, true),
+		Entry("empty", "", nil, false),
+		Entry("empty segment", "a..b", nil, false),
+		Entry("bad index", "a[x]", nil, false),
+		Entry("unterminated index", "a[0", nil, false),
+	)
+
+	It("should extract compound key from JSON", func() {
+		keys := []shard.ContentKey{{Path: "label", Type: shard.ContentKeyInt}, {Path: "meta.ts", Type: shard.ContentKeyFloat}}
+		key, err := extract(shard.ContentFormatJSON, ".json", keys, []byte(`{"label": 7, "meta": {"ts": 1.5}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal([]any{int64(7), 1.5}))
+	})
+
+	It("should extract key from msgpack", func() {
+		b := msgp.AppendMapHeader(nil, 2)
+		b = msgp.AppendString(b, "label")
+		b = msgp.AppendInt64(b, -3)
+		b = msgp.AppendString(b, "names")
+		b = msgp.AppendArrayHeader(b, 2)
+		b = msgp.AppendString(b, "x")
+		b = msgp.AppendString(b, "y")
+		keys := []shard.ContentKey{{Path: "names[1]", Type: shard.ContentKeyString}, {Path: "label", Type: shard.ContentKeyInt}}
+		key, err := extract(shard.ContentFormatMsgpack, ".msgpack", keys, b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal([]any{"y", int64(-3)}))
+	})
+
+	It("should extract key from CBOR", func() {
+		// {"label": 500, "meta": {"ts": 1.5 (half-precision)}, "name": "ab"}
+		b := []byte{
+			0xa3,
+			0x65, 'l', 'a', 'b', 'e', 'l', 0x19, 0x01, 0xf4,
+			0x64, 'm', 'e', 't', 'a', 0xa1, 0x62, 't', 's', 0xf9, 0x3e, 0x00,
+			0x64, 'n', 'a', 'm', 'e', 0x62, 'a', 'b',
+		}
+		keys := []shard.ContentKey{
+			{Path: "label", Type: shard.ContentKeyInt},
+			{Path: "meta.ts", Type: shard.ContentKeyFloat},
+			{Path: "name", Type: shard.ContentKeyString},
+		}
+		key, err := extract(shard.ContentFormatCBOR, ".cbor", keys, b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(Equal([]any{int64(500), 1.5, "ab"}))
+	})
+
+	It("should report missing and mistyped keys", func() {
+		var errKey *shard.ErrContentKey
+
+		_, err := extract(shard.ContentFormatJSON, ".json", []shard.ContentKey{{Path: "nope", Type: shard.ContentKeyInt}}, []byte(`{"label": 7}`))
+		Expect(err).To(BeAssignableToTypeOf(errKey))
+		Expect(err.(*shard.ErrContentKey).Missing).To(BeTrue())
+
+		_, err = extract(shard.ContentFormatJSON, ".json", []shard.ContentKey{{Path: "label", Type: shard.ContentKeyInt}}, []byte(`{"label": "seven"}`))
+		Expect(err).To(BeAssignableToTypeOf(errKey))
+		Expect(err.(*shard.ErrContentKey).Missing).To(BeFalse())
+
+		_, err = extract(shard.ContentFormatJSON, ".json", []shard.ContentKey{{Path: "label", Type: shard.ContentKeyInt}}, []byte(`{"label": `))
+		Expect(err).To(BeAssignableToTypeOf(errKey))
+		Expect(err.(*shard.ErrContentKey).Missing).To(BeFalse())
+	})
+
+	It("should compare compound keys", func() {
+		records := shard.NewRecords(4)
+		records.Insert(
+			&shard.Record{Name: "a", Key: []any{int64(1), "z"}},
+			&shard.Record{Name: "b", Key: []any{int64(1), "a"}},
+			&shard.Record{Name: "c", Key: []any{}}, // no key
+			&shard.Record{Name: "d", Key: []any{float64(0.5), "z"}},
+		)
+		idx := func(name string) int {
+			for i, r := range records.All() {
+				if r.Name == name {
+					return i
+				}
+			}
+			Fail("not found: " + name)
+			return -1
+		}
+		for _, decreasing := range []bool{false, true} {
+			less, err := records.LessCompound(idx("b"), idx("a"), decreasing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(less).To(Equal(!decreasing))
+
+			less, err = records.LessCompound(idx("d"), idx("b"), decreasing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(less).To(Equal(!decreasing))
+
+			// no key: last in either order
+			less, err = records.LessCompound(idx("c"), idx("a"), decreasing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(less).To(BeFalse())
+			less, err = records.LessCompound(idx("a"), idx("c"), decreasing)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(less).To(BeTrue())
+		}
+	})
+})
@@ -25,6 +25,10 @@ func (n *nopRW) IsCompressed() bool   { return n.internal.IsCompressed() }
 func (n *nopRW) SupportsOffset() bool { return n.internal.SupportsOffset() }
 func (n *nopRW) MetadataSize() int64  { return n.internal.MetadataSize() }
 
+func (n *nopRW) Resize(obj *RecordObj, md []byte, size int64) ([]byte, error) {
+	return n.internal.Resize(obj, md, size)
+}
+
 // Extract reads the tarball f and extracts its metadata.
 func (n *nopRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (extractedSize int64,
 	extractedCount int, err error) {
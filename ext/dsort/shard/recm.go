@@ -62,6 +62,7 @@ type (
 		Records             *Records
 		bck                 cmn.Bck
 		onDuplicatedRecords func(string) error
+		onContentKeyErr     func(*ErrContentKey) error
 
 		extractCreator  RW
 		keyExtractor    KeyExtractor
@@ -79,11 +80,13 @@ type (
 // RecordManager //
 ///////////////////
 
-func NewRecordManager(bck cmn.Bck, extractCreator RW, keyExtractor KeyExtractor, onDupRecs func(string) error) *RecordManager {
+func NewRecordManager(bck cmn.Bck, extractCreator RW, keyExtractor KeyExtractor, onDupRecs func(string) error,
+	onKeyErr func(*ErrContentKey) error) *RecordManager {
 	return &RecordManager{
 		Records:             NewRecords(1000),
 		bck:                 bck,
 		onDuplicatedRecords: onDupRecs,
+		onContentKeyErr:     onKeyErr,
 		extractCreator:      extractCreator,
 		keyExtractor:        keyExtractor,
 		contents:            &sync.Map{},
@@ -175,7 +178,14 @@ func (recm *RecordManager) RecordWithBuffer(args *extractRecordArgs) (size int64
 
 	var key any
 	if key, err = recm.keyExtractor.ExtractKey(ske); err != nil {
-		return size, errors.WithStack(err)
+		var errKey *ErrContentKey
+		if !errors.As(err, &errKey) || recm.onContentKeyErr == nil {
+			return size, errors.WithStack(err)
+		}
+		if err = recm.onContentKeyErr(errKey); err != nil {
+			return size, err // react: abort
+		}
+		key = []any{} // react: ignore or warn (the record will be ordered last)
 	}
 
 	if contentPath == "" || storeType == "" {
@@ -340,9 +350,28 @@ func (recm *RecordManager) ExtractionPaths() *sync.Map {
 	return recm.extractionPaths
 }
 
-func (recm *RecordManager) Cleanup() {
+// Restore previously extracted (and checkpointed) records, registering their
+// on-disk contents for cleanup. Records stored in memory (SGLs) cannot be restored.
+func (recm *RecordManager) Restore(records *Records) {
+	for _, record := range records.arr {
+		for _, obj := range record.Objects {
+			debug.Assert(obj.StoreType != SGLStoreType, record.Name)
+			if obj.StoreType == DiskStoreType {
+				recm.extractionPaths.Store(recm.FullContentPath(obj), struct{}{})
+			}
+		}
+	}
+	recm.Records.merge(records)
+}
+
+// Cleanup frees all memory and removes extracted contents, unless `keepFiles`
+// (as in: to resume the job later on)
+func (recm *RecordManager) Cleanup(keepFiles bool) {
 	recm.Records.Drain()
 	recm.extractionPaths.Range(func(k, _ any) bool {
+		if keepFiles {
+			return false
+		}
 		if err := fs.RemoveAll(k.(string)); err != nil {
 			nlog.Errorf("could not remove extraction path (%v) from previous run, err: %v", k, err)
 		}
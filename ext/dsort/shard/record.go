@@ -7,6 +7,7 @@ package shard
 
 import (
 	"encoding/json"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -201,6 +202,30 @@ func (r *Records) Exists(name, ext string) (exists bool) {
 	return
 }
 
+// DeleteShard removes all records extracted from the given (input) shard - e.g.,
+// partially extracted ones, prior to retrying the extraction
+func (r *Records) DeleteShard(shardName string) {
+	prefix := shardName + recSepa
+	r.Lock()
+	arr := r.arr[:0]
+	for _, record := range r.arr {
+		if !strings.HasPrefix(record.Name, prefix) {
+			arr = append(arr, record)
+			continue
+		}
+		delete(r.m, record.Name)
+		r.totalObjectCount -= len(record.Objects)
+	}
+	clear(r.arr[len(arr):])
+	r.arr = arr
+	for name := range r.dups {
+		if strings.HasPrefix(name, prefix) {
+			delete(r.dups, name)
+		}
+	}
+	r.Unlock()
+}
+
 func (r *Records) merge(records *Records) {
 	r.Insert(records.arr...)
 }
@@ -261,6 +286,34 @@ func (r *Records) Less(i, j int, keyType string) (bool, error) {
 	return false, nil
 }
 
+// compare compound (structured content) keys element by element;
+// records with no key (see ErrContentKey) are always ordered last
+func (r *Records) LessCompound(i, j int, decreasing bool) (bool, error) {
+	lhs, lok := r.arr[i].Key.([]any)
+	rhs, rok := r.arr[j].Key.([]any)
+	if !lok {
+		return false, errors.Errorf("key is missing for %q", r.arr[i].Name)
+	} else if !rok {
+		return false, errors.Errorf("key is missing for %q", r.arr[j].Name)
+	}
+	switch {
+	case len(lhs) == 0:
+		return false, nil
+	case len(rhs) == 0:
+		return true, nil
+	}
+	for k := range min(len(lhs), len(rhs)) {
+		c, err := cmpKey(lhs[k], rhs[k])
+		if err != nil {
+			return false, err
+		}
+		if c != 0 {
+			return (c < 0) != decreasing, nil
+		}
+	}
+	return false, nil
+}
+
 func (r *Records) TotalObjectCount() int {
 	return r.totalObjectCount
 }
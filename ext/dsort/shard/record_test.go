@@ -149,5 +149,30 @@ var _ = Describe("Records", func() {
 			Expect(records.TotalObjectCount()).To(Equal(1))
 			Expect(records.All()[0].TotalSize()).To(BeEquivalentTo(objectSize))
 		})
+
+		It("should delete all records of a given shard", func() {
+			records := shard.NewRecords(0)
+			for _, name := range []string{"shard-1|a", "shard-1|b", "shard-10|a", "shard-2|a"} {
+				records.Insert(&shard.Record{
+					Key:  name,
+					Name: name,
+					Objects: []*shard.RecordObj{
+						{Size: objectSize, Extension: ".cls"},
+						{Size: objectSize, Extension: ".jpg"},
+					},
+				})
+			}
+			Expect(records.Len()).To(Equal(4))
+			Expect(records.TotalObjectCount()).To(Equal(8))
+
+			records.DeleteShard("shard-1")
+
+			Expect(records.Len()).To(Equal(2))
+			Expect(records.TotalObjectCount()).To(Equal(4))
+			Expect(records.All()[0].Name).To(Equal("shard-10|a"))
+			Expect(records.All()[1].Name).To(Equal("shard-2|a"))
+			Expect(records.Exists("shard-1|a", ".cls")).To(BeFalse())
+			Expect(records.Exists("shard-2|a", ".cls")).To(BeTrue())
+		})
 	})
 })
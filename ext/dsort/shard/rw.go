@@ -20,6 +20,9 @@ type RW interface {
 	IsCompressed() bool
 	SupportsOffset() bool
 	MetadataSize() int64
+	// given record object's metadata (e.g., tar header), returns the metadata
+	// for the same object with a new (e.g., transformed) content size
+	Resize(obj *RecordObj, md []byte, size int64) ([]byte, error)
 }
 
 var (
@@ -0,0 +1,64 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard_test
+
+import (
+	"archive/tar"
+	"bytes"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	jsoniter "github.com/json-iterator/go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resize", func() {
+	const (
+		name    = "a/b/c.jpg"
+		oldSize = 1000
+		newSize = 123456
+	)
+	header := &tar.Header{Name: name, Size: oldSize, Mode: 0o644, Typeflag: tar.TypeReg, Format: tar.FormatUSTAR}
+
+	It("should resize raw tar header", func() {
+		var bb bytes.Buffer
+		tw := tar.NewWriter(&bb)
+		Expect(tw.WriteHeader(header)).NotTo(HaveOccurred())
+		md := bb.Bytes()[:archive.TarBlockSize]
+
+		obj := &shard.RecordObj{StoreType: shard.OffsetStoreType, MetadataSize: archive.TarBlockSize}
+		resized, err := shard.NewTarRW().Resize(obj, md, newSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resized).To(HaveLen(archive.TarBlockSize))
+
+		hdr, err := tar.NewReader(bytes.NewReader(resized)).Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hdr.Name).To(Equal(name))
+		Expect(hdr.Size).To(BeEquivalentTo(newSize))
+	})
+
+	It("should resize JSON-encoded tar header", func() {
+		md := cos.MustMarshal(header)
+		obj := &shard.RecordObj{StoreType: shard.SGLStoreType, MetadataSize: int64(len(md))}
+		resized, err := shard.NewTarRW().Resize(obj, md, newSize)
+		Expect(err).NotTo(HaveOccurred())
+
+		var hdr tar.Header
+		Expect(jsoniter.Unmarshal(resized, &hdr)).NotTo(HaveOccurred())
+		Expect(hdr.Name).To(Equal(name))
+		Expect(hdr.Size).To(BeEquivalentTo(newSize))
+	})
+
+	It("should keep zip metadata as is", func() {
+		md := []byte(`{"name":"a/b/c.jpg","comment":""}`)
+		obj := &shard.RecordObj{StoreType: shard.DiskStoreType, MetadataSize: int64(len(md))}
+		resized, err := shard.NewZipRW().Resize(obj, md, newSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resized).To(Equal(md))
+	})
+})
@@ -7,6 +7,8 @@ package shard
 
 import (
 	"archive/tar"
+	"bytes"
+	"fmt"
 	"io"
 	"strconv"
 
@@ -99,6 +101,10 @@ func (*tarRW) IsCompressed() bool   { return false }
 func (*tarRW) SupportsOffset() bool { return true }
 func (*tarRW) MetadataSize() int64  { return archive.TarBlockSize } // size of tar header with padding
 
+func (*tarRW) Resize(obj *RecordObj, md []byte, size int64) ([]byte, error) {
+	return resizeTarHeader(obj, md, size)
+}
+
 func (trw *tarRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
 	ar, err := archive.NewReader(trw.ext, r)
 	if err != nil {
@@ -170,6 +176,36 @@ func (*tarRW) Create(s *Shard, tarball io.Writer, loader ContentLoader) (written
 	return written, nil
 }
 
+// tar header of a record object is stored either as is (OffsetStoreType - raw header block
+// that precedes the content in the original shard) or JSON-encoded (SGL and disk)
+func resizeTarHeader(obj *RecordObj, md []byte, size int64) ([]byte, error) {
+	if obj.StoreType != OffsetStoreType {
+		var header tar.Header
+		if err := jsoniter.Unmarshal(md, &header); err != nil {
+			return nil, err
+		}
+		header.Size = size
+		return jsoniter.Marshal(&header)
+	}
+	header, err := tar.NewReader(bytes.NewReader(md)).Next()
+	if err != nil {
+		return nil, err
+	}
+	header.Size = size
+
+	var (
+		bb bytes.Buffer
+		tw = tar.NewWriter(&bb)
+	)
+	if err := tw.WriteHeader(header); err != nil { // (no tw.Close - the content follows)
+		return nil, err
+	}
+	if bb.Len() != len(md) {
+		return nil, fmt.Errorf("%s: cannot resize tar header (%d vs %d)", header.Name, bb.Len(), len(md))
+	}
+	return bb.Bytes(), nil
+}
+
 // mostly follows `tar.formatPAXRecord`
 func estimateXHeaderSize(paxRecords map[string]string) int64 {
 	const padding = 3 // Extra padding for ' ', '=', and '\n'
@@ -28,6 +28,10 @@ func (*tgzRW) IsCompressed() bool   { return true }
 func (*tgzRW) SupportsOffset() bool { return true }
 func (*tgzRW) MetadataSize() int64  { return archive.TarBlockSize } // size of tar header with padding
 
+func (*tgzRW) Resize(obj *RecordObj, md []byte, size int64) ([]byte, error) {
+	return resizeTarHeader(obj, md, size)
+}
+
 // Extract reads the tarball f and extracts its metadata.
 // Writes work tar
 func (trw *tgzRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
@@ -28,6 +28,10 @@ func (*tlz4RW) IsCompressed() bool   { return true }
 func (*tlz4RW) SupportsOffset() bool { return true }
 func (*tlz4RW) MetadataSize() int64  { return archive.TarBlockSize } // size of tar header with padding
 
+func (*tlz4RW) Resize(obj *RecordObj, md []byte, size int64) ([]byte, error) {
+	return resizeTarHeader(obj, md, size)
+}
+
 // Extract  the tarball f and extracts its metadata.
 func (trw *tlz4RW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
 	ar, err := archive.NewReader(trw.ext, r)
@@ -55,6 +55,9 @@ func (*zipRW) IsCompressed() bool   { return true }
 func (*zipRW) SupportsOffset() bool { return false }
 func (*zipRW) MetadataSize() int64  { return 0 } // zip does not have header size
 
+// zip metadata does not include the size (see zipFileHeader)
+func (*zipRW) Resize(_ *RecordObj, md []byte, _ int64) ([]byte, error) { return md, nil }
+
 // Extract reads the tarball f and extracts its metadata.
 func (zrw *zipRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
 	ar, err := archive.NewReader(zrw.ext, r, lom.Lsize())
@@ -34,7 +34,9 @@ func (s *alphaByKey) Less(i, j int) bool {
 		err  error
 		less bool
 	)
-	if s.decreasing {
+	if s.keyType == shard.ContentKeyCompound {
+		less, err = s.records.LessCompound(i, j, s.decreasing)
+	} else if s.decreasing {
 		less, err = s.records.Less(j, i, s.keyType)
 	} else {
 		less, err = s.records.Less(i, j, s.keyType)
@@ -66,6 +68,9 @@ func sortRecords(r *shard.Records, alg *Algorithm) (err error) {
 		}
 	default:
 		keys := &alphaByKey{records: r, decreasing: alg.Decreasing, keyType: alg.ContentKeyType}
+		if alg.Kind == Content && len(alg.ContentKeys) > 0 {
+			keys.keyType = shard.ContentKeyCompound
+		}
 		sort.Sort(keys)
 		err = keys.err
 	}
@@ -5,6 +5,7 @@
 package etl
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"sort"
@@ -46,6 +47,10 @@ const (
 	Hrev = "hrev://"
 	// Stdin/stdout communication.
 	HpushStdin = "io://"
+	// Pool of long-lived WebSocket connections, with objects framed and
+	// multiplexed over the pool (see wscomm.go for the protocol).
+	// Falls back to Hpush when the container cannot be connected.
+	WebSocket = "ws://"
 )
 
 // enum arg types (`argTypes`)
@@ -86,6 +91,7 @@ type (
 		// InitCodeMsg carries the name of the transforming function;
 		// the `Transform` function is mandatory and cannot be "" (empty) - it _will_ be called
 		// by the `Runtime` container (see etl/runtime/all.go for all supported pre-built runtimes);
+		// (the exception: "wasm" runtime, where it defaults to "_start" - see etl/wasm.go)
 		// =========================================================================================
 		Funcs struct {
 			Transform string `json:"transform"` // cannot be omitted
@@ -95,6 +101,12 @@ type (
 		ChunkSize int64 `json:"chunk_size"`
 		// bitwise flags: (streaming | debug | strict | ...) future enhancements
 		Flags int64 `json:"flags"`
+		// resource limits - currently, only the (in-process) "wasm" runtime (see etl/wasm.go)
+		Limits struct {
+			MaxMem  int64 `json:"max_mem,omitempty"`  // max module memory, in bytes; 0: wasmDefaultMem
+			MaxConc int   `json:"max_conc,omitempty"` // max concurrent transformations; 0: number of CPUs
+			MaxOut  int64 `json:"max_out,omitempty"`  // max transformed size, in bytes; 0: wasmDefaultOut
+		} `json:"limits"`
 	}
 )
 
@@ -129,8 +141,8 @@ type (
 )
 
 var (
-	commTypes = []string{Hpush, Hpull, Hrev, HpushStdin}         // NOTE: must contain all
-	argTypes  = []string{ArgTypeDefault, ArgTypeURL, ArgTypeFQN} // ditto
+	commTypes = []string{Hpush, Hpull, Hrev, HpushStdin, WebSocket} // NOTE: must contain all
+	argTypes  = []string{ArgTypeDefault, ArgTypeURL, ArgTypeFQN}    // ditto
 )
 
 ////////////////
@@ -228,9 +240,15 @@ func (m *InitMsgBase) validate(detail string) error {
 }
 
 func (m *InitCodeMsg) Validate() error {
+	if m.InProcess() && m.CommTypeX == "" {
+		m.CommTypeX = HpushStdin
+	}
 	if err := m.InitMsgBase.validate(m.String()); err != nil {
 		return err
 	}
+	if m.InProcess() {
+		return m.validateWasm()
+	}
 
 	if len(m.Code) == 0 {
 		return fmt.Errorf("source code is empty (%q)", m.Runtime)
@@ -242,6 +260,9 @@ func (m *InitCodeMsg) Validate() error {
 		return fmt.Errorf("unsupported runtime %q (supported: %v)", m.Runtime, runtime.GetNames())
 	}
 
+	if m.CommTypeX == WebSocket {
+		return fmt.Errorf("comm-type %q is not supported by the %q runtime yet (use init-spec)", m.CommTypeX, m.Runtime)
+	}
 	if m.Funcs.Transform == "" {
 		return fmt.Errorf("transform function cannot be empty (comm-type %q, funcs %+v)", m.CommTypeX, m.Funcs)
 	}
@@ -252,6 +273,30 @@ func (m *InitCodeMsg) Validate() error {
 	return nil
 }
 
+// the "wasm" runtime: stdin/stdout ABI, no dependencies, and the module's exported
+// function to start with (default "_start")
+func (m *InitCodeMsg) validateWasm() error {
+	if m.CommTypeX != HpushStdin {
+		return fmt.Errorf("runtime %q requires comm-type %q (got %q)", m.Runtime, HpushStdin, m.CommTypeX)
+	}
+	if len(m.Deps) > 0 {
+		return fmt.Errorf("runtime %q does not support dependencies", m.Runtime)
+	}
+	if !bytes.HasPrefix(m.Code, wasmMagic) {
+		return fmt.Errorf("runtime %q: code is not a WebAssembly binary module", m.Runtime)
+	}
+	if m.Funcs.Transform == "" {
+		m.Funcs.Transform = wasmStart
+	}
+	if m.Limits.MaxMem < 0 || m.Limits.MaxConc < 0 || m.Limits.MaxOut < 0 {
+		return fmt.Errorf("runtime %q: invalid limits %+v", m.Runtime, m.Limits)
+	}
+	return nil
+}
+
+// whether transformation runs inside the target (no ETL container)
+func (m *InitCodeMsg) InProcess() bool { return m.Runtime == runtime.Wasm }
+
 func (m *InitSpecMsg) Validate() (err error) {
 	if err := m.InitMsgBase.validate(m.String()); err != nil {
 		return err
@@ -12,6 +12,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,8 +23,10 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/fs"
+	jsoniter "github.com/json-iterator/go"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/net/websocket"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -38,7 +41,9 @@ func TestETLTransform(t *testing.T) {
 var _ = Describe("CommunicatorTest", func() {
 	var (
 		tmpDir            string
+		lom               *core.LOM
 		comm              Communicator
+		etlArgs           string // as received by the transformer
 		transformerServer *httptest.Server
 		targetServer      *httptest.Server
 		proxyServer       *httptest.Server
@@ -75,7 +80,7 @@ var _ = Describe("CommunicatorTest", func() {
 		// cluster.InitLomLocker(tMock)
 
 		// Create an object.
-		lom := &core.LOM{ObjName: objName}
+		lom = &core.LOM{ObjName: objName}
 		err = lom.InitBck(clusterBck.Bucket())
 		Expect(err).NotTo(HaveOccurred())
 		err = createRandomFile(lom.FQN, dataSize)
@@ -86,12 +91,18 @@ var _ = Describe("CommunicatorTest", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		// Initialize the HTTP servers.
-		transformerServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			etlArgs = r.URL.Query().Get(apc.QparamETLArgs)
 			_, err := w.Write(transformData)
 			Expect(err).NotTo(HaveOccurred())
+		})
+		mux.Handle(wsPath, websocket.Handler(func(conn *websocket.Conn) {
+			serveWs(conn, transformData, &etlArgs)
 		}))
+		transformerServer = httptest.NewServer(mux)
 		targetServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			err := comm.InlineTransform(w, r, lom)
+			err := comm.InlineTransform(w, r, lom, "")
 			Expect(err).NotTo(HaveOccurred())
 		}))
 		proxyServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -110,25 +121,33 @@ var _ = Describe("CommunicatorTest", func() {
 		Hpush,
 		Hpull,
 		Hrev,
+		WebSocket,
+	}
+
+	newComm := func(commType string) {
+		pod := &corev1.Pod{}
+		pod.SetName("somename")
+
+		xctn := mock.NewXact(apc.ActETLInline)
+		boot := &etlBootstrapper{
+			msg: InitSpecMsg{
+				InitMsgBase: InitMsgBase{
+					CommTypeX: commType,
+				},
+			},
+			pod:  pod,
+			uri:  transformerServer.URL,
+			xctn: xctn,
+		}
+		comm = newCommunicator(nil, boot)
 	}
 
 	for _, commType := range tests {
 		It("should perform transformation "+commType, func() {
-			pod := &corev1.Pod{}
-			pod.SetName("somename")
-
-			xctn := mock.NewXact(apc.ActETLInline)
-			boot := &etlBootstrapper{
-				msg: InitSpecMsg{
-					InitMsgBase: InitMsgBase{
-						CommTypeX: commType,
-					},
-				},
-				pod:  pod,
-				uri:  transformerServer.URL,
-				xctn: xctn,
+			newComm(commType)
+			if wc, ok := comm.(*wsComm); ok {
+				defer wc.pool.close()
 			}
-			comm = newCommunicator(nil, boot)
 
 			resp, err := http.Get(proxyServer.URL)
 			Expect(err).NotTo(HaveOccurred())
@@ -139,9 +158,100 @@ var _ = Describe("CommunicatorTest", func() {
 			Expect(len(b)).To(Equal(len(transformData)))
 			Expect(b).To(Equal(transformData))
 		})
+
+		It("should forward ETL args "+commType, func() {
+			newComm(commType)
+			if wc, ok := comm.(*wsComm); ok {
+				defer wc.pool.close()
+			}
+
+			const args = "crop=10,10,200,200&seed=42"
+			r, err := comm.OfflineTransform(lom, 0 /*timeout*/, args)
+			Expect(err).NotTo(HaveOccurred())
+			b, err := io.ReadAll(r)
+			r.Close()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(transformData))
+			Expect(etlArgs).To(Equal(args))
+		})
 	}
+
+	It("should count sent bytes once "+WebSocket, func() {
+		newComm(WebSocket)
+		defer comm.(*wsComm).pool.close()
+
+		r, err := comm.OfflineTransform(lom, 0 /*timeout*/, "")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = io.Copy(io.Discard, r)
+		r.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		xctn := comm.Xact().(*mock.XactMock)
+		Expect(xctn.OutObjs()).To(Equal(int64(1)))
+		Expect(xctn.OutBytes()).To(Equal(dataSize))
+		Expect(xctn.InObjs()).To(Equal(int64(1)))
+		Expect(xctn.InBytes()).To(Equal(int64(len(transformData))))
+	})
+
+	It("should time out hung container "+WebSocket, func() {
+		hung := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+			var frame []byte
+			for websocket.Message.Receive(conn, &frame) == nil { // consume and never respond
+			}
+		}))
+		defer hung.Close()
+
+		pod := &corev1.Pod{}
+		pod.SetName("somename")
+		boot := &etlBootstrapper{
+			msg: InitSpecMsg{
+				InitMsgBase: InitMsgBase{CommTypeX: WebSocket, Timeout: cos.Duration(200 * time.Millisecond)},
+			},
+			pod:  pod,
+			uri:  hung.URL,
+			xctn: mock.NewXact(apc.ActETLInline),
+		}
+		wc := newCommunicator(nil, boot).(*wsComm)
+		wc.pool.url = "ws" + strings.TrimPrefix(hung.URL, "http") // (serving at root)
+		defer wc.pool.close()
+
+		started := time.Now()
+		err := wc.InlineTransform(httptest.NewRecorder(), nil, lom, "")
+		Expect(err).To(HaveOccurred())
+		Expect(time.Since(started)).To(BeNumerically("<", 5*time.Second))
+		Expect(wc.pool.sema).To(BeEmpty()) // connection slot released
+	})
 })
 
+// Mock ws:// transformer: consumes each request and responds with the same data.
+func serveWs(conn *websocket.Conn, data []byte, args *string) {
+	for {
+		var (
+			s   string
+			hdr wsHdr
+		)
+		if err := websocket.Message.Receive(conn, &s); err != nil {
+			return
+		}
+		Expect(jsoniter.UnmarshalFromString(s, &hdr)).NotTo(HaveOccurred())
+		*args = hdr.Args
+		for {
+			var frame []byte
+			Expect(websocket.Message.Receive(conn, &frame)).NotTo(HaveOccurred())
+			if len(frame) == 0 {
+				break
+			}
+		}
+		s, _ = jsoniter.MarshalToString(&wsHdr{ID: hdr.ID, Size: int64(len(data))})
+		Expect(websocket.Message.Send(conn, s)).NotTo(HaveOccurred())
+		for off := 0; off < len(data); off += cos.MiB {
+			end := min(off+cos.MiB, len(data))
+			Expect(websocket.Message.Send(conn, data[off:end])).NotTo(HaveOccurred())
+		}
+		Expect(websocket.Message.Send(conn, []byte{})).NotTo(HaveOccurred())
+	}
+}
+
 // Creates a file with random content.
 func createRandomFile(fileName string, size int64) error {
 	b := make([]byte, size)
@@ -46,15 +46,22 @@ type (
 		// InlineTransform uses one of the two ETL container endpoints:
 		//  - Method "PUT", Path "/"
 		//  - Method "GET", Path "/bucket/object"
-		InlineTransform(w http.ResponseWriter, r *http.Request, lom *core.LOM) error
+		// All transform methods forward (opaque, per-request) `args`, if any, to the container -
+		// via apc.QparamETLArgs query parameter or, in case of WebSocket, the object's header
+		InlineTransform(w http.ResponseWriter, r *http.Request, lom *core.LOM, args string) error
 
 		// OfflineTransform is driven by `OfflineDP` to provide offline transformation, as it were
 		// Implementations include:
 		// - pushComm
 		// - redirectComm
 		// - revProxyComm
+		// - wsComm
 		// See also, and separately: on-the-fly transformation as part of a user (e.g. training model) GET request handling
-		OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error)
+		OfflineTransform(lom *core.LOM, timeout time.Duration, args string) (cos.ReadCloseSizer, error)
+
+		// PushTransform PUTs the output of the previous ETL pipeline stage to the container
+		// (regardless of the communication type) and closes it when done - see Pipeline
+		PushTransform(r cos.ReadCloseSizer, lom *core.LOM, timeout time.Duration, args string) (cos.ReadCloseSizer, error)
 
 		Stop()
 
@@ -89,6 +96,7 @@ var (
 	_ Communicator = (*pushComm)(nil)
 	_ Communicator = (*redirectComm)(nil)
 	_ Communicator = (*revProxyComm)(nil)
+	_ Communicator = (*wsComm)(nil)
 
 	_ io.Writer = (*cbWriter)(nil)
 )
@@ -130,6 +138,11 @@ func newCommunicator(listener meta.Slistener, boot *etlBootstrapper) Communicato
 		}
 		rp.rp = revProxy
 		return rp
+	case WebSocket:
+		wc := &wsComm{}
+		wc.listener, wc.boot = listener, boot
+		wc.pool = newWsPool(boot.uri)
+		return wc
 	}
 
 	debug.Assert(false, "unknown comm-type '"+boot.msg.CommTypeX+"'")
@@ -194,18 +207,96 @@ func (c *baseComm) getWithTimeout(url string, size int64, timeout time.Duration)
 	}), nil
 }
 
+// per-object timeout: the request's (or stage's), if specified, or else InitMsg.Timeout, or else DefaultTimeout
+// (a hung container must not hold the object's lock - or, with WebSocket, a connection slot - indefinitely)
+func (c *baseComm) timeout(timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	if timeout = c.boot.msg.Timeout.D(); timeout > 0 {
+		return timeout
+	}
+	return DefaultTimeout
+}
+
+func (c *baseComm) PushTransform(r cos.ReadCloseSizer, lom *core.LOM, timeout time.Duration, args string) (cos.ReadCloseSizer, error) {
+	var (
+		req  *http.Request
+		resp *http.Response
+		xctn = c.boot.xctn
+		err  = xctn.AbortErr()
+	)
+	if err == nil && c.boot.msg.ArgTypeX == ArgTypeFQN {
+		err = fmt.Errorf("%s: argument type %q (local file) cannot be used with a subsequent ETL pipeline stage", c, ArgTypeFQN)
+	}
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	debug.Assertf(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname("")+" - bucket with namespace") // see pushComm.do
+	var (
+		u    = withArgs(c.boot.uri+"/"+lom.Bck().Name+"/"+lom.ObjName, args)
+		body = cos.NewReaderWithArgs(cos.ReaderArgs{
+			R:      r,
+			Size:   r.Size(),
+			ReadCb: func(n int, _ error) { xctn.OutObjsAdd(0, int64(n)) },
+		})
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout(timeout))
+	req, err = http.NewRequestWithContext(ctx, http.MethodPut, u, body)
+	if err == nil {
+		if c.boot.msg.CommTypeX == HpushStdin {
+			q := req.URL.Query()
+			q["command"] = []string{"bash", "-c", strings.Join(c.boot.originalCommand, " ")}
+			req.URL.RawQuery = q.Encode()
+		}
+		if size := r.Size(); size >= 0 {
+			req.ContentLength = size
+		}
+		req.Header.Set(cos.HdrContentType, cos.ContentBinary)
+		resp, err = core.T.DataClient().Do(req) //nolint:bodyclose // closed by the caller
+	} else {
+		r.Close()
+	}
+	if err == nil && resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		err = fmt.Errorf("%s: %s (status %d)", c, strings.TrimSpace(string(b)), resp.StatusCode)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      resp.Body,
+		Size:   resp.ContentLength,
+		ReadCb: func(n int, _ error) { xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			cancel()
+			xctn.InObjsAdd(1, 0)
+			xctn.OutObjsAdd(1, 0)
+		},
+	}), nil
+}
+
 //////////////
 // pushComm: implements (Hpush | HpushStdin)
 //////////////
 
-func (pc *pushComm) doRequest(lom *core.LOM, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+func (pc *pushComm) doRequest(lom *core.LOM, timeout time.Duration, args string) (r cos.ReadCloseSizer, err error) {
+	return doLocked(lom, timeout, args, pc.do)
+}
+
+// call `do` under read lock; cold-GET remote object if need be (and retry)
+func doLocked(lom *core.LOM, timeout time.Duration, args string,
+	do func(*core.LOM, time.Duration, string) (cos.ReadCloseSizer, int, error)) (r cos.ReadCloseSizer, err error) {
 	if err := lom.InitBck(lom.Bucket()); err != nil {
 		return nil, err
 	}
 
 	var ecode int
 	lom.Lock(false)
-	r, ecode, err = pc.do(lom, timeout)
+	r, ecode, err = do(lom, timeout, args)
 	lom.Unlock(false)
 
 	if err != nil && cos.IsNotExist(err, ecode) && lom.Bucket().IsRemote() {
@@ -214,13 +305,13 @@ func (pc *pushComm) doRequest(lom *core.LOM, timeout time.Duration) (r cos.ReadC
 			return nil, err
 		}
 		lom.Lock(false)
-		r, _, err = pc.do(lom, timeout)
+		r, _, err = do(lom, timeout, args)
 		lom.Unlock(false)
 	}
 	return
 }
 
-func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSizer, ecode int, err error) {
+func (pc *pushComm) do(lom *core.LOM, timeout time.Duration, args string) (_ cos.ReadCloseSizer, ecode int, err error) {
 	var (
 		body   io.ReadCloser
 		cancel func()
@@ -255,6 +346,7 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 	default:
 		debug.Assert(false, "unexpected msg type:", pc.boot.msg.ArgTypeX) // is validated at construction time
 	}
+	u = withArgs(u, args)
 
 	if timeout != 0 {
 		var ctx context.Context
@@ -292,7 +384,7 @@ finish:
 		}
 		return nil, ecode, err
 	}
-	args := cos.ReaderArgs{
+	rargs := cos.ReaderArgs{
 		R:      resp.Body,
 		Size:   resp.ContentLength,
 		ReadCb: func(n int, _ error) { pc.boot.xctn.InObjsAdd(0, int64(n)) },
@@ -304,11 +396,11 @@ finish:
 			pc.boot.xctn.OutObjsAdd(1, size) // see also: `coi.objsAdd`
 		},
 	}
-	return cos.NewReaderWithArgs(args), 0, nil
+	return cos.NewReaderWithArgs(rargs), 0, nil
 }
 
-func (pc *pushComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
-	r, err := pc.doRequest(lom, 0 /*timeout*/)
+func (pc *pushComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM, args string) error {
+	r, err := pc.doRequest(lom, 0 /*timeout*/, args)
 	if err != nil {
 		return err
 	}
@@ -328,9 +420,9 @@ func (pc *pushComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom
 	return err
 }
 
-func (pc *pushComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (r cos.ReadCloseSizer, err error) {
+func (pc *pushComm) OfflineTransform(lom *core.LOM, timeout time.Duration, args string) (r cos.ReadCloseSizer, err error) {
 	clone := *lom
-	r, err = pc.doRequest(&clone, timeout)
+	r, err = pc.doRequest(&clone, timeout, args)
 	if err == nil && cmn.Rom.FastV(5, cos.SmoduleETL) {
 		nlog.Infoln(Hpush, clone.Cname(), err)
 	}
@@ -341,7 +433,7 @@ func (pc *pushComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (r co
 // redirectComm: implements Hpull
 //////////////////
 
-func (rc *redirectComm) InlineTransform(w http.ResponseWriter, r *http.Request, lom *core.LOM) error {
+func (rc *redirectComm) InlineTransform(w http.ResponseWriter, r *http.Request, lom *core.LOM, args string) error {
 	if err := rc.boot.xctn.AbortErr(); err != nil {
 		return err
 	}
@@ -352,7 +444,7 @@ func (rc *redirectComm) InlineTransform(w http.ResponseWriter, r *http.Request,
 	if size > 0 {
 		rc.boot.xctn.OutObjsAdd(1, size)
 	}
-	http.Redirect(w, r, rc.redirectURL(lom), http.StatusTemporaryRedirect)
+	http.Redirect(w, r, rc.redirectURL(lom, args), http.StatusTemporaryRedirect)
 
 	if cmn.Rom.FastV(5, cos.SmoduleETL) {
 		nlog.Infoln(Hpull, lom.Cname())
@@ -360,25 +452,25 @@ func (rc *redirectComm) InlineTransform(w http.ResponseWriter, r *http.Request,
 	return nil
 }
 
-func (rc *redirectComm) redirectURL(lom *core.LOM) string {
+func (rc *redirectComm) redirectURL(lom *core.LOM, args string) string {
 	switch rc.boot.msg.ArgTypeX {
 	case ArgTypeDefault, ArgTypeURL:
-		return cos.JoinPath(rc.boot.uri, transformerPath(lom))
+		return withArgs(cos.JoinPath(rc.boot.uri, transformerPath(lom)), args)
 	case ArgTypeFQN:
-		return cos.JoinPath(rc.boot.uri, url.PathEscape(lom.FQN))
+		return withArgs(cos.JoinPath(rc.boot.uri, url.PathEscape(lom.FQN)), args)
 	}
 	cos.Assert(false) // is validated at construction time
 	return ""
 }
 
-func (rc *redirectComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+func (rc *redirectComm) OfflineTransform(lom *core.LOM, timeout time.Duration, args string) (cos.ReadCloseSizer, error) {
 	clone := *lom
 	size, errV := lomLoad(&clone)
 	if errV != nil {
 		return nil, errV
 	}
 
-	etlURL := rc.redirectURL(&clone)
+	etlURL := rc.redirectURL(&clone, args)
 	r, err := rc.getWithTimeout(etlURL, size, timeout)
 
 	if cmn.Rom.FastV(5, cos.SmoduleETL) {
@@ -391,7 +483,8 @@ func (rc *redirectComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (
 // revProxyComm: implements Hrev
 //////////////////
 
-func (rp *revProxyComm) InlineTransform(w http.ResponseWriter, r *http.Request, lom *core.LOM) error {
+// (args, if any, are forwarded as is - see pruneQuery)
+func (rp *revProxyComm) InlineTransform(w http.ResponseWriter, r *http.Request, lom *core.LOM, _ string) error {
 	size, err := lomLoad(lom)
 	if err != nil {
 		return err
@@ -408,13 +501,13 @@ func (rp *revProxyComm) InlineTransform(w http.ResponseWriter, r *http.Request,
 	return nil
 }
 
-func (rp *revProxyComm) OfflineTransform(lom *core.LOM, timeout time.Duration) (cos.ReadCloseSizer, error) {
+func (rp *revProxyComm) OfflineTransform(lom *core.LOM, timeout time.Duration, args string) (cos.ReadCloseSizer, error) {
 	clone := *lom
 	size, errV := lomLoad(&clone)
 	if errV != nil {
 		return nil, errV
 	}
-	etlURL := cos.JoinPath(rp.boot.uri, transformerPath(&clone))
+	etlURL := withArgs(cos.JoinPath(rp.boot.uri, transformerPath(&clone)), args)
 	r, err := rp.getWithTimeout(etlURL, size, timeout)
 
 	if cmn.Rom.FastV(5, cos.SmoduleETL) {
@@ -451,6 +544,14 @@ func pruneQuery(rawQuery string) string {
 	return vals.Encode()
 }
 
+// append (optional) per-request ETL arguments to the container's URL
+func withArgs(u, args string) string {
+	if args == "" {
+		return u
+	}
+	return u + "?" + apc.QparamETLArgs + "=" + url.QueryEscape(args)
+}
+
 // TODO -- FIXME: unify the way we encode bucket/object:
 // - url.PathEscape(uname) - see below - versus
 // - Bck().Name + "/" + lom.ObjName - see pushComm above - versus
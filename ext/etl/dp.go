@@ -11,6 +11,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 )
@@ -19,37 +20,41 @@ import (
 
 type (
 	OfflineDP struct {
-		comm           Communicator
-		tcbmsg         *apc.TCBMsg
-		config         *cmn.Config
-		requestTimeout time.Duration
+		pipeline Pipeline // a single stage when there's no pipeline
+		tcbmsg   *apc.TCBMsg
+		config   *cmn.Config
 	}
 )
 
 // interface guard
-var _ core.DP = (*OfflineDP)(nil)
+var _ core.FanOutDP = (*OfflineDP)(nil)
 
 func NewOfflineDP(msg *apc.TCBMsg, config *cmn.Config) (*OfflineDP, error) {
-	comm, err := GetCommunicator(msg.Transform.Name)
+	pipeline, err := NewPipeline(msg.Transform.Stages())
 	if err != nil {
 		return nil, err
 	}
-	pr := &OfflineDP{comm: comm, tcbmsg: msg, config: config}
-	pr.requestTimeout = time.Duration(msg.Transform.Timeout)
-	return pr, nil
+	return &OfflineDP{pipeline: pipeline, tcbmsg: msg, config: config}, nil
 }
 
+func (dp *OfflineDP) FanOut() bool { return dp.tcbmsg.Transform.FanOut }
+
 // Returns reader resulting from lom ETL transformation.
 // TODO -- FIXME: comm.OfflineTransform to support latestVer and sync
 func (dp *OfflineDP) Reader(lom *core.LOM, latestVer, sync bool) (cos.ReadOpenCloser, cos.OAH, error) {
 	var (
 		r      cos.ReadCloseSizer // note: +sizer
 		err    error
-		action = "read [" + dp.tcbmsg.Transform.Name + "]-transformed " + lom.Cname()
+		failed int // pipeline stage
+		action = "read [" + dp.pipeline.String() + "]-transformed " + lom.Cname()
 	)
 	debug.Assert(!latestVer && !sync, "NIY") // TODO -- FIXME
 	call := func() (int, error) {
-		r, err = dp.comm.OfflineTransform(lom, dp.requestTimeout)
+		started := mono.NanoTime()
+		r, failed, err = dp.pipeline.Transform(lom)
+		if err == nil {
+			core.StatsAdd(core.ETLLatency, mono.SinceNano(started)) // (time to response)
+		}
 		return 0, err
 	}
 	// TODO: Check if ETL pod is healthy and wait some more if not (yet).
@@ -66,7 +71,7 @@ func (dp *OfflineDP) Reader(lom *core.LOM, latestVer, sync bool) (cos.ReadOpenCl
 		nlog.Infoln(action, err)
 	}
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, dp.pipeline.Err(failed, err)
 	}
 	lom.SetAtimeUnix(time.Now().UnixNano())
 	oah := &cmn.ObjAttrs{
@@ -0,0 +1,85 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// Pipeline: ETL stages applied in sequence (apc.Transform.Pipeline, apc.ParseETLPipeline)
+// - the first stage transforms the object (via its own communication type);
+// - each subsequent stage receives the output of the previous one (see Communicator.PushTransform),
+//   streaming from container to container with no intermediate writes;
+// - each stage counts its own stats and errors (as in: its own ETL xaction)
+
+type (
+	Stage struct {
+		comm    Communicator
+		args    string
+		timeout time.Duration
+	}
+	Pipeline []Stage
+)
+
+func NewPipeline(stages []apc.ETLStage) (Pipeline, error) {
+	pl := make(Pipeline, 0, len(stages))
+	for i := range stages {
+		comm, err := GetCommunicator(stages[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		pl = append(pl, Stage{comm: comm, args: stages[i].Args, timeout: stages[i].Timeout.D()})
+	}
+	return pl, nil
+}
+
+func (pl Pipeline) String() string {
+	stages := make([]apc.ETLStage, len(pl))
+	for i := range pl {
+		stages[i] = apc.ETLStage{Name: pl[i].comm.Name(), Timeout: cos.Duration(pl[i].timeout)}
+	}
+	return apc.ETLPipelineSpec(stages)
+}
+
+// returns the output of the last stage or, in case of error, the failed stage
+func (pl Pipeline) Transform(lom *core.LOM) (cos.ReadCloseSizer, int, error) {
+	r, err := pl[0].comm.OfflineTransform(lom, pl[0].timeout, pl[0].args)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := 1; i < len(pl); i++ {
+		if r, err = pl[i].comm.PushTransform(r, lom, pl[i].timeout, pl[i].args); err != nil {
+			return nil, i, err
+		}
+	}
+	return r, 0, nil
+}
+
+// same as above, with the input that is not an object (e.g., dsort record);
+// the object (name) is only used to name the transformation request
+func (pl Pipeline) Push(r cos.ReadCloseSizer, lom *core.LOM) (_ cos.ReadCloseSizer, failed int, err error) {
+	for i := range pl {
+		if r, err = pl[i].comm.PushTransform(r, lom, pl[i].timeout, pl[i].args); err != nil {
+			return nil, i, err
+		}
+	}
+	return r, 0, nil
+}
+
+// wrap and record the error with the stage that failed
+func (pl Pipeline) Err(failed int, err error) error {
+	var (
+		comm = pl[failed].comm
+		ctx  = &cmn.ETLErrCtx{ETLName: comm.Name(), PodName: comm.PodName(), SvcName: comm.SvcName()}
+		errV = cmn.NewErrETL(ctx, err.Error())
+	)
+	comm.Xact().AddErr(errV)
+	return errV
+}
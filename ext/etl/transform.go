@@ -167,7 +167,11 @@ func InitSpec(msg *InitSpecMsg, etlName string, opts StartOpts) error {
 // - make the corresponding assorted substitutions in the etl/runtime/podspec.yaml spec, and
 // - execute `InitSpec` with the modified podspec
 // See also: etl/runtime/podspec.yaml
+// (the exception being in-process runtime.Wasm - see etl/wasm.go)
 func InitCode(msg *InitCodeMsg, xid string) error {
+	if msg.InProcess() {
+		return initWasm(msg, xid)
+	}
 	var (
 		ftp      = fromToPairs(msg)
 		replacer = strings.NewReplacer(ftp...)
@@ -328,9 +332,6 @@ func Stop(id string, errCause error) error {
 
 // StopAll terminates all running ETLs.
 func StopAll() {
-	if !k8s.IsK8s() {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(e.Name, nil); err != nil {
 			nlog.Errorln(err)
@@ -353,6 +354,9 @@ func PodLogs(transformID string) (logs Logs, err error) {
 	if err != nil {
 		return logs, err
 	}
+	if c.PodName() == "" {
+		return Logs{TargetID: core.T.SID()}, nil // in-process (no logs)
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
@@ -372,6 +376,9 @@ func PodHealth(etlName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if c.PodName() == "" {
+		return string(corev1.PodRunning), nil // in-process: running as long as registered
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return "", err
@@ -384,6 +391,9 @@ func PodMetrics(etlName string) (*CPUMemUsed, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.PodName() == "" {
+		return nil, fmt.Errorf("%s: metrics are not supported for in-process ETL", c)
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return nil, err
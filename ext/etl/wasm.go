@@ -0,0 +1,338 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/sys"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// In-process WebAssembly (runtime.Wasm) transformation
+//
+// The user-provided module (InitCodeMsg.Code) gets compiled once, at init time, and then
+// instantiated - from scratch - for each transformed object, whereby the (WASI) module:
+//   - reads the object from stdin and writes the transformed result to stdout;
+//   - gets the ETL name and per-request ETL args (if any) as its command-line arguments;
+//   - gets the bucket/object name in the AIS_OBJECT environment variable;
+//   - signals failure by exiting with non-zero code (stderr, if any, is then included in the error).
+// The start function is InitCodeMsg.Funcs.Transform (default "_start").
+//
+// Budget:
+//   - each instance is limited to InitCodeMsg.Limits.MaxMem bytes of linear memory (default wasmDefaultMem);
+//   - at most InitCodeMsg.Limits.MaxConc instances run concurrently (default: number of CPUs);
+//   - each transformation is limited by the request timeout (or InitCodeMsg.Timeout, or DefaultTimeout) -
+//     upon expiration, the running instance is terminated;
+//   - transformed output is buffered in memory (SGL) and, therefore, has known size - up to
+//     InitCodeMsg.Limits.MaxOut bytes (default wasmDefaultOut), beyond which the transformation fails.
+//
+// There's no ETL container (pod) - see PodLogs, PodHealth, and PodMetrics.
+
+const (
+	wasmStart      = "_start"
+	wasmDefaultMem = 64 * cos.MiB
+	wasmDefaultOut = 256 * cos.MiB
+	wasmPageSize   = 64 * cos.KiB
+	wasmMaxPages   = 65536 // (4GiB)
+	wasmMaxStderr  = cos.KiB
+	wasmEnvObject  = "AIS_OBJECT"
+)
+
+var wasmMagic = []byte("\x00asm")
+
+type (
+	wasmComm struct {
+		baseComm
+		rt       wazero.Runtime
+		compiled wazero.CompiledModule
+		sema     chan struct{}
+		start    string
+		timeout  time.Duration
+		maxOut   int64
+	}
+	// stdout, up to wasmComm.maxOut bytes
+	wasmStdout struct {
+		sgl      *memsys.SGL
+		max      int64
+		exceeded bool
+	}
+	// stderr, up to wasmMaxStderr bytes
+	wasmStderr struct {
+		bytes.Buffer
+	}
+)
+
+// interface guard
+var (
+	_ Communicator = (*wasmComm)(nil)
+	_ io.Writer    = (*wasmStdout)(nil)
+	_ io.Writer    = (*wasmStderr)(nil)
+)
+
+func initWasm(msg *InitCodeMsg, xid string) error {
+	wc, err := newWasmComm(msg)
+	if err != nil {
+		return cmn.NewErrETL(&cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}, err.Error())
+	}
+	wc.boot.errCtx.TID = core.T.SID()
+	wc.boot.config = cmn.GCO.Get()
+	wc.boot.setupXaction(xid)
+
+	wc.listener = newAborter(msg.IDX)
+	if err := reg.add(msg.IDX, wc); err != nil {
+		wc.Stop()
+		return err
+	}
+	core.T.Sowner().Listeners().Reg(wc)
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infof("started etl[%s], msg %s (in-process)", msg.IDX, msg)
+	}
+	return nil
+}
+
+// compile the module and check that it exports the start function
+func newWasmComm(msg *InitCodeMsg) (*wasmComm, error) {
+	var (
+		ctx     = context.Background()
+		maxMem  = msg.Limits.MaxMem
+		conc    = msg.Limits.MaxConc
+		maxOut  = msg.Limits.MaxOut
+		timeout = msg.Timeout.D()
+	)
+	if maxMem == 0 {
+		maxMem = wasmDefaultMem
+	}
+	if conc == 0 {
+		conc = sys.NumCPU()
+	}
+	if maxOut == 0 {
+		maxOut = wasmDefaultOut
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout // (never unbounded)
+	}
+	pages := min(cos.DivCeil(maxMem, wasmPageSize), wasmMaxPages)
+	config := wazero.NewRuntimeConfig().WithMemoryLimitPages(uint32(pages)).WithCloseOnContextDone(true)
+	rt := wazero.NewRuntimeWithConfig(ctx, config)
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		rt.Close(ctx)
+		return nil, err
+	}
+	compiled, err := rt.CompileModule(ctx, msg.Code)
+	if err != nil {
+		rt.Close(ctx)
+		return nil, fmt.Errorf("failed to compile WebAssembly module: %v", err)
+	}
+	start := msg.Funcs.Transform
+	if start == "" {
+		start = wasmStart
+	}
+	if _, ok := compiled.ExportedFunctions()[start]; !ok {
+		rt.Close(ctx)
+		return nil, fmt.Errorf("WebAssembly module does not export transform function %q", start)
+	}
+
+	wc := &wasmComm{
+		rt:       rt,
+		compiled: compiled,
+		sema:     make(chan struct{}, conc),
+		start:    start,
+		timeout:  timeout,
+		maxOut:   maxOut,
+	}
+	wc.boot = &etlBootstrapper{
+		errCtx:          &cmn.ETLErrCtx{ETLName: msg.IDX},
+		msg:             InitSpecMsg{InitMsgBase: msg.InitMsgBase},
+		originalPodName: msg.IDX,
+	}
+	return wc, nil
+}
+
+func (*wasmComm) PodName() string { return "" }
+func (*wasmComm) SvcName() string { return "" }
+
+func (wc *wasmComm) Stop() {
+	wc.rt.Close(context.Background())
+	wc.baseComm.Stop()
+}
+
+func (wc *wasmComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM, args string) error {
+	r, err := doLocked(lom, 0 /*timeout*/, args, wc.do)
+	if err != nil {
+		return err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(wc.String(), lom.Cname())
+	}
+
+	size := r.Size()
+	w.Header().Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	buf, slab := core.T.PageMM().AllocSize(size)
+	_, err = io.CopyBuffer(w, r, buf)
+
+	slab.Free(buf)
+	r.Close()
+	return err
+}
+
+func (wc *wasmComm) OfflineTransform(lom *core.LOM, timeout time.Duration, args string) (r cos.ReadCloseSizer, err error) {
+	clone := *lom
+	r, err = doLocked(&clone, timeout, args, wc.do)
+	if err == nil && cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(wc.String(), clone.Cname())
+	}
+	return
+}
+
+func (wc *wasmComm) PushTransform(r cos.ReadCloseSizer, lom *core.LOM, timeout time.Duration, args string) (cos.ReadCloseSizer, error) {
+	defer r.Close()
+	if err := wc.boot.xctn.AbortErr(); err != nil {
+		return nil, err
+	}
+	sgl, err := wc.run(r, lom.Bck().Name+"/"+lom.ObjName, args, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return wc.newReader(sgl), nil
+}
+
+func (wc *wasmComm) do(lom *core.LOM, timeout time.Duration, args string) (_ cos.ReadCloseSizer, ecode int, err error) {
+	if err := wc.boot.xctn.AbortErr(); err != nil {
+		return nil, 0, err
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return nil, 0, err
+	}
+	fh, err := cos.NewFileHandle(lom.FQN)
+	if err != nil {
+		return nil, 0, err
+	}
+	sgl, err := wc.run(fh, lom.Bck().Name+"/"+lom.ObjName, args, timeout)
+	fh.Close()
+	if err != nil {
+		return nil, 0, err
+	}
+	return wc.newReader(sgl), 0, nil
+}
+
+// instantiate and run the module: stdin => stdout (SGL)
+func (wc *wasmComm) run(r io.Reader, name, args string, timeout time.Duration) (*memsys.SGL, error) {
+	if timeout <= 0 {
+		timeout = wc.timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// back-pressure
+	select {
+	case wc.sema <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s: timed out waiting to transform %s (%d running)", wc, name, cap(wc.sema))
+	}
+	defer func() { <-wc.sema }()
+
+	var (
+		xctn   = wc.boot.xctn
+		stdout = &wasmStdout{sgl: core.T.PageMM().NewSGL(0), max: wc.maxOut}
+		stderr = &wasmStderr{}
+		stdin  = cos.NewReaderWithArgs(cos.ReaderArgs{
+			R:      r,
+			Size:   -1,
+			ReadCb: func(n int, _ error) { xctn.OutObjsAdd(0, int64(n)) },
+		})
+		argv = []string{wc.Name()}
+	)
+	if args != "" {
+		argv = append(argv, args)
+	}
+	config := wazero.NewModuleConfig().
+		WithName(""). // (anonymous - allows concurrent instances)
+		WithStartFunctions(wc.start).
+		WithArgs(argv...).
+		WithEnv(wasmEnvObject, name).
+		WithStdin(struct{ io.Reader }{stdin}). // (not to be closed by the module)
+		WithStdout(stdout).
+		WithStderr(stderr)
+
+	mod, err := wc.rt.InstantiateModule(ctx, wc.compiled, config)
+	if mod != nil {
+		mod.Close(ctx)
+	}
+	switch {
+	case stdout.exceeded: // (regardless of whether the module handled the write error)
+		err = stdout.err()
+	case err != nil && ctx.Err() != nil:
+		err = fmt.Errorf("timed out after %v", timeout)
+	}
+	if err != nil {
+		stdout.sgl.Free()
+		return nil, wc.wrapErr(name, err, stderr)
+	}
+	return stdout.sgl, nil
+}
+
+func (wc *wasmComm) wrapErr(name string, err error, stderr *wasmStderr) error {
+	if s := strings.TrimSpace(stderr.String()); s != "" {
+		return fmt.Errorf("%s: failed to transform %s: %v (stderr: %q)", wc, name, err, s)
+	}
+	return fmt.Errorf("%s: failed to transform %s: %v", wc, name, err)
+}
+
+// (stats: input bytes are counted when read from stdin - see run)
+func (wc *wasmComm) newReader(sgl *memsys.SGL) cos.ReadCloseSizer {
+	xctn := wc.boot.xctn
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      sgl,
+		Size:   sgl.Size(),
+		ReadCb: func(n int, _ error) { xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			sgl.Free()
+			xctn.InObjsAdd(1, 0)
+			xctn.OutObjsAdd(1, 0)
+		},
+	})
+}
+
+////////////////
+// wasmStdout //
+////////////////
+
+func (o *wasmStdout) Write(b []byte) (int, error) {
+	if o.exceeded || o.sgl.Size()+int64(len(b)) > o.max {
+		o.exceeded = true
+		return 0, o.err()
+	}
+	return o.sgl.Write(b)
+}
+
+func (o *wasmStdout) err() error {
+	return fmt.Errorf("output exceeds %s limit", cos.ToSizeIEC(o.max, 0))
+}
+
+////////////////
+// wasmStderr //
+////////////////
+
+func (e *wasmStderr) Write(b []byte) (int, error) {
+	if n := wasmMaxStderr - e.Len(); n > 0 {
+		e.Buffer.Write(b[:min(n, len(b))])
+	}
+	return len(b), nil
+}
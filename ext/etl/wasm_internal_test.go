@@ -0,0 +1,221 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/ext/etl/runtime"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Minimal (hand-assembled) WASI module exporting:
+//   - "_start": copies stdin to stdout (fd_read/fd_write, 4KiB at a time)
+//   - "spin":   loops forever
+//   - "fail":   proc_exit(3)
+var wasmCat = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x10, 0x03, 0x60, 0x04, 0x7f, 0x7f, 0x7f,
+	0x7f, 0x01, 0x7f, 0x60, 0x01, 0x7f, 0x00, 0x60, 0x00, 0x00, 0x02, 0x67, 0x03, 0x16, 0x77, 0x61,
+	0x73, 0x69, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x31, 0x07, 0x66, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x00, 0x00, 0x16, 0x77,
+	0x61, 0x73, 0x69, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x31, 0x08, 0x66, 0x64, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x00, 0x00,
+	0x16, 0x77, 0x61, 0x73, 0x69, 0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x31, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x5f, 0x65, 0x78, 0x69,
+	0x74, 0x00, 0x01, 0x03, 0x04, 0x03, 0x02, 0x02, 0x02, 0x05, 0x03, 0x01, 0x00, 0x01, 0x07, 0x21,
+	0x04, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x06, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x00, 0x03, 0x04, 0x73, 0x70, 0x69, 0x6e, 0x00, 0x04, 0x04, 0x66, 0x61, 0x69, 0x6c, 0x00,
+	0x05, 0x0a, 0x5b, 0x03, 0x4a, 0x01, 0x01, 0x7f, 0x03, 0x40, 0x41, 0x00, 0x41, 0x10, 0x36, 0x02,
+	0x00, 0x41, 0x04, 0x41, 0x80, 0x20, 0x36, 0x02, 0x00, 0x41, 0x08, 0x41, 0x00, 0x36, 0x02, 0x00,
+	0x41, 0x00, 0x41, 0x00, 0x41, 0x01, 0x41, 0x08, 0x10, 0x00, 0x1a, 0x41, 0x08, 0x28, 0x02, 0x00,
+	0x21, 0x00, 0x20, 0x00, 0x45, 0x04, 0x40, 0x0f, 0x0b, 0x41, 0x04, 0x20, 0x00, 0x36, 0x02, 0x00,
+	0x41, 0x01, 0x41, 0x00, 0x41, 0x01, 0x41, 0x0c, 0x10, 0x01, 0x1a, 0x0c, 0x00, 0x0b, 0x0b, 0x07,
+	0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x0b, 0x06, 0x00, 0x41, 0x03, 0x10, 0x02, 0x0b,
+}
+
+var _ = Describe("WasmTest", func() {
+	const objSize = cos.MiB*3 + 17
+
+	var (
+		tmpDir string
+		lom    *core.LOM
+		data   = make([]byte, objSize)
+
+		bck        = cmn.Bck{Name: "wasmBck", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		clusterBck = meta.NewBck(
+			bck.Name, bck.Provider, bck.Ns,
+			&cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}},
+		)
+		bmdMock = mock.NewBaseBownerMock(clusterBck)
+	)
+
+	newMsg := func(start string) *InitCodeMsg {
+		msg := &InitCodeMsg{Code: wasmCat, Runtime: runtime.Wasm}
+		msg.IDX = "wasm-cat"
+		msg.Funcs.Transform = start
+		return msg
+	}
+	newComm := func(msg *InitCodeMsg) *wasmComm {
+		Expect(msg.Validate()).NotTo(HaveOccurred())
+		wc, err := newWasmComm(msg)
+		Expect(err).NotTo(HaveOccurred())
+		wc.boot.xctn = mock.NewXact(apc.ActETLInline)
+		return wc
+	}
+
+	BeforeEach(func() {
+		_, err := cryptorand.Read(data)
+		Expect(err).NotTo(HaveOccurred())
+
+		tmpDir, err = os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+		mpath := filepath.Join(tmpDir, "mpath")
+		Expect(cos.CreateDir(mpath)).NotTo(HaveOccurred())
+		fs.TestNew(nil)
+		_, err = fs.Add(mpath, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+
+		_ = mock.NewTarget(bmdMock)
+
+		lom = &core.LOM{ObjName: "wasmObj"}
+		Expect(lom.InitBck(clusterBck.Bucket())).NotTo(HaveOccurred())
+		f, err := cos.CreateFile(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.Write(data)
+		f.Close()
+		Expect(err).NotTo(HaveOccurred())
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		lom.SetSize(objSize)
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	It("should validate and apply defaults", func() {
+		msg := newMsg("")
+		Expect(msg.Validate()).NotTo(HaveOccurred())
+		Expect(msg.CommTypeX).To(Equal(HpushStdin))
+		Expect(msg.Funcs.Transform).To(Equal(wasmStart))
+
+		msg = newMsg("")
+		msg.CommTypeX = Hpush
+		Expect(msg.Validate()).To(HaveOccurred())
+
+		msg = newMsg("")
+		msg.Code = []byte("print('hello')")
+		Expect(msg.Validate()).To(HaveOccurred())
+
+		msg = newMsg("")
+		msg.Limits.MaxOut = -1
+		Expect(msg.Validate()).To(HaveOccurred())
+
+		// not validated (no timeout) - still, never unbounded
+		wc, err := newWasmComm(newMsg(""))
+		Expect(err).NotTo(HaveOccurred())
+		defer wc.rt.Close(context.Background())
+		Expect(wc.timeout).To(Equal(DefaultTimeout))
+		Expect(wc.maxOut).To(BeEquivalentTo(wasmDefaultOut))
+	})
+
+	It("should fail to init when transform function is not exported", func() {
+		msg := newMsg("transform")
+		Expect(msg.Validate()).NotTo(HaveOccurred())
+		_, err := newWasmComm(msg)
+		Expect(err).To(MatchError(ContainSubstring("transform")))
+	})
+
+	It("should perform offline transformation", func() {
+		wc := newComm(newMsg(""))
+		defer wc.rt.Close(context.Background())
+
+		r, err := wc.OfflineTransform(lom, 0 /*timeout*/, "a=1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Size()).To(BeEquivalentTo(objSize))
+		b, err := io.ReadAll(r)
+		r.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(data))
+	})
+
+	It("should push-transform (ETL pipeline)", func() {
+		wc := newComm(newMsg(""))
+		defer wc.rt.Close(context.Background())
+
+		in := cos.NewReaderWithArgs(cos.ReaderArgs{R: bytes.NewReader(data), Size: objSize})
+		r, err := wc.PushTransform(in, lom, 0 /*timeout*/, "")
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(r)
+		r.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(data))
+	})
+
+	It("should fail upon non-zero exit code", func() {
+		wc := newComm(newMsg("fail"))
+		defer wc.rt.Close(context.Background())
+
+		_, err := wc.OfflineTransform(lom, 0 /*timeout*/, "")
+		Expect(err).To(MatchError(ContainSubstring("exit_code(3)")))
+	})
+
+	It("should terminate upon timeout", func() {
+		wc := newComm(newMsg("spin"))
+		defer wc.rt.Close(context.Background())
+
+		started := time.Now()
+		_, err := wc.OfflineTransform(lom, 200*time.Millisecond, "")
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+		Expect(time.Since(started)).To(BeNumerically("<", 10*time.Second))
+	})
+
+	It("should terminate inline transformation upon (init) timeout", func() {
+		msg := newMsg("spin")
+		msg.Timeout = cos.Duration(200 * time.Millisecond)
+		wc := newComm(msg)
+		defer wc.rt.Close(context.Background())
+
+		started := time.Now()
+		err := wc.InlineTransform(httptest.NewRecorder(), nil, lom, "")
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+		Expect(time.Since(started)).To(BeNumerically("<", 10*time.Second))
+	})
+
+	It("should fail when output exceeds the limit", func() {
+		msg := newMsg("")
+		msg.Limits.MaxOut = objSize / 2
+		wc := newComm(msg)
+		defer wc.rt.Close(context.Background())
+
+		_, err := wc.OfflineTransform(lom, 0 /*timeout*/, "")
+		Expect(err).To(MatchError(ContainSubstring("exceeds")))
+
+		msg = newMsg("")
+		msg.Limits.MaxOut = objSize
+		wc = newComm(msg)
+		defer wc.rt.Close(context.Background())
+
+		r, err := wc.OfflineTransform(lom, 0 /*timeout*/, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Size()).To(BeEquivalentTo(objSize))
+		r.Close()
+	})
+})
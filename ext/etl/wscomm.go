@@ -0,0 +1,406 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocket (ws://) communication
+//
+// Each target keeps a pool of (up to wsMaxConns) long-lived connections to its ETL container
+// at `/ws`. Objects are multiplexed over the pool - one object per connection at a time - and framed
+// as follows (same in both directions):
+//   1. text frame: JSON header (wsHdr)
+//   2. binary frames: object data (any number, any size)
+//   3. empty binary frame: end of object
+// The container echoes the request's header ID; non-empty `err` in the response header
+// means failure (and no data frames).
+//
+// Back-pressure: at most wsMaxConns objects are being transformed at any given time;
+// the rest wait for a connection (subject to the request timeout, if any).
+// Each object is limited by the request timeout or, if not specified, InitMsg.Timeout
+// or DefaultTimeout - sending the request and receiving the entire response included.
+// On connection loss, the object is retried once over a newly dialed connection; when
+// the container cannot be connected at all, the request falls back to Hpush (HTTP PUT).
+//
+// The client is golang.org/x/net/websocket: no other WebSocket implementation is used
+// in this tree, x/net is already a (transitive) dependency at the same version, and the
+// protocol above requires nothing beyond basic message framing.
+
+const (
+	wsMaxConns = 16
+	wsPath     = "/ws"
+
+	// waiting for an available connection when there's no request timeout
+	wsAcquireTimeout = DefaultTimeout
+)
+
+type (
+	wsComm struct {
+		pushComm // fallback
+		pool     *wsPool
+		seq      atomic.Uint64
+	}
+	wsHdr struct {
+		Name string `json:"name,omitempty"` // bucket/object
+		Args string `json:"args,omitempty"` // opaque, transformer-specific
+		Err  string `json:"err,omitempty"`  // response only
+		ID   uint64 `json:"id"`
+		Size int64  `json:"size"` // -1 when unknown
+	}
+	wsPool struct {
+		url  string
+		idle chan *websocket.Conn
+		sema chan struct{}
+	}
+	// reads response data frames; returns (or closes) the connection when done
+	wsReader struct {
+		wc    *wsComm
+		conn  *websocket.Conn
+		sent  chan error // request sender's result
+		frame []byte
+		size  int64
+		eof   bool
+		err   error
+	}
+
+	// container-reported (transformation) error - not retried
+	errWsRemote struct {
+		msg string
+	}
+)
+
+// interface guard
+var _ cos.ReadCloseSizer = (*wsReader)(nil)
+
+func (e *errWsRemote) Error() string { return e.msg }
+
+////////////
+// wsPool //
+////////////
+
+func newWsPool(uri string) *wsPool {
+	return &wsPool{
+		url:  "ws" + strings.TrimPrefix(uri, "http") + wsPath,
+		idle: make(chan *websocket.Conn, wsMaxConns),
+		sema: make(chan struct{}, wsMaxConns),
+	}
+}
+
+// wait for a slot (back-pressure)
+func (p *wsPool) acquire(timeout time.Duration) error {
+	select {
+	case p.sema <- struct{}{}:
+		return nil
+	default:
+	}
+	if timeout == 0 {
+		timeout = wsAcquireTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case p.sema <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("timed out waiting for available connection to %s (%d in use)", p.url, wsMaxConns)
+	}
+}
+
+func (p *wsPool) release() { <-p.sema }
+
+// idle connection, if available, or a new one
+func (p *wsPool) get() (*websocket.Conn, error) {
+	select {
+	case conn := <-p.idle:
+		return conn, nil
+	default:
+		return p.dial()
+	}
+}
+
+func (p *wsPool) dial() (*websocket.Conn, error) {
+	config, err := websocket.NewConfig(p.url, "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+	return websocket.DialConfig(config)
+}
+
+func (p *wsPool) put(conn *websocket.Conn) {
+	conn.SetDeadline(time.Time{})
+	select {
+	case p.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+func (p *wsPool) close() {
+	for {
+		select {
+		case conn := <-p.idle:
+			conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+////////////
+// wsComm //
+////////////
+
+func (wc *wsComm) Stop() {
+	wc.pool.close()
+	wc.baseComm.Stop()
+}
+
+func (wc *wsComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM, args string) error {
+	r, err := doLocked(lom, wc.timeout(0), args, wc.do)
+	if err != nil {
+		return err
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(WebSocket, lom.Cname(), err)
+	}
+
+	size := r.Size()
+	if size < 0 {
+		size = memsys.DefaultBufSize
+	}
+	buf, slab := core.T.PageMM().AllocSize(size)
+	_, err = io.CopyBuffer(w, r, buf)
+
+	slab.Free(buf)
+	r.Close()
+	return err
+}
+
+func (wc *wsComm) OfflineTransform(lom *core.LOM, timeout time.Duration, args string) (r cos.ReadCloseSizer, err error) {
+	clone := *lom
+	r, err = doLocked(&clone, timeout, args, wc.do)
+	if err == nil && cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(WebSocket, clone.Cname(), err)
+	}
+	return
+}
+
+// (ETL pipeline) the input cannot be re-read - hence, no retries
+func (wc *wsComm) PushTransform(r cos.ReadCloseSizer, lom *core.LOM, timeout time.Duration, args string) (cos.ReadCloseSizer, error) {
+	if err := wc.boot.xctn.AbortErr(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	if err := wc.pool.acquire(timeout); err != nil {
+		r.Close()
+		return nil, err
+	}
+	conn, err := wc.pool.get()
+	if err != nil {
+		wc.pool.release()
+		nlog.Warningln(wc.String(), "falling back to", Hpush+":", err)
+		return wc.baseComm.PushTransform(r, lom, timeout, args)
+	}
+	hdr := &wsHdr{Name: lom.Bck().Name + "/" + lom.ObjName, Args: args, Size: r.Size()}
+	wr, err := wc.exchange(conn, hdr, r, timeout)
+	if err != nil {
+		wc.pool.release()
+		return nil, err
+	}
+	return wc.newReader(wr), nil
+}
+
+func (wc *wsComm) do(lom *core.LOM, timeout time.Duration, args string) (_ cos.ReadCloseSizer, ecode int, err error) {
+	if err := wc.boot.xctn.AbortErr(); err != nil {
+		return nil, 0, err
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return nil, 0, err
+	}
+	if err := wc.pool.acquire(timeout); err != nil {
+		return nil, 0, err
+	}
+	debug.Assertf(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname("")+" - bucket with namespace") // see pushComm.do
+	var (
+		conn *websocket.Conn
+		wr   *wsReader
+		size = lom.Lsize()
+		hdr  = &wsHdr{Name: lom.Bck().Name + "/" + lom.ObjName, Args: args, Size: size}
+	)
+	for retry := false; ; retry = true {
+		var fh *cos.FileHandle
+		if retry {
+			conn, err = wc.pool.dial()
+		} else {
+			conn, err = wc.pool.get()
+		}
+		if err != nil {
+			wc.pool.release()
+			nlog.Warningln(wc.String(), "falling back to", Hpush+":", err)
+			return wc.pushComm.do(lom, timeout, args)
+		}
+		if fh, err = cos.NewFileHandle(lom.FQN); err != nil {
+			conn.Close()
+			break
+		}
+		// NOTE: the request keeps being sent (see exchange) after the caller (doLocked) releases
+		// the object's lock - which is fine as long as it reads via the file handle opened
+		// under the lock: objects are never written in place (but rather, written to a work file
+		// and then renamed), so the handle keeps reading the (locked) version it was opened with
+		wr, err = wc.exchange(conn, hdr, fh, timeout)
+		if err == nil {
+			return wc.newReader(wr), 0, nil
+		}
+		var errRemote *errWsRemote
+		if retry || errors.As(err, &errRemote) || wc.boot.xctn.IsAborted() {
+			break
+		}
+		nlog.Warningln(wc.String(), "retrying", lom.Cname(), "over a new connection:", err)
+	}
+	wc.pool.release()
+	return nil, 0, err
+}
+
+// send the request (asynchronously, closing the body when done) and receive response header;
+// the connection is closed upon failure
+func (wc *wsComm) exchange(conn *websocket.Conn, hdr *wsHdr, body io.ReadCloser, timeout time.Duration) (*wsReader, error) {
+	conn.SetDeadline(time.Now().Add(wc.timeout(timeout)))
+	hdr.ID = wc.seq.Add(1)
+	sent := make(chan error, 1)
+	go func() {
+		err := wc.send(conn, hdr, body)
+		body.Close()
+		sent <- err
+	}()
+
+	var (
+		s    string
+		resp wsHdr
+		err  = websocket.Message.Receive(conn, &s)
+	)
+	if err == nil {
+		err = jsoniter.UnmarshalFromString(s, &resp)
+	}
+	if err == nil {
+		switch {
+		case resp.ID != hdr.ID:
+			err = fmt.Errorf("%s: invalid response ID %d (expecting %d)", wc, resp.ID, hdr.ID)
+		case resp.Err != "":
+			err = &errWsRemote{fmt.Sprintf("%s: %s", wc, resp.Err)}
+		}
+	}
+	if err != nil {
+		conn.Close()
+		<-sent
+		return nil, err
+	}
+	return &wsReader{wc: wc, conn: conn, sent: sent, size: resp.Size}, nil
+}
+
+func (wc *wsComm) send(conn *websocket.Conn, hdr *wsHdr, body io.Reader) error {
+	s, err := jsoniter.MarshalToString(hdr)
+	if err != nil {
+		return err
+	}
+	if err := websocket.Message.Send(conn, s); err != nil {
+		return err
+	}
+	buf, slab := core.T.PageMM().Alloc()
+	defer slab.Free(buf)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if errV := websocket.Message.Send(conn, buf[:n]); errV != nil {
+				return errV
+			}
+			wc.boot.xctn.OutObjsAdd(0, int64(n))
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return websocket.Message.Send(conn, []byte{}) // end of object
+}
+
+// (stats: compare with pushComm.do; bytes sent are counted by wsComm.send)
+func (wc *wsComm) newReader(wr *wsReader) cos.ReadCloseSizer {
+	return cos.NewReaderWithArgs(cos.ReaderArgs{
+		R:      wr,
+		Size:   wr.size,
+		ReadCb: func(n int, _ error) { wc.boot.xctn.InObjsAdd(0, int64(n)) },
+		DeferCb: func() {
+			wc.boot.xctn.InObjsAdd(1, 0)
+			wc.boot.xctn.OutObjsAdd(1, 0)
+		},
+	})
+}
+
+//////////////
+// wsReader //
+//////////////
+
+func (r *wsReader) Size() int64 { return r.size }
+
+func (r *wsReader) Read(b []byte) (int, error) {
+	for len(r.frame) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.err = websocket.Message.Receive(r.conn, &r.frame); r.err != nil {
+			return 0, r.err
+		}
+		if len(r.frame) == 0 {
+			r.eof = true
+		}
+	}
+	n := copy(b, r.frame)
+	r.frame = r.frame[n:]
+	return n, nil
+}
+
+// reuse the connection only if both request and response have completed
+func (r *wsReader) Close() error {
+	var errSend error
+	if r.eof {
+		select {
+		case errSend = <-r.sent:
+		default:
+			errSend = errors.New("request not consumed")
+		}
+	}
+	if r.eof && errSend == nil {
+		r.wc.pool.put(r.conn)
+	} else {
+		r.conn.Close()
+		if errSend == nil {
+			<-r.sent
+		}
+	}
+	r.wc.pool.release()
+	return nil
+}
@@ -38,6 +38,7 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	ArchIdxType  = "ai" // random-access index of a TAR shard (see cmn/archive/index.go)
 )
 
 type (
@@ -117,6 +118,16 @@ func (f *contentSpecMgr) Gen(parts PartsFQN, contentType, prefix string) (fqn st
 	return parts.Mountpath().MakePathFQN(parts.Bucket(), contentType, objName)
 }
 
+// GenKeyed, unlike Gen, generates the same workfile name given the same object, prefix,
+// and key (which must not contain '.') - to resume the work across restarts.
+// Keyed workfiles are never "old" (see ParseUniqueFQN) - removing them is the caller's
+// responsibility.
+func (*contentSpecMgr) GenKeyed(parts PartsFQN, prefix, key string) string {
+	dir, fname := filepath.Split(parts.ObjectName())
+	objName := filepath.Join(dir, prefix+"."+fname) + "." + key + "." + keyedPID
+	return parts.Mountpath().MakePathFQN(parts.Bucket(), WorkfileType, objName)
+}
+
 // FileSpec returns the specification/attributes and information about the `fqn`
 // (which must be generated by the Gen)
 func (f *contentSpecMgr) FileSpec(fqn string) (resolver ContentResolver, info *ContentInfo) {
@@ -178,6 +189,7 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	ArchIdxContentResolver  struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -230,7 +242,7 @@ func (*WorkfileContentResolver) ParseUniqueFQN(base string) (orig string, old, o
 		return "", false, false
 	}
 
-	return base[:tieIndex], filePID != pid, true
+	return base[:tieIndex], filePID != pid && filePID != 0 /*keyed*/, true
 }
 
 func (*ECSliceContentResolver) PermToMove() bool    { return true }
@@ -252,3 +264,14 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// archive index can always be regenerated - no need to move it
+func (*ArchIdxContentResolver) PermToMove() bool    { return false }
+func (*ArchIdxContentResolver) PermToEvict() bool   { return true }
+func (*ArchIdxContentResolver) PermToProcess() bool { return false }
+
+func (*ArchIdxContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (*ArchIdxContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
@@ -35,6 +35,8 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileDownload     = "dl"             // downloader: (partially) downloaded content
+	WorkfileFanOut       = "fan-out"        // offline ETL: staged output (one of many) of a single source object
 )
 
 type ParsedFQN struct {
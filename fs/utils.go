@@ -14,7 +14,10 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
-const maxNumCopies = 16
+const (
+	maxNumCopies = 16
+	keyedPID     = "0" // in place of the pid - see CSM.GenKeyed
+)
 
 var (
 	pid  int64 = 0xDEADBEEF   // pid of the current process
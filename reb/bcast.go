@@ -33,6 +33,8 @@ type (
 		Aborted     bool       `json:"aborted"`             // aborted?
 		Running     bool       `json:"running"`             // running?
 		Quiescent   bool       `json:"quiescent"`           // true when queue is empty
+
+		Drain *cmn.DrainStatus `json:"drain,omitempty"` // when this target is (or was) leaving the cluster
 	}
 )
 
@@ -0,0 +1,305 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/xact/xs"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Drain: global rebalance from the perspective of a target that is leaving the cluster
+// (maintenance or decommission). In particular:
+// - the leaving target counts its objects upfront, to then report remaining objects and bytes,
+//   throughput, and ETA (see cmn.DrainStatus);
+// - all other targets hold off their own traversals until the leaving targets are done
+//   traversing theirs (prioritizing objects whose HRW owner is moving);
+// - upon receiving all ACKs, the leaving target confirms that each of its objects is present
+//   at the new owner; otherwise, the rebalance fails and the node does not get marked as safe
+//   to power off.
+// Erasure-coded buckets are handled by EC rebalance and are excluded.
+
+// max concurrent (intra-cluster) HEAD requests when verifying drain (across all mountpaths)
+const drainVerifyHeads = 8
+
+type (
+	drainer struct {
+		st      cmn.DrainStatus
+		started int64 // mono-time when migrating started
+		mu      sync.Mutex
+	}
+	// walk all local objects (excepting EC buckets and copies)
+	drainWalk struct {
+		cb   func(lom *core.LOM) error
+		xreb *xs.Rebalance
+	}
+)
+
+// leaving and not yet post-rebalance
+func isLeaving(smap *meta.Smap, tsi *meta.Snode) bool {
+	return smap.InMaintOrDecomm(tsi) && !tsi.Flags.IsSet(meta.SnodeMaintPostReb)
+}
+
+func (reb *Reb) leavingTs(smap *meta.Smap) (tsis meta.Nodes) {
+	for _, tsi := range smap.Tmap {
+		if tsi.ID() != core.T.SID() && isLeaving(smap, tsi) {
+			tsis = append(tsis, tsi)
+		}
+	}
+	return tsis
+}
+
+// DrainStatus returns nil if this target has never been drained (since it started)
+func (reb *Reb) DrainStatus() *cmn.DrainStatus {
+	d := &reb.drain
+	d.mu.Lock()
+	if d.st.RebID == "" {
+		d.mu.Unlock()
+		return nil
+	}
+	st := d.st
+	started := d.started
+	d.mu.Unlock()
+
+	if st.Stage != cmn.DrainMigrating {
+		return &st
+	}
+	if xreb := reb.xctn(); xreb != nil && xreb.ID() == st.RebID {
+		var stats core.Stats
+		xreb.ToStats(&stats)
+		st.SentObjs, st.SentBytes = stats.OutObjs, stats.OutBytes
+	}
+	_remaining(&st, started)
+	return &st
+}
+
+func _remaining(st *cmn.DrainStatus, started int64) {
+	st.RemObjs = max(st.TotalObjs-st.SentObjs, 0)
+	st.RemBytes = max(st.TotalBytes-st.SentBytes, 0)
+	if started == 0 {
+		return
+	}
+	elapsed := mono.Since(started)
+	if elapsed < time.Second || st.SentBytes == 0 {
+		return
+	}
+	st.Throughput = int64(float64(st.SentBytes) / elapsed.Seconds())
+	if st.Throughput > 0 {
+		st.ETA = cos.Duration(time.Duration(st.RemBytes/st.Throughput) * time.Second)
+	}
+}
+
+func (reb *Reb) drainStage(stage string, err error) {
+	d := &reb.drain
+	d.mu.Lock()
+	if d.st.Stage == cmn.DrainMigrating && stage != cmn.DrainMigrating {
+		// freeze the final numbers
+		if xreb := reb.xctn(); xreb != nil && xreb.ID() == d.st.RebID {
+			var stats core.Stats
+			xreb.ToStats(&stats)
+			d.st.SentObjs, d.st.SentBytes = stats.OutObjs, stats.OutBytes
+		}
+		_remaining(&d.st, d.started)
+	}
+	d.st.Stage = stage
+	if err != nil {
+		d.st.Err = err.Error()
+	}
+	d.mu.Unlock()
+}
+
+// stage 1: count local objects
+func (reb *Reb) drainBegin(rargs *rebArgs) {
+	var (
+		d           = &reb.drain
+		xreb        = reb.xctn()
+		objs, bytes atomic.Int64
+	)
+	d.mu.Lock()
+	d.st = cmn.DrainStatus{DaemonID: core.T.SID(), RebID: xreb.ID(), Stage: cmn.DrainCounting}
+	d.started = 0
+	d.mu.Unlock()
+
+	w := &drainWalk{xreb: xreb, cb: func(lom *core.LOM) error {
+		objs.Inc()
+		bytes.Add(lom.Lsize())
+		return nil
+	}}
+	w.run(rargs)
+
+	d.mu.Lock()
+	d.st.TotalObjs, d.st.TotalBytes = objs.Load(), bytes.Load()
+	d.st.Stage = cmn.DrainMigrating
+	d.started = mono.NanoTime()
+	d.mu.Unlock()
+	nlog.Infof("%s: draining %d objects (%s)", reb.logHdr(rargs.id, rargs.smap), objs.Load(),
+		cos.ToSizeIEC(bytes.Load(), 2))
+}
+
+// stage 3: confirm that all local objects are present at their new HRW owners
+func (reb *Reb) drainVerify(rargs *rebArgs) error {
+	var (
+		unverified atomic.Int64
+		xreb       = reb.xctn()
+		logHdr     = reb.logHdr(rargs.id, rargs.smap)
+		sema       = cos.NewSemaphore(drainVerifyHeads)
+	)
+	reb.drainStage(cmn.DrainVerifying, nil)
+
+	w := &drainWalk{xreb: xreb, cb: func(lom *core.LOM) error {
+		tsi, err := rargs.smap.HrwHash2T(lom.Digest())
+		if err != nil {
+			return err
+		}
+		if tsi.ID() == core.T.SID() {
+			return nil
+		}
+		sema.Acquire()
+		ok := core.T.HeadObjT2T(lom, tsi)
+		sema.Release()
+		if !ok {
+			if unverified.Inc() <= 8 {
+				nlog.Warningf("%s: %s not found at %s", logHdr, lom, tsi.StringEx())
+			}
+		}
+		return nil
+	}}
+	w.run(rargs)
+
+	n := unverified.Load()
+	reb.drain.mu.Lock()
+	reb.drain.st.Unverified = n
+	reb.drain.mu.Unlock()
+	if err := xreb.AbortErr(); err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%s: drain verification failed: %d object%s not found at their new locations",
+			logHdr, n, cos.Plural(int(n)))
+	}
+	nlog.Infoln(logHdr + ": drain verified")
+	return nil
+}
+
+// other (non-leaving) targets: wait for the leaving ones to finish traversing
+// (not aborting upon failure to reach a leaving target - e.g., the one that was shut down
+// without rebalancing - simply not waiting for it)
+func (reb *Reb) waitDrained(rargs *rebArgs, leaving meta.Nodes) {
+	var (
+		sleep  = cmn.KeepaliveRetryDuration(rargs.config)
+		logHdr = reb.logHdr(rargs.id, rargs.smap)
+		query  = url.Values{apc.QparamRebStatus: []string{"true"}}
+		xreb   = reb.xctn()
+	)
+outer:
+	for _, tsi := range leaving {
+		for !reb.stages.isInStage(tsi, rebStageTraverse) {
+			body, _, err := core.T.Health(tsi, apc.DefaultTimeout, query)
+			if err != nil {
+				nlog.Warningln(logHdr+": not waiting for leaving", tsi.StringEx(), "[", err, "]")
+				continue outer
+			}
+			status := &Status{}
+			if err := jsoniter.Unmarshal(body, status); err != nil {
+				continue outer
+			}
+			if _drained(status, rargs.id) {
+				break
+			}
+			if err := xreb.AbortedAfter(sleep); err != nil {
+				return
+			}
+		}
+		nlog.Infoln(logHdr+": done waiting for leaving", tsi.StringEx())
+	}
+}
+
+// whether a given leaving target is done traversing (as far as the rebalance `id` is concerned)
+func _drained(status *Status, id int64) bool {
+	switch {
+	case status.RebID > id:
+		return true // moved on
+	case status.RebID < id:
+		return false // not started yet
+	case status.Aborted:
+		return true
+	}
+	switch status.Stage {
+	case rebStageWaitAck, rebStageFin, rebStageFinStreams, rebStageDone, rebStageAbort:
+		return true
+	default: // (inactive, init, traverse)
+		return false
+	}
+}
+
+///////////////
+// drainWalk //
+///////////////
+
+func (w *drainWalk) run(rargs *rebArgs) {
+	var (
+		wg  = &sync.WaitGroup{}
+		bmd = core.T.Bowner().Get()
+	)
+	for _, mi := range rargs.apaths {
+		wg.Add(1)
+		go func(mi *fs.Mountpath) {
+			opts := &fs.WalkOpts{Mi: mi, CTs: []string{fs.ObjectType}, Callback: w.visit}
+			bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+				if bck.Props.EC.Enabled {
+					return false
+				}
+				opts.Bck.Copy(bck.Bucket())
+				if err := fs.Walk(opts); err != nil {
+					if w.xreb.AbortErr() == nil {
+						nlog.Errorln(core.T.String(), "drain: failed to traverse", bck.String(), err)
+					}
+				}
+				return w.xreb.AbortErr() != nil
+			})
+			wg.Done()
+		}(mi)
+	}
+	wg.Wait()
+}
+
+func (w *drainWalk) visit(fqn string, de fs.DirEntry) error {
+	if err := w.xreb.AbortErr(); err != nil {
+		return err
+	}
+	if de.IsDir() {
+		return nil
+	}
+	lom := core.AllocLOM(fqn)
+	err := w._visit(lom, fqn)
+	core.FreeLOM(lom)
+	return err
+}
+
+func (w *drainWalk) _visit(lom *core.LOM, fqn string) error {
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		if cmn.IsErrBucketLevel(err) {
+			return err
+		}
+		return nil
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil || lom.IsCopy() {
+		return nil
+	}
+	return w.cb(lom)
+}
@@ -0,0 +1,71 @@
+// Package reb provides global cluster-wide rebalance upon adding/removing storage nodes.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drain", func() {
+	Describe("_remaining", func() {
+		It("should compute remaining objects and bytes", func() {
+			st := &cmn.DrainStatus{TotalObjs: 10, TotalBytes: 10 * cos.MiB, SentObjs: 4, SentBytes: 4 * cos.MiB}
+			_remaining(st, 0 /*not started*/)
+			Expect(st.RemObjs).To(Equal(int64(6)))
+			Expect(st.RemBytes).To(Equal(int64(6 * cos.MiB)))
+			Expect(st.Throughput).To(BeZero())
+			Expect(st.ETA).To(BeZero())
+		})
+		It("should never go negative", func() {
+			// (objects written during drain get sent as well)
+			st := &cmn.DrainStatus{TotalObjs: 10, TotalBytes: cos.MiB, SentObjs: 11, SentBytes: 2 * cos.MiB}
+			_remaining(st, mono.NanoTime()-int64(10*time.Second))
+			Expect(st.RemObjs).To(BeZero())
+			Expect(st.RemBytes).To(BeZero())
+			Expect(st.ETA).To(BeZero())
+		})
+		It("should not estimate during the first second or before sending anything", func() {
+			st := &cmn.DrainStatus{TotalObjs: 10, TotalBytes: 10 * cos.MiB, SentObjs: 4, SentBytes: 4 * cos.MiB}
+			_remaining(st, mono.NanoTime())
+			Expect(st.Throughput).To(BeZero())
+			Expect(st.ETA).To(BeZero())
+
+			st = &cmn.DrainStatus{TotalObjs: 10, TotalBytes: 10 * cos.MiB}
+			_remaining(st, mono.NanoTime()-int64(10*time.Second))
+			Expect(st.Throughput).To(BeZero())
+			Expect(st.ETA).To(BeZero())
+		})
+		It("should compute throughput and ETA", func() {
+			st := &cmn.DrainStatus{TotalObjs: 300, TotalBytes: 300 * cos.MiB, SentObjs: 100, SentBytes: 100 * cos.MiB}
+			_remaining(st, mono.NanoTime()-int64(10*time.Second))
+			Expect(st.RemBytes).To(Equal(int64(200 * cos.MiB)))
+			Expect(st.Throughput).To(BeNumerically("~", 10*cos.MiB, cos.MiB/10))
+			Expect(st.ETA.D()).To(BeNumerically("~", 20*time.Second, time.Second))
+		})
+	})
+
+	DescribeTable("_drained",
+		func(status Status, id int64, expected bool) {
+			Expect(_drained(&status, id)).To(Equal(expected))
+		},
+		Entry("previous rebalance", Status{RebID: 1, Stage: rebStageDone}, int64(2), false),
+		Entry("next rebalance", Status{RebID: 3, Stage: rebStageInit}, int64(2), true),
+		Entry("inactive", Status{RebID: 2, Stage: rebStageInactive}, int64(2), false),
+		Entry("init", Status{RebID: 2, Stage: rebStageInit}, int64(2), false),
+		Entry("traversing", Status{RebID: 2, Stage: rebStageTraverse}, int64(2), false),
+		Entry("traversing (aborted)", Status{RebID: 2, Stage: rebStageTraverse, Aborted: true}, int64(2), true),
+		Entry("waiting for ACKs", Status{RebID: 2, Stage: rebStageWaitAck}, int64(2), true),
+		Entry("fin", Status{RebID: 2, Stage: rebStageFin}, int64(2), true),
+		Entry("fin-streams", Status{RebID: 2, Stage: rebStageFinStreams}, int64(2), true),
+		Entry("done", Status{RebID: 2, Stage: rebStageDone}, int64(2), true),
+		Entry("abort", Status{RebID: 2, Stage: rebStageAbort}, int64(2), true),
+	)
+})
@@ -79,6 +79,7 @@ type (
 		ecClient  *http.Client
 		stages    *nodeStages
 		lomacks   [cos.MultiSyncMapCount]*lomAcks
+		drain     drainer // when this target is leaving (see drain.go)
 		awaiting  struct {
 			targets meta.Nodes // targets for which we are waiting for
 			ts      int64      // last time we have recomputed
@@ -108,11 +109,12 @@ type (
 		ver  int64
 	}
 	rebArgs struct {
-		smap   *meta.Smap
-		config *cmn.Config
-		apaths fs.MPI
-		id     int64
-		ecUsed bool
+		smap    *meta.Smap
+		config  *cmn.Config
+		apaths  fs.MPI
+		id      int64
+		ecUsed  bool
+		leaving bool // this target is being drained (maintenance or decommission)
 	}
 )
 
@@ -226,6 +228,11 @@ func (reb *Reb) RunRebalance(smap *meta.Smap, id int64, notif *xact.NotifXact, t
 
 	tstats.SetFlag(stats.NodeStateFlags, cos.Rebalancing)
 
+	if self := smap.GetTarget(core.T.SID()); self != nil && isLeaving(smap, self) {
+		rargs.leaving = true
+		reb.drainBegin(rargs)
+	}
+
 	errCnt := 0
 	err := reb.run(rargs)
 	if err == nil {
@@ -239,9 +246,24 @@ func (reb *Reb) RunRebalance(smap *meta.Smap, id int64, notif *xact.NotifXact, t
 		errCnt = bcast(rargs, reb.waitFinExtended)
 	}
 
+	if rargs.leaving && err == nil && !reb.xctn().IsAborted() {
+		if errV := reb.drainVerify(rargs); errV != nil {
+			nlog.Errorln(errV)
+			reb.xctn().Abort(errV) // (the node won't be marked safe to power off)
+		}
+	}
+
 	reb.fini(rargs, logHdr, err)
 	tstats.SetClrFlag(stats.NodeStateFlags, 0, cos.Rebalancing)
 
+	if rargs.leaving {
+		if errA := reb.xctn().AbortErr(); errA != nil {
+			reb.drainStage(cmn.DrainAborted, errA)
+		} else {
+			reb.drainStage(cmn.DrainDone, nil)
+		}
+	}
+
 	offGFN()
 }
 
@@ -527,6 +549,16 @@ func (reb *Reb) runNoEC(rargs *rebArgs) error {
 		nlog.Errorln(logHdr, "rx-ready num-fail", errCnt) // unlikely
 	}
 
+	// prioritize leaving targets (their objects are moving)
+	if !rargs.leaving {
+		if leaving := reb.leavingTs(rargs.smap); len(leaving) > 0 {
+			reb.waitDrained(rargs, leaving)
+			if err := xreb.AbortErr(); err != nil {
+				return err
+			}
+		}
+	}
+
 	wg := &sync.WaitGroup{}
 	ver := rargs.smap.Version
 	for _, mi := range rargs.apaths {
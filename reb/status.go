@@ -25,6 +25,7 @@ func (reb *Reb) RebStatus(status *Status) {
 	)
 	status.Aborted = marked.Interrupted
 	status.Running = marked.Xact != nil && marked.Xact.Running()
+	status.Drain = reb.DrainStatus()
 
 	// rlock
 	reb.mu.RLock()
@@ -347,7 +347,7 @@ func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.ArchIdxType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -410,6 +410,16 @@ func (j *clnJ) visitCT(parsedFQN *fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.ArchIdxType:
+		// archive indexes: remove those that are stale or have no corresponding shard
+		ct, err := core.NewCTFromFQN(fqn, nil)
+		if err != nil {
+			j.oldWork = append(j.oldWork, fqn)
+			return
+		}
+		if idx, err := core.LoadArchIdx(ct.Make(fs.ObjectType), fqn); err != nil || idx == nil {
+			j.oldWork = append(j.oldWork, fqn)
+		}
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
@@ -328,6 +328,9 @@ func (j *lruJ) _visit(lom *core.LOM) (pushed bool) {
 	if lom.HasCopies() && lom.IsCopy() {
 		return
 	}
+	if _, ok := lom.GetCustomKey(cmn.WritebackObjMD); ok {
+		return // never evict objects pending write-back
+	}
 	// do nothing if the heap's curSize >= totalSize and
 	// the file is more recent then the the heap's newest.
 	if j.curSize >= j.totalSize && lom.AtimeUnix() > j.newest {
@@ -432,6 +435,13 @@ func (j *lruJ) _throttle(usedPct int64) (err error) {
 // remove local copies that "belong" to different LRU joggers (space accounting may be temporarily not precise)
 func (j *lruJ) evictObj(lom *core.LOM) bool {
 	lom.Lock(true)
+	// re-check write-back under lock (the object may have been overwritten since visited)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		if _, ok := lom.GetCustomKey(cmn.WritebackObjMD); ok {
+			lom.Unlock(true)
+			return false
+		}
+	}
 	err := lom.RemoveObj()
 	lom.Unlock(true)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -50,13 +51,19 @@ type (
 		GetMetricNames() cos.StrKVs // (name, kind) pairs
 
 		RegExtMetric(node *meta.Snode, name, kind string)
+
+		// per-bucket data-path metrics (see feat.BucketMetrics)
+		AddBck(bck *cmn.Bck, nvs ...cos.NamedVal64)
+		IncBckErr(bck *cmn.Bck, metric string)
 	}
 
 	// REST API
 	Node struct {
-		Snode   *meta.Snode `json:"snode"`
-		Tracker copyTracker `json:"tracker"`
-		Tcdf    fs.Tcdf     `json:"capacity"`
+		Snode   *meta.Snode          `json:"snode"`
+		Tracker copyTracker          `json:"tracker"`
+		Tcdf    fs.Tcdf              `json:"capacity"`
+		Buckets BckTracker           `json:"buckets,omitempty"` // see feat.BucketMetrics
+		Hists   map[string]*HistSnap `json:"hists,omitempty"`   // latency histograms (cumulative)
 	}
 	Cluster struct {
 		Proxy  *Node            `json:"proxy"`
@@ -84,6 +91,9 @@ type (
 		SmapVersion    int64          `json:"smap_version,string"`
 		Reserved3      int64          `json:"reserved3,omitempty"`
 		Reserved4      int64          `json:"reserved4,omitempty"`
+		// phi-accrual keepalive tracker: peer ID => suspicion level
+		// (primary: all other nodes; others: primary)
+		KeepalivePhi map[string]float64 `json:"keepalive_phi,omitempty"`
 	}
 )
 
@@ -0,0 +1,298 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"sync"
+	ratomic "sync/atomic"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+)
+
+// Per-bucket data-path metrics ----------------------------------------------------------
+// - enabled via feat.BucketMetrics (cluster-wide feature flag);
+// - a subset of the node-level metrics: counters, sizes, cumulative latencies, and errors;
+// - all values are cumulative (monotonic) - compute rates and average latencies
+//   as (delta total latency) / (delta count), etc.
+// - cardinality is bounded: at most maxBckSlots buckets at any given time, with
+//   all the rest accounted for under BckOther;
+// - slots go to the most active buckets: every stats interval, buckets without a slot
+//   that are busier (recently) than the least busy slotted ones take their slots;
+// - buckets that remain idle for bckSlotIdle stats intervals release their slots;
+// - upon releasing a slot, its (cumulative) values are folded into BckOther.
+// ---------------------------------------------------------------------------------------
+
+const (
+	maxBckSlots = 32
+	maxBckCands = 4 * maxBckSlots // max buckets (without slots) tracked for recent activity
+	bckSlotIdle = 10              // num 'periodic.stats_time' intervals
+
+	// (bucket, namespace) label values of the catch-all slot
+	BckOther = "other"
+
+	bckMetricPrefix = "bucket."
+
+	// Prometheus variable labels
+	bckBucketLabel = "bucket"
+	bckNsLabel     = "namespace"
+)
+
+// per-bucket metrics (the names are the same as their node-level counterparts)
+var bckMetrics = [...]string{
+	GetCount,
+	GetSize,
+	GetLatencyTotal,
+	PutCount,
+	PutSize,
+	PutLatencyTotal,
+	DeleteCount,
+	errPrefix + GetCount,
+	errPrefix + PutCount,
+	errPrefix + DeleteCount,
+}
+
+type (
+	// REST API: bucket cname (or BckOther) => (metric name => cumulative value)
+	BckTracker map[string]map[string]int64
+
+	bckKey struct {
+		ns       cmn.Ns
+		name     string
+		provider string
+	}
+	// recent activity: number of updates during the current stats interval (act),
+	// and exponentially decaying (halved every interval) total (score)
+	bckActivity struct {
+		act   int64
+		score int64
+	}
+	bckSlot struct {
+		bck  cmn.Bck
+		vals [len(bckMetrics)]int64
+		bckActivity
+		idle int // consecutive idle intervals
+	}
+	// bucket without slot (accounted for under BckOther)
+	bckCand struct {
+		bck cmn.Bck
+		bckActivity
+	}
+	bckStats struct {
+		slots map[bckKey]*bckSlot
+		cands map[bckKey]*bckCand
+		other bckSlot
+		mu    sync.RWMutex
+	}
+)
+
+func bckMetricIdx(name string) int {
+	for i := range bckMetrics {
+		if bckMetrics[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func newBckStats() *bckStats {
+	bs := &bckStats{
+		slots: make(map[bckKey]*bckSlot, maxBckSlots),
+		cands: make(map[bckKey]*bckCand, maxBckSlots),
+	}
+	bs.other.bck = cmn.Bck{Name: BckOther}
+	return bs
+}
+
+func (bs *bckStats) add(bck *cmn.Bck, nvs []cos.NamedVal64) {
+	key := bckKey{ns: bck.Ns, name: bck.Name, provider: bck.Provider}
+
+	// fast path (note: slots are released only under write lock)
+	bs.mu.RLock()
+	if s, ok := bs.slots[key]; ok {
+		s.add(nvs)
+		bs.mu.RUnlock()
+		return
+	}
+	if c, ok := bs.cands[key]; ok {
+		ratomic.AddInt64(&c.act, 1)
+		bs.other.add(nvs)
+		bs.mu.RUnlock()
+		return
+	}
+	bs.mu.RUnlock()
+
+	// new bucket
+	bs.mu.Lock()
+	s, ok := bs.slots[key]
+	switch {
+	case ok:
+	case len(bs.slots) < maxBckSlots:
+		s = &bckSlot{bck: cmn.Bck{Name: bck.Name, Provider: bck.Provider, Ns: bck.Ns}}
+		bs.slots[key] = s
+	default:
+		s = &bs.other
+		if c, ok := bs.cands[key]; ok {
+			ratomic.AddInt64(&c.act, 1)
+		} else if len(bs.cands) < maxBckCands {
+			c = &bckCand{bck: cmn.Bck{Name: bck.Name, Provider: bck.Provider, Ns: bck.Ns}}
+			c.act = 1
+			bs.cands[key] = c
+		}
+	}
+	s.add(nvs)
+	bs.mu.Unlock()
+}
+
+// is called every stats interval: update recent activity, release idle slots,
+// and give slots to the busiest buckets
+func (bs *bckStats) housekeep() {
+	bs.mu.Lock()
+	for key, s := range bs.slots {
+		if s.decay() != 0 {
+			s.idle = 0
+			continue
+		}
+		if s.idle++; s.idle >= bckSlotIdle {
+			bs.release(key, s)
+		}
+	}
+	for key, c := range bs.cands {
+		if c.decay(); c.score == 0 {
+			delete(bs.cands, key)
+		}
+	}
+	for len(bs.cands) > 0 {
+		ckey, c := bs.busiest()
+		if len(bs.slots) >= maxBckSlots {
+			skey, s := bs.leastBusy()
+			if s.score >= c.score {
+				break
+			}
+			bs.release(skey, s)
+		}
+		delete(bs.cands, ckey)
+		bs.slots[ckey] = &bckSlot{bck: c.bck, bckActivity: bckActivity{score: c.score}}
+	}
+	bs.mu.Unlock()
+}
+
+// (under write lock)
+func (bs *bckStats) release(key bckKey, s *bckSlot) {
+	for i := range s.vals {
+		bs.other.vals[i] += s.vals[i]
+	}
+	delete(bs.slots, key)
+}
+
+func (bs *bckStats) busiest() (key bckKey, c *bckCand) {
+	for k, v := range bs.cands {
+		if c == nil || v.score > c.score {
+			key, c = k, v
+		}
+	}
+	return key, c
+}
+
+func (bs *bckStats) leastBusy() (key bckKey, s *bckSlot) {
+	for k, v := range bs.slots {
+		if s == nil || v.score < s.score {
+			key, s = k, v
+		}
+	}
+	return key, s
+}
+
+// visit all (non-zero) slots including BckOther
+// (under read lock, given that releasing a slot updates BckOther)
+func (bs *bckStats) visit(cb func(s *bckSlot)) {
+	bs.mu.RLock()
+	for _, s := range bs.slots {
+		cb(s)
+	}
+	if bs.other.nonzero() {
+		cb(&bs.other)
+	}
+	bs.mu.RUnlock()
+}
+
+func (bs *bckStats) copy() BckTracker {
+	out := make(BckTracker, 8)
+	bs.visit(func(s *bckSlot) {
+		vals := make(map[string]int64, len(bckMetrics))
+		for i, name := range bckMetrics {
+			if v := ratomic.LoadInt64(&s.vals[i]); v != 0 {
+				vals[name] = v
+			}
+		}
+		if len(vals) > 0 {
+			out[s.cname()] = vals
+		}
+	})
+	return out
+}
+
+func (bs *bckStats) reset(errorsOnly bool) {
+	bs.visit(func(s *bckSlot) {
+		for i, name := range bckMetrics {
+			if !errorsOnly || IsErrMetric(name) {
+				ratomic.StoreInt64(&s.vals[i], 0)
+			}
+		}
+	})
+}
+
+/////////////////
+// bckActivity //
+/////////////////
+
+// returns the number of updates during the interval that has just ended
+func (a *bckActivity) decay() int64 {
+	act := ratomic.SwapInt64(&a.act, 0)
+	a.score = a.score/2 + act
+	return act
+}
+
+/////////////
+// bckSlot //
+/////////////
+
+func (s *bckSlot) add(nvs []cos.NamedVal64) {
+	for _, nv := range nvs {
+		if i := bckMetricIdx(nv.Name); i >= 0 {
+			ratomic.AddInt64(&s.vals[i], nv.Value)
+		}
+	}
+	ratomic.AddInt64(&s.act, 1)
+}
+
+func (s *bckSlot) nonzero() bool {
+	for i := range s.vals {
+		if ratomic.LoadInt64(&s.vals[i]) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *bckSlot) cname() string {
+	if s.bck.Name == BckOther && s.bck.Provider == "" {
+		return BckOther
+	}
+	return s.bck.Cname("")
+}
+
+// (bucket, namespace) labels, e.g. ("s3://abc", "") or ("ais://abc", "@uuid#ns")
+func (s *bckSlot) labels() (bucket, namespace string) {
+	if s.bck.Name == BckOther && s.bck.Provider == "" {
+		return BckOther, BckOther
+	}
+	return apc.ToScheme(s.bck.Provider) + apc.BckProviderSeparator + s.bck.Name, s.bck.Ns.String()
+}
+
+func bckMetricsEnabled() bool { return cmn.Rom.Features().IsSet(feat.BucketMetrics) }
@@ -0,0 +1,157 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+func TestBckStatsBounded(t *testing.T) {
+	bs := newBckStats()
+	get := []cos.NamedVal64{{Name: GetCount, Value: 1}, {Name: GetSize, Value: 10}}
+	for i := range maxBckSlots + 5 {
+		bck := cmn.Bck{Name: "b" + strconv.Itoa(i), Provider: apc.AIS}
+		bs.add(&bck, get)
+	}
+	out := bs.copy()
+	if len(out) != maxBckSlots+1 {
+		t.Fatalf("expected %d entries, got %d", maxBckSlots+1, len(out))
+	}
+	if n := out[BckOther][GetCount]; n != 5 {
+		t.Fatalf("expected %q to have 5 GETs, got %d", BckOther, n)
+	}
+	if sz := out["ais://b0"][GetSize]; sz != 10 {
+		t.Fatalf("expected size 10, got %d", sz)
+	}
+
+	// idle buckets release their slots
+	busy := cmn.Bck{Name: "b1", Provider: apc.AIS}
+	for range bckSlotIdle + 1 {
+		bs.add(&busy, get)
+		bs.housekeep()
+	}
+	if out = bs.copy(); len(out) != 2 {
+		t.Fatalf("expected (busy, other), got %v", out)
+	}
+	// released slots fold into "other"
+	if n := out[BckOther][GetCount]; n != maxBckSlots+4 {
+		t.Fatalf("expected %q to have %d GETs, got %d", BckOther, maxBckSlots+4, n)
+	}
+	bs.add(&cmn.Bck{Name: "new", Provider: apc.AWS}, get)
+	if out = bs.copy(); out["s3://new"][GetCount] != 1 {
+		t.Fatalf("expected new bucket to get a slot, got %v", out)
+	}
+
+	bs.reset(false)
+	if out = bs.copy(); len(out) != 0 {
+		t.Fatalf("expected no stats after reset, got %v", out)
+	}
+}
+
+func TestBckStatsRanked(t *testing.T) {
+	bs := newBckStats()
+	get := []cos.NamedVal64{{Name: GetCount, Value: 1}}
+	for i := range maxBckSlots {
+		bck := cmn.Bck{Name: "b" + strconv.Itoa(i), Provider: apc.AIS}
+		bs.add(&bck, get)
+		if i > 0 {
+			bs.add(&bck, get)
+		}
+	}
+	// no free slots: late but busy bucket
+	late := cmn.Bck{Name: "late", Provider: apc.AIS}
+	for range 10 {
+		bs.add(&late, get)
+	}
+	out := bs.copy()
+	if _, ok := out["ais://late"]; ok || out[BckOther][GetCount] != 10 {
+		t.Fatalf("expected %q to be accounted under %q, got %v", late.Name, BckOther, out)
+	}
+
+	// takes the slot of the least busy bucket, whose values are then folded into "other"
+	bs.housekeep()
+	out = bs.copy()
+	if len(out) != maxBckSlots { // (the new slot has no values yet)
+		t.Fatalf("expected %d entries, got %d", maxBckSlots, len(out))
+	}
+	if _, ok := out["ais://b0"]; ok {
+		t.Fatalf("expected least busy bucket to release its slot, got %v", out)
+	}
+	if n := out[BckOther][GetCount]; n != 11 {
+		t.Fatalf("expected %q to have 11 GETs, got %d", BckOther, n)
+	}
+	bs.add(&late, get)
+	if n := bs.copy()["ais://late"][GetCount]; n != 1 {
+		t.Fatalf("expected %q to have a slot, got %d GETs", late.Name, n)
+	}
+
+	// total is preserved
+	var total int64
+	for _, vals := range bs.copy() {
+		total += vals[GetCount]
+	}
+	if exp := int64(2*maxBckSlots - 1 + 11); total != exp {
+		t.Fatalf("expected total %d GETs, got %d", exp, total)
+	}
+}
+
+// (run with -race)
+func TestBckStatsConcurrent(t *testing.T) {
+	var (
+		bs   = newBckStats()
+		get  = []cos.NamedVal64{{Name: GetCount, Value: 1}}
+		wg   sync.WaitGroup
+		stop = make(chan struct{})
+	)
+	for i := range 4 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; ; j++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				bck := cmn.Bck{Name: "b" + strconv.Itoa(i*1000+j%(2*maxBckSlots)), Provider: apc.AIS}
+				bs.add(&bck, get)
+			}
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			bs.copy()
+		}
+	}()
+	for range 4 * bckSlotIdle {
+		bs.housekeep()
+		time.Sleep(time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+
+	var total int64
+	for _, vals := range bs.copy() {
+		total += vals[GetCount]
+	}
+	if total == 0 {
+		t.Fatal("expected non-zero GET count")
+	}
+}
@@ -117,6 +117,7 @@ type (
 		Value      int64 `json:"v,string"`
 		numSamples int64 // (log + StatsD) only
 		cumulative int64
+		hist       *histogram // optional, KindLatency only (see histogram.go)
 	}
 	copyValue struct {
 		Value int64 `json:"v,string"`
@@ -132,6 +133,7 @@ type (
 		ticker    *time.Ticker
 		core      *coreStats
 		ctracker  copyTracker // to avoid making it at runtime
+		bcks      *bckStats   // per-bucket metrics (target only; see bucket_stats.go)
 		sorted    []string    // sorted names
 		name      string      // this stats-runner's name
 		prev      string      // prev ctracker.write
@@ -157,10 +159,22 @@ func (r *runner) IncNonIOErr() { ratomic.AddInt64(&r.nonIOErr, 1) }
 
 func (r *runner) InitPrometheus(snode *meta.Snode) {
 	r.core.initProm(snode)
+	if r.bcks != nil {
+		r.core.initBck(snode)
+	}
 }
 
 func (r *runner) RegExtMetric(snode *meta.Snode, name, kind string) { r.reg(snode, name, kind) }
 
+// additionally track already registered latency metric(s) as histogram(s)
+func (r *runner) regHist(bounds []int64, names ...string) {
+	for _, name := range names {
+		v, ok := r.core.Tracker[name]
+		debug.Assert(ok && v.kind == KindLatency, name)
+		v.hist = newHistogram(bounds)
+	}
+}
+
 // common (target, proxy) metrics
 func (r *runner) regCommon(snode *meta.Snode) {
 	// basic counters
@@ -223,6 +237,23 @@ func (r *runner) AddMany(nvs ...cos.NamedVal64) {
 	}
 }
 
+// per-bucket metrics (no-op unless feat.BucketMetrics)
+func (r *runner) AddBck(bck *cmn.Bck, nvs ...cos.NamedVal64) {
+	if r.bcks != nil && bckMetricsEnabled() {
+		r.bcks.add(bck, nvs)
+	}
+}
+
+func (r *runner) IncBckErr(bck *cmn.Bck, metric string) {
+	if r.bcks == nil || !bckMetricsEnabled() {
+		return
+	}
+	if !IsErrMetric(metric) {
+		metric = errPrefix + metric
+	}
+	r.bcks.add(bck, []cos.NamedVal64{{Name: metric, Value: 1}})
+}
+
 func (r *runner) SetFlag(name string, set cos.NodeStateFlags) {
 	v := r.core.Tracker[name]
 	oval := cos.BitFlags(ratomic.LoadInt64(&v.Value))
@@ -382,7 +413,19 @@ func (r *runner) _mem(mm *memsys.MMSA, set, clr cos.NodeStateFlags) {
 func (r *runner) GetStats() *Node {
 	ctracker := make(copyTracker, 48)
 	r.core.copyCumulative(ctracker)
-	return &Node{Tracker: ctracker}
+	ds := &Node{Tracker: ctracker}
+	for name, v := range r.core.Tracker {
+		if v.hist != nil {
+			if ds.Hists == nil {
+				ds.Hists = make(map[string]*HistSnap, 8)
+			}
+			ds.Hists[name] = v.hist.snap()
+		}
+	}
+	if r.bcks != nil {
+		ds.Buckets = r.bcks.copy()
+	}
+	return ds
 }
 
 func (r *runner) GetStatsV322() (out *NodeV322) {
@@ -396,6 +439,9 @@ func (r *runner) GetStatsV322() (out *NodeV322) {
 
 func (r *runner) ResetStats(errorsOnly bool) {
 	r.core.reset(errorsOnly)
+	if r.bcks != nil {
+		r.bcks.reset(errorsOnly)
+	}
 }
 
 func (r *runner) GetMetricNames() cos.StrKVs {
@@ -110,9 +110,61 @@ func (s *coreStats) initProm(snode *meta.Snode) {
 		fullqn := prometheus.BuildFQName("ais", snode.Type(), v.label.stpr)
 		// e.g. metric: ais_target_disk_avg_wsize{disk="nvme0n1",node_id="fqWt8081"}
 		s.promDesc[name] = prometheus.NewDesc(fullqn, help, variableLabels, prometheus.Labels{"node_id": id})
+
+		// e.g. ais_target_get_latency_seconds_bucket{le="0.005",node_id="fqWt8081"}
+		if v.hist != nil {
+			hname := strings.TrimSuffix(strings.ReplaceAll(name, ".", "_"), "_ns") + "_latency_seconds"
+			fullqn = prometheus.BuildFQName("ais", snode.Type(), hname)
+			s.promDesc[name+histSuffix] = prometheus.NewDesc(fullqn, "latency histogram (seconds)",
+				nil, prometheus.Labels{"node_id": id})
+		}
+	}
+}
+
+// cumulative histogram in seconds
+func (s *coreStats) collectHist(ch chan<- prometheus.Metric, name string, h *histogram) {
+	desc, ok := s.promDesc[name+histSuffix]
+	if !ok {
+		return
 	}
+	var (
+		snap    = h.snap()
+		buckets = make(map[float64]uint64, len(snap.Bounds))
+		cum     uint64
+	)
+	for i, bound := range snap.Bounds {
+		cum += uint64(snap.Counts[i])
+		buckets[float64(bound)/float64(time.Second)] = cum
+	}
+	cum += uint64(snap.Counts[len(snap.Bounds)])
+	m, err := prometheus.NewConstHistogram(desc, cum, float64(snap.Sum)/float64(time.Second), buckets)
+	debug.AssertNoErr(err)
+	ch <- m
 }
 
+// per-bucket metrics, e.g.: ais_target_bucket_get_n{bucket="s3://abc",namespace="",node_id="fqWt8081"}
+func (s *coreStats) initBck(snode *meta.Snode) {
+	id := strings.ReplaceAll(snode.ID(), ".", "_")
+	for _, name := range bckMetrics {
+		var (
+			label = strings.ReplaceAll(bckMetricPrefix+name, ".", "_")
+			help  = "total number of operations"
+		)
+		switch {
+		case strings.HasSuffix(label, "_size"):
+			help = "total size (bytes)"
+		case strings.HasSuffix(label, "_ns_total"):
+			help = "cumulative latency (nanoseconds)"
+		}
+		fullqn := prometheus.BuildFQName("ais", snode.Type(), label)
+		s.promDesc[bckMetricPrefix+name] = prometheus.NewDesc(fullqn, help,
+			[]string{bckBucketLabel, bckNsLabel}, prometheus.Labels{"node_id": id})
+	}
+}
+
+// StatsD only
+func (*coreStats) bckStatsd(*bckStats) {}
+
 func (s *coreStats) updateUptime(d time.Duration) {
 	v := s.Tracker[Uptime]
 	ratomic.StoreInt64(&v.Value, d.Nanoseconds())
@@ -133,7 +185,11 @@ func (s *coreStats) update(nv cos.NamedVal64) {
 	switch v.kind {
 	case KindLatency:
 		ratomic.AddInt64(&v.numSamples, 1)
-		fallthrough
+		ratomic.AddInt64(&v.Value, nv.Value)
+		ratomic.AddInt64(&v.cumulative, nv.Value)
+		if v.hist != nil {
+			v.hist.observe(nv.Value)
+		}
 	case KindThroughput:
 		ratomic.AddInt64(&v.Value, nv.Value)
 		ratomic.AddInt64(&v.cumulative, nv.Value)
@@ -241,6 +297,9 @@ func (s *coreStats) reset(errorsOnly bool) {
 		switch v.kind {
 		case KindLatency:
 			ratomic.StoreInt64(&v.numSamples, 0)
+			if v.hist != nil {
+				v.hist.reset()
+			}
 			fallthrough
 		case KindThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
@@ -357,8 +416,32 @@ func (r *runner) Collect(ch chan<- prometheus.Metric) {
 		m, err := prometheus.NewConstMetric(desc, promMetricType, fv, variableLabels...)
 		debug.AssertNoErr(err)
 		ch <- m
+
+		if v.hist != nil {
+			r.core.collectHist(ch, name, v.hist)
+		}
 	}
 	r.core.promRUnlock()
+
+	if r.bcks != nil && bckMetricsEnabled() {
+		r.collectBck(ch)
+	}
+}
+
+func (r *runner) collectBck(ch chan<- prometheus.Metric) {
+	r.bcks.visit(func(s *bckSlot) {
+		bucket, namespace := s.labels()
+		for i, name := range bckMetrics {
+			desc, ok := r.core.promDesc[bckMetricPrefix+name]
+			if !ok {
+				return // (not initialized)
+			}
+			fv := float64(ratomic.LoadInt64(&s.vals[i]))
+			m, err := prometheus.NewConstMetric(desc, prometheus.CounterValue, fv, bucket, namespace)
+			debug.AssertNoErr(err)
+			ch <- m
+		}
+	})
 }
 
 // extractPromDiskMetricName returns prometheus friendly metrics name
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	ratomic "sync/atomic"
 	"time"
@@ -32,6 +33,7 @@ type (
 		Tracker   map[string]*statsValue
 		statsdC   *statsd.Client
 		sgl       *memsys.SGL
+		bckPrefix string // per-bucket metrics (see bckStatsd)
 		statsTime time.Duration
 	}
 )
@@ -97,6 +99,52 @@ func (s *coreStats) initMetricClient(snode *meta.Snode, _ *runner) {
 	s.statsdC = statsD
 }
 
+// per-bucket metrics, e.g.: aistarget.<id>.bucket.s3_abc.get.count
+func (s *coreStats) initBck(snode *meta.Snode) {
+	s.bckPrefix = fmt.Sprintf("%s.%s.%s", "ais"+snode.Type(), snode.ID(), strings.TrimSuffix(bckMetricPrefix, "."))
+}
+
+// StatsD breakdown: cumulative per-bucket counters, sizes, and latencies
+func (s *coreStats) bckStatsd(bs *bckStats) {
+	if s.statsdDisabled() || !bckMetricsEnabled() {
+		return
+	}
+	s.sgl.Reset()
+	bs.visit(func(slot *bckSlot) {
+		bname := _statsdBck(slot)
+		for i, name := range bckMetrics {
+			val := ratomic.LoadInt64(&slot.vals[i])
+			if val == 0 {
+				continue
+			}
+			var (
+				comm = name
+				unit = "count"
+			)
+			switch {
+			case strings.HasSuffix(name, ".size"):
+				comm, unit = strings.TrimSuffix(name, ".size"), "bytes"
+			case strings.HasSuffix(name, ".ns.total"):
+				comm, unit = strings.TrimSuffix(name, ".ns.total"), "ns"
+			default:
+				comm = strings.TrimSuffix(name, ".n")
+			}
+			m := metric{Type: statsd.Counter, Name: s.bckPrefix + "." + bname + "." + comm + "." + unit, Value: val}
+			s.statsdC.AppMetric(m, s.sgl)
+		}
+	})
+	s.statsdC.SendSGL(s.sgl)
+}
+
+// StatsD-safe bucket name: no '.' (separator) and no ':' (name-value delimiter)
+func _statsdBck(slot *bckSlot) string {
+	bucket, namespace := slot.labels()
+	if namespace != "" && namespace != BckOther {
+		bucket += "_" + namespace
+	}
+	return strings.NewReplacer(".", "_", ":", "_", "/", "_", "@", "", "#", "_").Replace(bucket)
+}
+
 func (s *coreStats) updateUptime(d time.Duration) {
 	v := s.Tracker[Uptime]
 	ratomic.StoreInt64(&v.Value, d.Nanoseconds())
@@ -117,7 +165,11 @@ func (s *coreStats) update(nv cos.NamedVal64) {
 	switch v.kind {
 	case KindLatency:
 		ratomic.AddInt64(&v.numSamples, 1)
-		fallthrough
+		ratomic.AddInt64(&v.Value, nv.Value)
+		ratomic.AddInt64(&v.cumulative, nv.Value)
+		if v.hist != nil {
+			v.hist.observe(nv.Value)
+		}
 	case KindThroughput:
 		ratomic.AddInt64(&v.Value, nv.Value)
 		ratomic.AddInt64(&v.cumulative, nv.Value)
@@ -156,6 +208,9 @@ func (s *coreStats) copyT(out copyTracker, diskLowUtil ...int64) bool {
 			if !s.statsdDisabled() && millis > 0 {
 				s.statsdC.AppMetric(metric{Type: statsd.Timer, Name: v.label.stpr, Value: float64(millis)}, s.sgl)
 			}
+			if !s.statsdDisabled() && v.hist != nil {
+				s.percentiles(v)
+			}
 		case KindThroughput:
 			var throughput int64
 			if throughput = ratomic.SwapInt64(&v.Value, 0); throughput > 0 {
@@ -223,6 +278,20 @@ func (s *coreStats) copyT(out copyTracker, diskLowUtil ...int64) bool {
 	return idle
 }
 
+// interval percentiles, e.g.: aistarget.<id>.get.p99.ms
+func (s *coreStats) percentiles(v *statsValue) {
+	snap := v.hist.interval()
+	if snap.Count() == 0 {
+		return
+	}
+	prefix := strings.TrimSuffix(v.label.stpr, ".ms")
+	for _, p := range Percentiles {
+		ms := float64(snap.Percentile(p)) / float64(time.Millisecond)
+		name := prefix + ".p" + strings.ReplaceAll(strconv.FormatFloat(p, 'f', -1, 64), ".", "") + ".ms"
+		s.statsdC.AppMetric(metric{Type: statsd.Timer, Name: name, Value: ms}, s.sgl)
+	}
+}
+
 // REST API what=stats query
 // NOTE: not reporting zero counts
 func (s *coreStats) copyCumulative(ctracker copyTracker) {
@@ -264,6 +333,9 @@ func (s *coreStats) reset(errorsOnly bool) {
 		switch v.kind {
 		case KindLatency:
 			ratomic.StoreInt64(&v.numSamples, 0)
+			if v.hist != nil {
+				v.hist.reset()
+			}
 			fallthrough
 		case KindThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
@@ -0,0 +1,178 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	ratomic "sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// Latency histograms ----------------------------------------------------------------
+// Selected KindLatency metrics (see `regHist`) are additionally tracked as cumulative
+// histograms with the bucket boundaries defined by 'periodic.latency_buckets'
+// (or dfltLatencyBuckets when not configured). Histograms are:
+// - exported as Prometheus histograms (in seconds), e.g. ais_target_get_latency_seconds;
+// - returned via REST API (see Node.Hists) to compute p50, p90, p99, and p99.9
+//   over any given interval - see `HistSnap.Percentile` and `ais show performance latency`.
+// Changing the bucket boundaries requires node restart.
+// ------------------------------------------------------------------------------------
+
+var dfltLatencyBuckets = [...]time.Duration{
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+// percentiles reported by CLI and StatsD
+var Percentiles = [...]float64{50, 90, 99, 99.9}
+
+const histSuffix = ".hist" // (internal) Prometheus descriptor
+
+// e.g. ("get.ns", 99.9) => "get.p999.ns"
+func PercentileName(name string, p float64) string {
+	s := strconv.FormatFloat(p, 'f', -1, 64)
+	return strings.TrimSuffix(name, ".ns") + ".p" + strings.ReplaceAll(s, ".", "") + ".ns"
+}
+
+type (
+	histogram struct {
+		bounds []int64 // upper bounds (inclusive, nanoseconds), in increasing order
+		counts []int64 // len(bounds) + 1 (the last one: +Inf)
+		sum    int64   // total nanoseconds
+		prev   HistSnap
+	}
+
+	// REST API: cumulative (non-decreasing) histogram snapshot
+	HistSnap struct {
+		Bounds []int64 `json:"bounds"` // nanoseconds
+		Counts []int64 `json:"counts"` // per bucket (non-cumulative); len(Bounds) + 1
+		Sum    int64   `json:"sum"`    // nanoseconds
+	}
+)
+
+// latency buckets (ns) from config, or defaults
+func latencyBuckets(config *cmn.Config) (bounds []int64) {
+	ds, err := cmn.ParseLatencyBuckets(config.Periodic.LatencyBuckets)
+	if err != nil {
+		nlog.Errorln(err, "- using defaults")
+		ds = nil
+	}
+	if len(ds) == 0 {
+		ds = dfltLatencyBuckets[:]
+	}
+	bounds = make([]int64, len(ds))
+	for i, d := range ds {
+		bounds[i] = d.Nanoseconds()
+	}
+	return bounds
+}
+
+func newHistogram(bounds []int64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]int64, len(bounds)+1)}
+}
+
+func (h *histogram) observe(ns int64) {
+	i := sort.Search(len(h.bounds), func(i int) bool { return h.bounds[i] >= ns })
+	ratomic.AddInt64(&h.counts[i], 1)
+	ratomic.AddInt64(&h.sum, ns)
+}
+
+func (h *histogram) snap() *HistSnap {
+	s := &HistSnap{Bounds: h.bounds, Counts: make([]int64, len(h.counts)), Sum: ratomic.LoadInt64(&h.sum)}
+	for i := range h.counts {
+		s.Counts[i] = ratomic.LoadInt64(&h.counts[i])
+	}
+	return s
+}
+
+// delta since the previous call (serial context: stats runner only)
+func (h *histogram) interval() *HistSnap {
+	curr := h.snap()
+	delta := curr.Sub(&h.prev)
+	h.prev = *curr
+	return delta
+}
+
+func (h *histogram) reset() {
+	for i := range h.counts {
+		ratomic.StoreInt64(&h.counts[i], 0)
+	}
+	ratomic.StoreInt64(&h.sum, 0)
+}
+
+//////////////
+// HistSnap //
+//////////////
+
+func (s *HistSnap) Count() (n int64) {
+	for _, c := range s.Counts {
+		n += c
+	}
+	return n
+}
+
+// s - prev, where both are cumulative snapshots of the same histogram
+func (s *HistSnap) Sub(prev *HistSnap) *HistSnap {
+	out := &HistSnap{Bounds: s.Bounds, Counts: make([]int64, len(s.Counts)), Sum: max(s.Sum-prev.Sum, 0)}
+	copy(out.Counts, s.Counts)
+	if len(prev.Counts) == len(s.Counts) {
+		for i, c := range prev.Counts {
+			out.Counts[i] = max(out.Counts[i]-c, 0)
+		}
+	}
+	return out
+}
+
+// estimate p-th percentile (0 < p < 100) via linear interpolation within the bucket;
+// returns zero when empty and the largest bound when in the +Inf bucket
+func (s *HistSnap) Percentile(p float64) int64 {
+	total := s.Count()
+	if total == 0 || len(s.Bounds) == 0 {
+		return 0
+	}
+	var (
+		rank = p / 100 * float64(total)
+		cum  float64
+	)
+	for i, c := range s.Counts {
+		if c == 0 {
+			continue
+		}
+		if cum+float64(c) < rank {
+			cum += float64(c)
+			continue
+		}
+		if i == len(s.Bounds) {
+			return s.Bounds[i-1]
+		}
+		var lower int64
+		if i > 0 {
+			lower = s.Bounds[i-1]
+		}
+		frac := (rank - cum) / float64(c)
+		return lower + int64(frac*float64(s.Bounds[i]-lower))
+	}
+	return s.Bounds[len(s.Bounds)-1]
+}
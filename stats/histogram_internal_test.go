@@ -0,0 +1,54 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogramPercentiles(t *testing.T) {
+	ms := int64(time.Millisecond)
+	h := newHistogram([]int64{ms, 10 * ms, 100 * ms})
+
+	// 90 fast, 9 medium, and 1 very slow
+	for range 90 {
+		h.observe(ms / 2)
+	}
+	for range 9 {
+		h.observe(50 * ms)
+	}
+	h.observe(time.Second.Nanoseconds())
+
+	snap := h.snap()
+	if n := snap.Count(); n != 100 {
+		t.Fatalf("expected 100 samples, got %d", n)
+	}
+	if p := snap.Percentile(50); p <= 0 || p > ms {
+		t.Errorf("p50: expected (0, 1ms], got %v", time.Duration(p))
+	}
+	if p := snap.Percentile(99); p <= 10*ms || p > 100*ms {
+		t.Errorf("p99: expected (10ms, 100ms], got %v", time.Duration(p))
+	}
+	if p := snap.Percentile(99.9); p != 100*ms {
+		t.Errorf("p99.9: expected the largest bound (+Inf bucket), got %v", time.Duration(p))
+	}
+
+	// interval: only new samples
+	h.interval()
+	h.observe(5 * ms)
+	delta := h.interval()
+	if n := delta.Count(); n != 1 {
+		t.Fatalf("expected 1 sample in the interval, got %d", n)
+	}
+	if p := delta.Percentile(50); p <= ms || p > 10*ms {
+		t.Errorf("interval p50: expected (1ms, 10ms], got %v", time.Duration(p))
+	}
+
+	if name := PercentileName(GetLatency, 99.9); name != "get.p999.ns" {
+		t.Errorf("unexpected percentile name %q", name)
+	}
+}
@@ -47,6 +47,40 @@ const (
 	VerChangeCount = "ver.change.n"
 	VerChangeSize  = "ver.change.size"
 
+	// readahead (sequential-access prefetch, see bucket property 'readahead.window'):
+	// - hit:   GET of a read-ahead object
+	// - miss:  cold GET in a detected sequential stream (not predicted or not read-ahead in time)
+	// - waste: read-ahead object that was not read within the (readahead) time-to-live
+	ReadaheadHitCount   = "readahead.hit.n"
+	ReadaheadMissCount  = "readahead.miss.n"
+	ReadaheadWasteCount = "readahead.waste.n"
+
+	// archive index (see feat.ArchIndex): shards not indexed upon PUT (APPEND, etc.) - too many pending
+	ArchIdxSkipCount = "arch.idx.skip.n"
+
+	// write-back (asynchronous PUT to remote backend, see bucket property 'write_back'):
+	// - objects uploaded (and their total size)
+	// - failed upload attempts (to be retried)
+	// - objects that failed to upload (permanently or too many times) - not to be retried
+	// - current number of objects pending upload (KindGauge)
+	WritebackCount        = "wb.put.n"
+	WritebackSize         = "wb.put.size"
+	ErrWritebackCount     = "err.wb.put.n"
+	ErrWritebackFailCount = "err.wb.fail.n"
+	WritebackPending      = "wb.pending"
+
+	// replication (bucket property 'replication'):
+	// - objects replicated (and their total size), deletions propagated
+	// - failed attempts (to be retried)
+	// - backlog: number of objects pending replication, and replication lag - the average
+	//   age of pending mutations (KindGauge, milliseconds)
+	ReplPutCount = "repl.put.n"
+	ReplPutSize  = "repl.put.size"
+	ReplDelCount = "repl.del.n"
+	ErrReplCount = "err.repl.n"
+	ReplPending  = "repl.pending"
+	ReplLag      = "repl.lag.ms"
+
 	// errors
 	ErrCksumCount = "err.cksum.n"
 	ErrCksumSize  = "err.cksum.size"
@@ -91,6 +125,9 @@ const (
 	LcacheEvictedCount   = core.LcacheEvictedCount
 	LcacheFlushColdCount = core.LcacheFlushColdCount
 
+	ECEncodeLatency = core.ECEncodeLatency
+	ETLLatency      = core.ETLLatency
+
 	// variable label used for prometheus disk metrics
 	diskMetricLabel = "disk"
 )
@@ -109,6 +146,7 @@ type (
 		lines    []string
 		fsIDs    []cos.FsID
 		xallRun  core.AllRunningInOut
+		gauges   map[string]func() int64 // see RegGauge
 		standby  bool
 	}
 )
@@ -142,6 +180,7 @@ func (r *Trunner) Init() *atomic.Bool {
 	r.regCommon(r.t.Snode())
 
 	r.ctracker = make(copyTracker, numTargetStats) // these two are allocated once and only used in serial context
+	r.bcks = newBckStats()
 	r.lines = make([]string, 0, 16)
 	r.disk = make(ios.AllDiskStats, 16)
 
@@ -192,6 +231,16 @@ func nameWbps(disk string) string { return _dmetric(disk, "write.bps") }
 func nameWavg(disk string) string { return _dmetric(disk, "avg.wsize") }
 func nameUtil(disk string) string { return _dmetric(disk, "util") }
 
+// register gauge metric the value of which gets refreshed (via the provided callback)
+// every 'periodic.stats_time' interval
+func (r *Trunner) RegGauge(snode *meta.Snode, name string, cb func() int64) {
+	r.reg(snode, name, KindGauge)
+	if r.gauges == nil {
+		r.gauges = make(map[string]func() int64, 4)
+	}
+	r.gauges[name] = cb
+}
+
 // log vs idle logic
 func isDiskMetric(name string) bool {
 	return strings.HasPrefix(name, "disk.")
@@ -212,6 +261,22 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 	r.reg(snode, VerChangeCount, KindCounter)
 	r.reg(snode, VerChangeSize, KindSize)
 
+	r.reg(snode, ReadaheadHitCount, KindCounter)
+	r.reg(snode, ReadaheadMissCount, KindCounter)
+	r.reg(snode, ReadaheadWasteCount, KindCounter)
+
+	r.reg(snode, ArchIdxSkipCount, KindCounter)
+
+	r.reg(snode, WritebackCount, KindCounter)
+	r.reg(snode, WritebackSize, KindSize)
+	r.reg(snode, ErrWritebackCount, KindCounter)
+	r.reg(snode, ErrWritebackFailCount, KindCounter)
+
+	r.reg(snode, ReplPutCount, KindCounter)
+	r.reg(snode, ReplPutSize, KindSize)
+	r.reg(snode, ReplDelCount, KindCounter)
+	r.reg(snode, ErrReplCount, KindCounter)
+
 	r.reg(snode, PutLatency, KindLatency)
 	r.reg(snode, PutLatencyTotal, KindTotal)
 	r.reg(snode, AppendLatency, KindLatency)
@@ -256,6 +321,14 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 	r.reg(snode, LcacheCollisionCount, KindCounter)
 	r.reg(snode, LcacheEvictedCount, KindCounter)
 	r.reg(snode, LcacheFlushColdCount, KindCounter)
+
+	// EC and ETL
+	r.reg(snode, ECEncodeLatency, KindLatency)
+	r.reg(snode, ETLLatency, KindLatency)
+
+	// latency histograms
+	r.regHist(latencyBuckets(cmn.GCO.Get()), GetLatency, PutLatency, GetColdRwLatency,
+		GetRedirLatency, PutRedirLatency, AppendLatency, ECEncodeLatency, ETLLatency)
 }
 
 func (r *Trunner) RegDiskMetrics(snode *meta.Snode, disk string) {
@@ -356,6 +429,9 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 		v = s.Tracker[nameUtil(disk)]
 		v.Value = stats.Util
 	}
+	for name, cb := range r.gauges {
+		ratomic.StoreInt64(&s.Tracker[name].Value, cb())
+	}
 
 	// 2 copy stats, reset latencies, send via StatsD if configured
 	s.updateUptime(uptime)
@@ -363,6 +439,10 @@ func (r *Trunner) log(now int64, uptime time.Duration, config *cmn.Config) {
 	idle := s.copyT(r.ctracker, config.Disk.DiskUtilLowWM)
 	s.promUnlock()
 
+	// per-bucket: StatsD breakdown (if configured) and slot housekeeping
+	s.bckStatsd(r.bcks)
+	r.bcks.housekeep()
+
 	if now >= r.next || !idle {
 		s.sgl.Reset() // sharing w/ CoreStats.copyT
 		r.ctracker.write(s.sgl, r.sorted, true /*target*/, idle)
@@ -170,6 +170,7 @@ func PrepareObjects(t *testing.T, desc ObjectsDesc) *ObjectsOut {
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
 	fs.CSM.Reg(fs.ECSliceType, &fs.ECSliceContentResolver{}, true)
 	fs.CSM.Reg(fs.ECMetaType, &fs.ECMetaContentResolver{}, true)
+	fs.CSM.Reg(fs.ArchIdxType, &fs.ArchIdxContentResolver{}, true)
 
 	dir := t.TempDir()
 
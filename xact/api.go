@@ -62,17 +62,20 @@ type (
 		Buckets     []cmn.Bck     // list of buckets (e.g., copy-bucket, lru-evict, etc.)
 		Timeout     time.Duration // max time to wait
 		Force       bool          // force
+		Queue       int           // when conflicting, queue the job with a given (non-zero) priority (see apc.QparamQueue)
 		OnlyRunning bool          // only for running xactions
+		Since       time.Duration // finished within (0: any time; running xactions always qualify)
 	}
 
 	// simplified JSON-tagged version of the above
 	QueryMsg struct {
-		OnlyRunning *bool     `json:"show_active"`
-		Bck         cmn.Bck   `json:"bck"`
-		ID          string    `json:"id"`
-		Kind        string    `json:"kind"`
-		DaemonID    string    `json:"node,omitempty"`
-		Buckets     []cmn.Bck `json:"buckets,omitempty"`
+		OnlyRunning *bool        `json:"show_active"`
+		Bck         cmn.Bck      `json:"bck"`
+		ID          string       `json:"id"`
+		Kind        string       `json:"kind"`
+		DaemonID    string       `json:"node,omitempty"`
+		Buckets     []cmn.Bck    `json:"buckets,omitempty"`
+		Since       cos.Duration `json:"since,omitempty"` // see ArgsMsg.Since
 	}
 
 	// primarily: `api.QueryXactionSnaps`
@@ -227,6 +230,19 @@ var Table = map[string]Descriptor{
 		Metasync:    true,
 		RefreshCap:  true,
 	},
+	apc.ActCreateInventory: {
+		DisplayName: "create-inventory",
+		Scope:       ScopeB,
+		Access:      apc.AceObjLIST | apc.AceGET, // apc.AcePUT is checked as well (destination bucket)
+		Startable:   true,
+		RefreshCap:  true,
+	},
+	apc.ActIndexShards: {
+		DisplayName: "index-shards",
+		Scope:       ScopeB,
+		Access:      apc.AccessRW,
+		Startable:   true,
+	},
 	apc.ActMoveBck: {
 		DisplayName:    "rename-bucket",
 		Scope:          ScopeB,
@@ -394,6 +410,9 @@ func (msg *QueryMsg) String() (s string) {
 	if msg.OnlyRunning != nil && *msg.OnlyRunning {
 		s += "-only-running"
 	}
+	if msg.Since > 0 {
+		s += "-since[" + msg.Since.String() + "]"
+	}
 	return
 }
 
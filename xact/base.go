@@ -46,7 +46,12 @@ type (
 			inobjs   atomic.Int64 // receive
 			inbytes  atomic.Int64
 		}
-		err cos.Errs
+		err    cos.Errs
+		origin ratomic.Pointer[origin]
+	}
+	origin struct {
+		msg  *apc.ActMsg
+		user string
 	}
 	Marked struct {
 		Xact        core.Xact
@@ -305,6 +310,10 @@ func (xctn *Base) AddNotif(n core.Notif) {
 	debug.Assert(!n.Upon(core.UponProgress) || xctn.notif.P != nil) // progress notification is optional
 }
 
+func (xctn *Base) SetOrigin(msg *apc.ActMsg, user string) {
+	xctn.origin.Store(&origin{msg: msg, user: user})
+}
+
 // atomically set end-time
 func (xctn *Base) Finish() {
 	var (
@@ -390,6 +399,9 @@ func (xctn *Base) ToSnap(snap *core.Snap) {
 	if b := xctn.Bck(); b != nil {
 		snap.Bck = b.Clone()
 	}
+	if o := xctn.origin.Load(); o != nil {
+		snap.Msg, snap.User = o.msg, o.user
+	}
 
 	// counters
 	xctn.ToStats(&snap.Stats)
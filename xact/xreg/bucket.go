@@ -116,6 +116,14 @@ func RenewBckLoadLomCache(uuid string, bck *meta.Bck) RenewRes {
 	return RenewBucketXact(apc.ActLoadLomCache, bck, Args{UUID: uuid})
 }
 
+func RenewBckArchIdx(uuid string, bck *meta.Bck) RenewRes {
+	return RenewBucketXact(apc.ActIndexShards, bck, Args{UUID: uuid})
+}
+
+func RenewCreateInventory(uuid string, bck *meta.Bck, msg *cmn.InvMsg) RenewRes {
+	return RenewBucketXact(apc.ActCreateInventory, bck, Args{UUID: uuid, Custom: msg})
+}
+
 func RenewPutMirror(lom *core.LOM) RenewRes {
 	return RenewBucketXact(apc.ActPutCopies, lom.Bck(), Args{Custom: lom})
 }
@@ -0,0 +1,180 @@
+// Package xreg provides registry and (renew, find) functions for AIS eXtended Actions (xactions).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xreg
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+// Job history: snapshots of finished xactions (excepting list-objects) that each node retains
+// locally and across restarts, subject to `config.JobHistory` (max age and max count).
+// - finished xactions get recorded when pruned from the active list (see hkPruneActive),
+//   which also gives the origin (see SetOrigin) a chance to be set for those that finish right away;
+// - GetSnap merges the history with (what remains of) the registry;
+// - persisting is done asynchronously and outside h.mu (see persist), except FlushHist.
+
+type hist struct {
+	fpath string
+	snaps []*core.Snap // older to newer (in the order of recording)
+	mu    sync.Mutex
+	smu   sync.Mutex // serializes persisting
+	dirty bool       // changed since last persisted
+}
+
+// load persisted history (is called once upon startup; not calling it disables the history)
+func InitHist(config *cmn.Config) { dreg.hist.init(config) }
+
+// record (and persist) finished xactions that are still in the active list (e.g., upon shutdown)
+func FlushHist() {
+	dreg.finDelta.Inc()
+	dreg.hkPruneActive()
+	dreg.hist.persist()
+}
+
+// set the originating request (and user) of a given xaction
+func SetOrigin(xid string, msg *apc.ActMsg, user string) {
+	if xctn, err := dreg.getXact(xid); err == nil && xctn != nil {
+		xctn.SetOrigin(msg, user)
+	}
+}
+
+// a single (finished) xaction from the history, or nil
+func GetHistSnap(xid string) *core.Snap {
+	snaps := dreg.hist.find(&Flt{ID: xid}, nil)
+	if len(snaps) == 0 {
+		return nil
+	}
+	return snaps[0]
+}
+
+func (h *hist) init(config *cmn.Config) {
+	h.mu.Lock()
+	h.fpath = filepath.Join(config.ConfigDir, fname.JobHistory)
+	if _, err := jsp.Load(h.fpath, &h.snaps, jsp.Plain()); err != nil && !os.IsNotExist(err) {
+		nlog.Errorln("failed to load job history:", err)
+		h.snaps = nil
+	}
+	h._trim(config, time.Now())
+	h.mu.Unlock()
+}
+
+func (h *hist) add(snaps []*core.Snap) {
+	h.mu.Lock()
+	if h.fpath == "" {
+		h.mu.Unlock()
+		return
+	}
+	h.snaps = append(h.snaps, snaps...)
+	h._trim(cmn.GCO.Get(), time.Now())
+	h.dirty = true
+	h.mu.Unlock()
+
+	go h.persist()
+}
+
+// save the current snapshot (if changed) - the latest persist always saves the latest version
+func (h *hist) persist() {
+	h.smu.Lock()
+	h.mu.Lock()
+	if !h.dirty {
+		h.mu.Unlock()
+		h.smu.Unlock()
+		return
+	}
+	snaps := append([]*core.Snap(nil), h.snaps...) // (snaps are immutable - shallow copy)
+	h.dirty = false
+	h.mu.Unlock()
+
+	if err := jsp.Save(h.fpath, snaps, jsp.Plain(), nil); err != nil {
+		nlog.Errorln("failed to persist job history:", err)
+		h.mu.Lock()
+		h.dirty = true // (to retry next time)
+		h.mu.Unlock()
+	}
+	h.smu.Unlock()
+}
+
+// under lock
+func (h *hist) _trim(config *cmn.Config, now time.Time) {
+	var (
+		maxAge = config.JobHistory.Age()
+		l      = len(h.snaps)
+		i      int
+	)
+	for i < l && now.Sub(h.snaps[i].EndTime) > maxAge {
+		i++
+	}
+	if n := l - config.JobHistory.Count(); n > i {
+		i = n
+	}
+	if i > 0 {
+		h.snaps = append(h.snaps[:0:0], h.snaps[i:]...)
+	}
+}
+
+// newer to older; skipping IDs that are still in the registry
+func (h *hist) find(flt *Flt, live map[string]struct{}) (snaps []*core.Snap) {
+	h.mu.Lock()
+	for i := len(h.snaps) - 1; i >= 0; i-- {
+		snap := h.snaps[i]
+		if _, ok := live[snap.ID]; ok {
+			continue
+		}
+		if flt.matchSnap(snap) {
+			snaps = append(snaps, snap)
+		}
+	}
+	h.mu.Unlock()
+	return snaps
+}
+
+// compare with Flt.Matches (above)
+func (flt *Flt) matchSnap(snap *core.Snap) bool {
+	if flt.OnlyRunning != nil && *flt.OnlyRunning {
+		return false // history is all finished
+	}
+	if flt.ID != "" {
+		return snap.ID == flt.ID && (flt.Kind == "" || snap.Kind == flt.Kind)
+	}
+	if flt.Kind != "" && snap.Kind != flt.Kind {
+		return false
+	}
+	if xact.Table[snap.Kind].Scope != xact.ScopeB || flt.Bck == nil {
+		return true
+	}
+	if len(flt.Buckets) == 2 && !snap.SrcBck.IsEmpty() {
+		return snap.SrcBck.Equal(flt.Buckets[0].Bucket()) && snap.DstBck.Equal(flt.Buckets[1].Bucket())
+	}
+	return snap.Bck.Equal(flt.Bck.Bucket())
+}
+
+// finished within `flt.Since` (running xactions always pass)
+func (flt *Flt) since(snaps []*core.Snap) []*core.Snap {
+	if flt.Since <= 0 {
+		return snaps
+	}
+	var (
+		now = time.Now()
+		j   int
+	)
+	for _, snap := range snaps {
+		if snap.EndTime.IsZero() || now.Sub(snap.EndTime) <= flt.Since {
+			snaps[j] = snap
+			j++
+		}
+	}
+	return snaps[:j]
+}
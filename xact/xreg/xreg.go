@@ -66,6 +66,7 @@ type (
 		ID          string
 		Kind        string
 		Buckets     []*meta.Bck
+		Since       time.Duration // finished within (0: any time)
 	}
 )
 
@@ -100,6 +101,7 @@ type (
 		entries     entries
 		bckXacts    map[string]Renewable
 		nonbckXacts map[string]Renewable
+		hist        hist // finished xactions retained across restarts (see hist.go)
 		finDelta    atomic.Int64
 	}
 )
@@ -264,7 +266,33 @@ func DoAbort(flt Flt, err error) {
 	}
 }
 
+// merges (registry, job history); see also hist.go
 func GetSnap(flt Flt) ([]*core.Snap, error) {
+	snaps, err := getSnap(flt)
+	if flt.OnlyRunning != nil && *flt.OnlyRunning {
+		return flt.since(snaps), err
+	}
+	if err != nil && !cmn.IsErrXactNotFound(err) {
+		return nil, err
+	}
+	live := make(map[string]struct{}, len(snaps))
+	for _, snap := range snaps {
+		live[snap.ID] = struct{}{}
+	}
+	if flt.ID != "" {
+		// (not to confuse finished rebalances "at or after" - see below - with the same ID)
+		if _, ok := live[flt.ID]; ok {
+			return flt.since(snaps), nil
+		}
+	}
+	snaps = append(snaps, dreg.hist.find(&flt, live)...)
+	if len(snaps) == 0 && err != nil {
+		return nil, err
+	}
+	return flt.since(snaps), nil
+}
+
+func getSnap(flt Flt) ([]*core.Snap, error) {
 	var onlyRunning bool
 	if flt.OnlyRunning != nil {
 		onlyRunning = *flt.OnlyRunning
@@ -396,20 +424,36 @@ func (r *registry) hkPruneActive() time.Duration {
 	if r.finDelta.Swap(0) == 0 {
 		return hk.PruneActiveIval
 	}
-	e := &r.entries
+	var (
+		e    = &r.entries
+		fins []core.Xact
+	)
 	e.mtx.Lock()
 	l := len(e.active)
 	for i := 0; i < l; i++ {
 		entry := e.active[i]
-		if !entry.Get().Finished() {
+		xctn := entry.Get()
+		if !xctn.Finished() {
 			continue
 		}
+		if xctn.Kind() != apc.ActList {
+			fins = append(fins, xctn)
+		}
 		copy(e.active[i:], e.active[i+1:])
 		i--
 		l--
 		e.active = e.active[:l]
 	}
 	e.mtx.Unlock()
+
+	// job history (outside registry lock)
+	if len(fins) > 0 {
+		snaps := make([]*core.Snap, 0, len(fins))
+		for _, xctn := range fins {
+			snaps = append(snaps, xctn.Snap())
+		}
+		r.hist.add(snaps)
+	}
 	return hk.PruneActiveIval
 }
 
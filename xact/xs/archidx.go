@@ -0,0 +1,111 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// explicit (re)indexing of all TAR shards in a bucket
+// (see also: feat.ArchIndex and cmn/archive/index.go)
+
+type (
+	aidxFactory struct {
+		xreg.RenewBase
+		xctn *xactArchIdx
+	}
+	xactArchIdx struct {
+		xact.BckJog
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*xactArchIdx)(nil)
+	_ xreg.Renewable = (*aidxFactory)(nil)
+)
+
+/////////////////
+// aidxFactory //
+/////////////////
+
+func (*aidxFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	p := &aidxFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}}
+	return p
+}
+
+func (p *aidxFactory) Start() error {
+	xctn := newXactArchIdx(p.UUID(), p.Bck)
+	p.xctn = xctn
+	go xctn.Run(nil)
+	return nil
+}
+
+func (*aidxFactory) Kind() string     { return apc.ActIndexShards }
+func (p *aidxFactory) Get() core.Xact { return p.xctn }
+
+func (*aidxFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+/////////////////
+// xactArchIdx //
+/////////////////
+
+func newXactArchIdx(uuid string, bck *meta.Bck) (r *xactArchIdx) {
+	r = &xactArchIdx{}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visit,
+		DoLoad:   mpather.Load,
+	}
+	mpopts.Bck.Copy(bck.Bucket())
+	r.BckJog.Init(uuid, apc.ActIndexShards, bck, mpopts, cmn.GCO.Get())
+	return
+}
+
+func (r *xactArchIdx) Run(*sync.WaitGroup) {
+	r.BckJog.Run()
+	nlog.Infoln(r.Name())
+	err := r.BckJog.Wait()
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.Finish()
+}
+
+func (r *xactArchIdx) visit(lom *core.LOM, _ []byte) error {
+	if mime, err := archive.Mime("", lom.ObjName); err != nil || !archive.Indexable(mime) {
+		return nil
+	}
+	lom.Lock(false)
+	err := lom.GenArchIdx()
+	lom.Unlock(false)
+	if err != nil {
+		r.AddErr(err, 5, cos.SmoduleXs)
+		return nil // keep going
+	}
+	r.ObjsAdd(1, lom.Lsize())
+	return nil
+}
+
+func (r *xactArchIdx) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
@@ -30,6 +30,8 @@ func Xreg(xeleOnly bool) {
 
 	xreg.RegBckXact(&proFactory{})
 	xreg.RegBckXact(&llcFactory{})
+	xreg.RegBckXact(&aidxFactory{})
+	xreg.RegBckXact(&invFactory{})
 
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActCopyBck})
 	xreg.RegBckXact(&tcbFactory{kind: apc.ActETLBck})
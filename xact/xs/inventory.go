@@ -0,0 +1,829 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Bucket inventory of ais:// buckets
+//
+// Generation (x-create-inventory): each target walks (in lexicographical order) the objects it owns
+// and writes CSV shards, at most cmn.InvMsg.PageSize records each, into the destination bucket:
+// - <prefix>/<xid>/<tid>-<seq>.csv - shards
+// - <prefix>/manifest-<tid>.json   - target's manifest (written last, overwritten upon every run)
+// where <prefix> is ".inventory/<bucket>[/<id>]" - see s3.InvPrefObjname
+// When the destination is explicitly specified (cmn.InvMsg.ToBck), manifests are also written
+// into the default destination (bucket's inventory.dest or the bucket itself) - the latter is
+// where list-objects looks for them, to then follow invManifest.DstBucket
+//
+// Consumption (list-objects with apc.HdrInventory): the designated target reads all manifests, merges
+// the (sorted) shards into a single local .csv, and then paginates it - same as with S3 inventory
+// (see core.LsoInvCtx and ais/backend/awsinv.go)
+//
+// Object names that contain newlines are not inventoried.
+
+const (
+	invTag     = "bucket-inventory"
+	invXidKey  = "inv-xid" // custom MD of the local(ized) .csv
+	invMaxLine = 4 * cos.KiB
+
+	invPageSGL = 4 * cos.MiB
+	invSwapSGL = invMaxLine
+
+	invBusyTimeout = 10 * time.Second
+)
+
+// canonical schema (compare with S3 inventory)
+const (
+	invSchemaBucket = "Bucket"
+	invSchemaKey    = "Key"
+	invKeyPos       = 1
+)
+
+var invSchema = map[string]string{
+	apc.GetPropsSize:     "Size",
+	apc.GetPropsChecksum: "Checksum",
+	apc.GetPropsVersion:  "Version",
+	apc.GetPropsAtime:    "Atime", // RFC 3339
+	apc.GetPropsCustom:   "CustomMD",
+}
+
+type (
+	invFactory struct {
+		xreg.RenewBase
+		xctn *XactInv
+		msg  *cmn.InvMsg
+	}
+	XactInv struct {
+		msg    *cmn.InvMsg
+		dst    *meta.Bck
+		def    *meta.Bck // default destination (same as dst unless specified)
+		smap   *meta.Smap
+		wi     *walkInfo
+		sgl    *memsys.SGL
+		w      *csv.Writer
+		prefix string
+		schema []string
+		rec    []string
+		files  []invFile
+		cnt    int64 // records in the current shard
+		xact.Base
+	}
+	invManifest struct {
+		SrcBucket  string    `json:"sourceBucket"`
+		DstBucket  string    `json:"destinationBucket"`
+		XID        string    `json:"xid"`
+		FileFormat string    `json:"fileFormat"`
+		FileSchema string    `json:"fileSchema"` // e.g. "Bucket, Key, Size, Checksum"
+		Targets    []string  `json:"targets"`    // all targets that generate this inventory
+		Files      []invFile `json:"files"`
+		Created    int64     `json:"creationTimestamp,string"`
+	}
+	invFile struct {
+		Key   string `json:"key"`
+		Size  int64  `json:"size"`
+		Count int64  `json:"count"`
+	}
+)
+
+// interface guard
+var (
+	_ core.Xact      = (*XactInv)(nil)
+	_ xreg.Renewable = (*invFactory)(nil)
+)
+
+////////////////
+// invFactory //
+////////////////
+
+func (*invFactory) New(args xreg.Args, bck *meta.Bck) xreg.Renewable {
+	msg := args.Custom.(*cmn.InvMsg)
+	p := &invFactory{RenewBase: xreg.RenewBase{Args: args, Bck: bck}, msg: msg}
+	return p
+}
+
+func (p *invFactory) Start() error {
+	if !p.Bck.IsAIS() {
+		return cmn.NewErrUnsupp("generate inventory of", p.Bck.Cname(""))
+	}
+	if err := p.msg.Validate(); err != nil {
+		return err
+	}
+	dst, err := invDstBck(p.Bck, p.msg.ToBck)
+	if err != nil {
+		return err
+	}
+	def, err := invDstBck(p.Bck, cmn.Bck{})
+	if err != nil {
+		return err
+	}
+	r := &XactInv{msg: p.msg, dst: dst, def: def, smap: core.T.Sowner().Get()}
+	r.prefix, _ = s3.InvPrefObjname(p.Bck.Bucket(), p.msg.Name, p.msg.ID)
+	r.schema = []string{invSchemaBucket, invSchemaKey}
+	for _, prop := range strings.Split(p.msg.Props, apc.LsPropsSepa) {
+		r.schema = append(r.schema, invSchema[prop])
+	}
+	r.InitBase(p.UUID(), apc.ActCreateInventory, p.Bck)
+	p.xctn = r
+	return nil
+}
+
+func (*invFactory) Kind() string     { return apc.ActCreateInventory }
+func (p *invFactory) Get() core.Xact { return p.xctn }
+
+func (*invFactory) WhenPrevIsRunning(xreg.Renewable) (xreg.WPR, error) { return xreg.WprUse, nil }
+
+// destination: explicitly specified, bucket's inventory.dest, or the bucket itself
+func invDstBck(bck *meta.Bck, tobck cmn.Bck) (*meta.Bck, error) {
+	if tobck.IsEmpty() && bck.Props != nil && bck.Props.Inventory.Dest != "" {
+		var err error
+		if tobck, err = bck.Props.Inventory.DestBck(); err != nil {
+			return nil, err
+		}
+	}
+	if tobck.IsEmpty() {
+		return bck, nil
+	}
+	dst := meta.CloneBck(&tobck)
+	if err := dst.Init(core.T.Bowner()); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+/////////////
+// XactInv //
+/////////////
+
+func (r *XactInv) Run(*sync.WaitGroup) {
+	nlog.Infoln(r.Name(), "->", r.dst.Cname(r.prefix))
+
+	r.wi = newWalkInfo(&apc.LsoMsg{Prefix: r.msg.Prefix, Props: r.msg.Props, TimeFormat: time.RFC3339Nano}, noopCb)
+	r.wi.smap = r.smap
+	r.sgl = core.T.PageMM().NewSGL(0)
+	r.w = csv.NewWriter(r.sgl)
+	r.rec = make([]string, len(r.schema))
+
+	opts := &fs.WalkBckOpts{
+		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: r.msg.Prefix, Sorted: true},
+	}
+	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
+	opts.ValidateCb = func(fqn string, de fs.DirEntry) error {
+		if de.IsDir() {
+			return r.wi.processDir(fqn)
+		}
+		return nil
+	}
+	err := fs.WalkBck(opts)
+	if err == nil || err == filepath.SkipDir {
+		err = r.flush()
+	}
+	if err == nil {
+		err = r.finalize()
+	}
+	if err != nil {
+		r.AddErr(err)
+	}
+	r.sgl.Free()
+	r.Finish()
+}
+
+func (r *XactInv) cb(fqn string, de fs.DirEntry) error {
+	if r.IsAborted() {
+		return cmn.NewErrAborted(r.Name(), "walk", nil)
+	}
+	entry, err := r.wi.callback(fqn, de)
+	if entry == nil || err != nil {
+		if err != nil {
+			r.AddErr(err, 5, cos.SmoduleXs)
+		}
+		return nil // keep going
+	}
+	if strings.HasPrefix(entry.Name, s3.InvName+cos.PathSeparator) || strings.IndexByte(entry.Name, '\n') >= 0 {
+		return nil
+	}
+
+	r.rec[0], r.rec[1] = r.Bck().Name, entry.Name
+	for i := invKeyPos + 1; i < len(r.schema); i++ {
+		switch r.schema[i] {
+		case "Size":
+			r.rec[i] = strconv.FormatInt(entry.Size, 10)
+		case "Checksum":
+			r.rec[i] = entry.Checksum
+		case "Version":
+			r.rec[i] = entry.Version
+		case "Atime":
+			r.rec[i] = entry.Atime
+		case "CustomMD":
+			r.rec[i] = entry.Custom
+		}
+	}
+	if err := r.w.Write(r.rec); err != nil {
+		return err
+	}
+	r.ObjsAdd(1, entry.Size)
+	r.cnt++
+	if r.cnt >= r.msg.PageSize {
+		return r.flush()
+	}
+	return nil
+}
+
+// PUT the current shard
+func (r *XactInv) flush() error {
+	r.w.Flush()
+	if err := r.w.Error(); err != nil {
+		return err
+	}
+	if r.cnt == 0 {
+		return nil
+	}
+	var (
+		name = fmt.Sprintf("%s/%s/%s-%d%s", r.prefix, r.ID(), core.T.SID(), len(r.files), s3.InvDstExt)
+		size = r.sgl.Len()
+	)
+	if err := invPut(r.dst, name, r.sgl, size, r.smap); err != nil {
+		return err
+	}
+	r.OutObjsAdd(1, size)
+	r.files = append(r.files, invFile{Key: name, Size: size, Count: r.cnt})
+	r.sgl.Reset()
+	r.cnt = 0
+	return nil
+}
+
+// write new manifest, remove the shards listed in the previous one
+func (r *XactInv) finalize() error {
+	var (
+		mname   = invManifestName(r.prefix, core.T.SID())
+		prev, _ = invGetManifest(r.def, mname, r.smap)
+		mf      = &invManifest{
+			SrcBucket:  r.Bck().Cname(""),
+			DstBucket:  r.dst.Cname(""),
+			XID:        r.ID(),
+			FileFormat: strings.ToUpper(cmn.InvFormatCSV),
+			FileSchema: strings.Join(r.schema, ", "),
+			Files:      r.files,
+			Created:    time.Now().UnixNano(),
+		}
+	)
+	for tid, tsi := range r.smap.Tmap {
+		if !tsi.InMaintOrDecomm() {
+			mf.Targets = append(mf.Targets, tid)
+		}
+	}
+	sort.Strings(mf.Targets)
+
+	b := cos.MustMarshal(mf)
+	if err := invPut(r.dst, mname, bytes.NewReader(b), int64(len(b)), r.smap); err != nil {
+		return err
+	}
+	if !r.def.Equal(r.dst, false, false) {
+		if err := invPut(r.def, mname, bytes.NewReader(b), int64(len(b)), r.smap); err != nil {
+			return err
+		}
+	}
+	if prev == nil || prev.XID == mf.XID {
+		return nil
+	}
+	pdst, err := invMfDstBck(r.Bck(), r.def, prev)
+	if err != nil {
+		nlog.Warningln(r.Name(), "failed to remove old", invTag, "shards:", err)
+		return nil
+	}
+	for _, f := range prev.Files {
+		if err := invDelete(pdst, f.Key, r.smap); err != nil {
+			nlog.Warningln(r.Name(), "failed to remove old", invTag, "shard", f.Key, "err:", err)
+		}
+	}
+	return nil
+}
+
+func (r *XactInv) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+//
+// list ais:// bucket via its inventory (see nextpage.go)
+//
+
+// returns with ctx.Lom rlocked and ctx.Lmfh open (same as backend.GetBucketInv)
+func getInvAIS(bck *meta.Bck, ctx *core.LsoInvCtx) error {
+	debug.Assert(ctx != nil && ctx.Lom == nil)
+	def, err := invDstBck(bck, cmn.Bck{})
+	if err != nil {
+		return err
+	}
+	var (
+		smap            = core.T.Sowner().Get()
+		prefix, objName = s3.InvPrefObjname(bck.Bucket(), ctx.Name, ctx.ID)
+	)
+	mfs, xid, err := invGetManifests(def, prefix, smap)
+	if err != nil {
+		return err
+	}
+	dst, err := invMfDstBck(bck, def, mfs[0])
+	if err != nil {
+		return err
+	}
+	ctx.Schema = strings.Split(mfs[0].FileSchema, ", ")
+
+	lom := core.AllocLOM(objName)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		core.FreeLOM(lom)
+		return err
+	}
+	if !lom.TryLock(false) {
+		core.FreeLOM(lom)
+		return cmn.NewErrBusy(invTag, lom.Cname(), "likely getting updated")
+	}
+	if usable := invLomUsable(lom, xid); !usable {
+		// rlock -> wlock
+		lom.Unlock(false)
+		err = cmn.NewErrBusy(invTag, lom.Cname(), "timed out waiting to acquire write access") // prelim
+		for total := invBusyTimeout; total >= 0; total -= time.Second {
+			if lom.TryLock(true) {
+				err = nil
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		if err != nil {
+			core.FreeLOM(lom)
+			return err
+		}
+		// (write/write race)
+		if !invLomUsable(lom, xid) {
+			err = invMerge(dst, lom, mfs, xid, smap)
+		}
+		// wlock -> rlock
+		lom.Unlock(true)
+		if err != nil {
+			core.FreeLOM(lom)
+			return err
+		}
+		lom.Lock(false)
+	}
+
+	ctx.Lom = lom
+	ctx.Size = lom.Lsize()
+	if ctx.Lmfh, err = lom.Open(); err != nil {
+		lom.Unlock(false)
+		core.FreeLOM(lom)
+		ctx.Lom = nil
+		return fmt.Errorf("%s: %s: %v", invTag, lom.Cname(), err)
+	}
+	return nil
+}
+
+func invLomUsable(lom *core.LOM, xid string) bool {
+	lom.Uncache()
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return false
+	}
+	v, ok := lom.GetCustomKey(invXidKey)
+	return ok && v == xid
+}
+
+// read all manifests; make sure they belong to the same (completed) generation
+func invGetManifests(dst *meta.Bck, prefix string, smap *meta.Smap) (mfs []*invManifest, xid string, _ error) {
+	var (
+		first *invManifest
+		err   error
+	)
+	// start from any current target that participated
+	for tid := range smap.Tmap {
+		if first, err = invGetManifest(dst, invManifestName(prefix, tid), smap); err == nil {
+			break
+		}
+	}
+	if first == nil {
+		if err == nil || cos.IsNotExist(err, 0) || cmn.IsErrObjNought(err) {
+			return nil, "", cos.NewErrNotFound(dst, invTag+":"+prefix)
+		}
+		return nil, "", err
+	}
+	mfs = make([]*invManifest, 0, len(first.Targets))
+	for _, tid := range first.Targets {
+		mf, err := invGetManifest(dst, invManifestName(prefix, tid), smap)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s %s: missing manifest from %s: %v", invTag, dst.Cname(prefix), meta.Tname(tid), err)
+		}
+		if mf.XID != first.XID || mf.FileSchema != first.FileSchema {
+			return nil, "", cmn.NewErrBusy(invTag, dst.Cname(prefix), "likely being regenerated")
+		}
+		mfs = append(mfs, mf)
+	}
+	return mfs, first.XID, nil
+}
+
+// where the manifest's shards are (the manifest itself may be a copy - see finalize)
+func invMfDstBck(bck, def *meta.Bck, mf *invManifest) (*meta.Bck, error) {
+	if mf.DstBucket == "" || mf.DstBucket == def.Cname("") {
+		return def, nil
+	}
+	tobck, _, err := cmn.ParseBckObjectURI(mf.DstBucket, cmn.ParseURIOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid destination %q: %v", invTag, mf.DstBucket, err)
+	}
+	return invDstBck(bck, tobck)
+}
+
+func invManifestName(prefix, tid string) string {
+	return prefix + cos.PathSeparator + "manifest-" + tid + ".json"
+}
+
+func invGetManifest(dst *meta.Bck, name string, smap *meta.Smap) (*invManifest, error) {
+	resp, err := invReq(http.MethodGet, dst, name, nil, 0, smap)
+	if err != nil {
+		return nil, err
+	}
+	mf := &invManifest{}
+	err = jsoniter.NewDecoder(resp.Body).Decode(mf)
+	cos.Close(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to parse %s: %v", invTag, dst.Cname(name), err)
+	}
+	return mf, nil
+}
+
+//
+// k-way merge of per-target (sorted) shards => local .csv (under wlock)
+//
+
+type (
+	invSrc struct {
+		r   *csv.Reader
+		rec []string
+	}
+	invHeap []*invSrc
+)
+
+func (src *invSrc) read() ([]string, error) {
+	rec, err := src.r.Read()
+	if err == nil && len(rec) <= invKeyPos {
+		line, _ := src.r.FieldPos(0)
+		err = fmt.Errorf("invalid record at line %d: %q", line, rec)
+	}
+	return rec, err
+}
+
+func (h invHeap) Len() int           { return len(h) }
+func (h invHeap) Less(i, j int) bool { return h[i].rec[invKeyPos] < h[j].rec[invKeyPos] }
+func (h invHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *invHeap) Push(x any)        { *h = append(*h, x.(*invSrc)) }
+func (h *invHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// reads target's shards, one after another
+type invShards struct {
+	dst   *meta.Bck
+	smap  *meta.Smap
+	body  io.ReadCloser
+	files []invFile
+}
+
+func (s *invShards) Read(b []byte) (int, error) {
+	for {
+		if s.body == nil {
+			if len(s.files) == 0 {
+				return 0, io.EOF
+			}
+			resp, err := invReq(http.MethodGet, s.dst, s.files[0].Key, nil, 0, s.smap)
+			if err != nil {
+				return 0, err
+			}
+			s.body, s.files = resp.Body, s.files[1:]
+		}
+		n, err := s.body.Read(b)
+		if err == io.EOF {
+			cos.Close(s.body)
+			s.body = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (s *invShards) Close() {
+	if s.body != nil {
+		cos.Close(s.body)
+	}
+}
+
+func invMerge(dst *meta.Bck, lom *core.LOM, mfs []*invManifest, xid string, smap *meta.Smap) error {
+	var (
+		h      = make(invHeap, 0, len(mfs))
+		shards = make([]*invShards, 0, len(mfs))
+		wfqn   = fs.CSM.Gen(lom, fs.WorkfileType, "")
+	)
+	defer func() {
+		for _, s := range shards {
+			s.Close()
+		}
+	}()
+	for _, mf := range mfs {
+		s := &invShards{dst: dst, smap: smap, files: mf.Files}
+		shards = append(shards, s)
+		src := &invSrc{r: csv.NewReader(s)}
+		src.r.ReuseRecord = false
+		rec, err := src.read()
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", invTag, err)
+		}
+		src.rec = rec
+		h = append(h, src)
+	}
+	heap.Init(&h)
+
+	wfh, err := lom.CreateWork(wfqn)
+	if err != nil {
+		return fmt.Errorf("%s: create-file: %v", invTag, err)
+	}
+	var (
+		w    = csv.NewWriter(wfh)
+		last string
+	)
+	for h.Len() > 0 && err == nil {
+		src := h[0]
+		// skip duplicates (e.g., the object that migrated between targets while generating)
+		if key := src.rec[invKeyPos]; key != last {
+			if err = w.Write(src.rec); err != nil {
+				break
+			}
+			last = key
+		}
+		src.rec, err = src.read()
+		switch err {
+		case nil:
+			heap.Fix(&h, 0)
+		case io.EOF:
+			heap.Pop(&h)
+			err = nil
+		}
+	}
+	if err == nil {
+		w.Flush()
+		err = w.Error()
+	}
+	cos.Close(wfh)
+
+	if err == nil {
+		err = lom.RenameFinalize(wfqn)
+	}
+	if err != nil {
+		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+			nlog.Errorln(invTag, "nested failure to remove", wfqn, nerr)
+		}
+		return fmt.Errorf("%s: merge: %v", invTag, err)
+	}
+
+	// (a lighter version of FinalizeObj - no redundancy)
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return err
+	}
+	lom.SetSize(finfo.Size())
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	lom.SetCustomKey(invXidKey, xid)
+	if err := lom.PersistMain(); err != nil {
+		return err
+	}
+	nlog.Infoln("new", invTag+":", lom.Cname(), "from", len(mfs), "manifest"+cos.Plural(len(mfs)))
+	return nil
+}
+
+func listInvAIS(msg *apc.LsoMsg, lst *cmn.LsoRes, ctx *core.LsoInvCtx) (err error) {
+	debug.Assert(ctx.Lom != nil && ctx.Lmfh != nil, ctx.Lom, " ", ctx.Lmfh)
+	mm := core.T.PageMM()
+	if ctx.SGL == nil {
+		if ctx.EOF {
+			lst.Entries = lst.Entries[:0]
+			return nil
+		}
+		ctx.SGL = mm.NewSGL(invPageSGL, memsys.DefaultBuf2Size)
+	} else if l := ctx.SGL.Len(); l > 0 && l < invSwapSGL && !ctx.EOF {
+		// swap SGLs
+		sgl := mm.NewSGL(invPageSGL, memsys.DefaultBuf2Size)
+		_, err = io.Copy(sgl, ctx.SGL)
+		debug.AssertNoErr(err)
+		ctx.SGL.Free()
+		ctx.SGL = sgl
+	}
+	err = _listInvAIS(ctx, msg, lst)
+	if err == nil || err == io.EOF {
+		return nil
+	}
+	lst.Entries = lst.Entries[:0]
+	return err
+}
+
+func _listInvAIS(ctx *core.LsoInvCtx, msg *apc.LsoMsg, lst *cmn.LsoRes) (err error) {
+	var (
+		i    int64
+		sgl  = ctx.SGL
+		lbuf = make([]byte, invMaxLine) // reuse for all read lines
+	)
+	if msg.PageSize == 0 {
+		msg.PageSize = apc.MaxPageSizeAIS
+	}
+	for j := len(lst.Entries); j < int(msg.PageSize); j++ {
+		lst.Entries = append(lst.Entries, &cmn.LsoEnt{})
+	}
+	lst.ContinuationToken = ""
+
+	// when little remains: read some more unless eof
+	if sgl.Len() < 2*invSwapSGL && !ctx.EOF {
+		_, err = io.CopyN(sgl, ctx.Lmfh, invPageSGL-sgl.Len()-256)
+		if err != nil {
+			ctx.EOF = err == io.EOF
+			if !ctx.EOF || sgl.Len() == 0 {
+				return err
+			}
+		}
+	}
+
+	// avoid having line split across SGLs
+	for i < msg.PageSize && (sgl.Len() > invSwapSGL || ctx.EOF) {
+		if lbuf, err = sgl.NextLine(lbuf, true); err != nil {
+			break
+		}
+		line, errN := invParseLine(lbuf)
+		if errN != nil {
+			nlog.Errorln(ctx.Lom.String(), errN)
+			continue
+		}
+		objName := line[invKeyPos]
+
+		// prefix
+		if msg.IsFlagSet(apc.LsNoRecursion) {
+			if _, errN := cmn.HandleNoRecurs(msg.Prefix, objName); errN != nil {
+				continue
+			}
+		} else if msg.Prefix != "" && !strings.HasPrefix(objName, msg.Prefix) {
+			continue
+		}
+
+		entry := lst.Entries[i]
+		i++
+		*entry = cmn.LsoEnt{Name: objName, Flags: apc.EntryIsCached}
+		for k := invKeyPos + 1; k < len(ctx.Schema) && k < len(line); k++ {
+			switch ctx.Schema[k] {
+			case "Size":
+				entry.Size, _ = strconv.ParseInt(line[k], 10, 64)
+			case "Checksum":
+				entry.Checksum = line[k]
+			case "Version":
+				entry.Version = line[k]
+			case "Atime":
+				entry.Atime = line[k]
+				if msg.TimeFormat != "" {
+					if t, errN := time.Parse(time.RFC3339Nano, line[k]); errN == nil {
+						entry.Atime = cos.FormatTime(t, msg.TimeFormat)
+					}
+				}
+			case "CustomMD":
+				entry.Custom = line[k]
+			}
+		}
+	}
+	lst.Entries = lst.Entries[:i]
+
+	// next continuation token (skipping malformed lines, if any)
+	for {
+		if lbuf, err = sgl.NextLine(lbuf, false /*advance roff*/); err != nil {
+			break
+		}
+		line, errN := invParseLine(lbuf)
+		if errN == nil {
+			lst.ContinuationToken = line[invKeyPos]
+			break
+		}
+		nlog.Errorln(ctx.Lom.String(), errN)
+		if lbuf, err = sgl.NextLine(lbuf, true); err != nil {
+			break
+		}
+	}
+	return err
+}
+
+func invParseLine(lbuf []byte) ([]string, error) {
+	line, err := csv.NewReader(bytes.NewReader(lbuf)).Read()
+	if err == nil && len(line) <= invKeyPos {
+		err = errors.New("invalid line: " + cos.BHead(lbuf, 128))
+	}
+	return line, err
+}
+
+//
+// intra-cluster requests to the target that owns a given (destination) object
+//
+
+func invPut(dst *meta.Bck, objName string, r io.Reader, size int64, smap *meta.Smap) error {
+	resp, err := invReq(http.MethodPut, dst, objName, r, size, smap)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	return nil
+}
+
+func invDelete(dst *meta.Bck, objName string, smap *meta.Smap) error {
+	resp, err := invReq(http.MethodDelete, dst, objName, nil, 0, smap)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	return nil
+}
+
+// (the caller closes resp.Body)
+func invReq(method string, dst *meta.Bck, objName string, r io.Reader, size int64, smap *meta.Smap) (*http.Response, error) {
+	tsi, err := smap.HrwName2T(dst.MakeUname(objName))
+	if err != nil {
+		return nil, err
+	}
+	hdr := make(http.Header, 4)
+	hdr.Set(apc.HdrCallerID, core.T.SID())
+	hdr.Set(apc.HdrCallerName, core.T.String())
+	if method == http.MethodPut {
+		hdr.Set(apc.HdrT2TPutterID, core.T.SID())
+		hdr.Set(cos.HdrContentLength, strconv.FormatInt(size, 10))
+	}
+	args := cmn.HreqArgs{
+		Method: method,
+		Base:   tsi.URL(cmn.NetIntraData),
+		Path:   apc.URLPathObjects.Join(dst.Name, objName),
+		Query:  dst.NewQuery(),
+		Header: hdr,
+		BodyR:  r,
+	}
+	req, err := args.Req()
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodPut {
+		req.ContentLength = size
+	}
+	resp, err := core.T.DataClient().Do(req) //nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, cmn.NewErrFailedTo(core.T, method, dst.Cname(objName), err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		cos.DrainReader(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, cos.NewErrNotFound(core.T, dst.Cname(objName))
+		}
+		return nil, cmn.NewErrFailedTo(core.T, method, dst.Cname(objName), errors.New(resp.Status), resp.StatusCode)
+	}
+	return resp, nil
+}
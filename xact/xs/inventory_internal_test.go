@@ -0,0 +1,346 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+var invBck = cmn.Bck{
+	Name:     "inv-bck",
+	Provider: apc.AIS,
+	Ns:       cmn.NsGlobal,
+	Props:    &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}, BID: 0xa5b6e7d8},
+}
+
+// explicitly specified inventory destination
+var invToBck = cmn.Bck{
+	Name:     "inv-dst",
+	Provider: apc.AIS,
+	Ns:       cmn.NsGlobal,
+	Props:    &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}},
+}
+
+type invSowner struct{ smap *meta.Smap }
+
+func (so *invSowner) Get() *meta.Smap            { return so.smap }
+func (*invSowner) Listeners() meta.SmapListeners { return nil }
+
+func invTestInit(t *testing.T) {
+	fs.TestNew(mock.NewIOS())
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	_, err := fs.Add(t.TempDir(), "daeID")
+	tassert.CheckFatal(t, err)
+	core.T = mock.NewTarget(mock.NewBaseBownerMock((*meta.Bck)(&invBck), (*meta.Bck)(&invToBck)))
+	if errs := fs.CreateBucket(&invBck, false /*nilbmd*/); len(errs) > 0 {
+		tassert.CheckFatal(t, errs[0])
+	}
+}
+
+func invTestLom(t *testing.T, name string) *core.LOM {
+	lom := core.AllocLOM(name)
+	tassert.CheckFatal(t, lom.InitBck(&invBck))
+	return lom
+}
+
+func TestInvParseLine(t *testing.T) {
+	tests := []struct {
+		line string
+		key  string // empty when expecting error
+	}{
+		{line: "bck,obj", key: "obj"},
+		{line: "bck,obj,1024,abc", key: "obj"},
+		{line: `bck,"dir/o,b""j",1`, key: `dir/o,b"j`},
+		{line: "bck"},
+		{line: ""},
+		{line: `bck,"obj`},
+		{line: `bck,o"bj`},
+	}
+	for _, test := range tests {
+		line, err := invParseLine([]byte(test.line))
+		if test.key == "" {
+			tassert.Errorf(t, err != nil, "%q: expected error, got %q", test.line, line)
+			continue
+		}
+		tassert.Fatalf(t, err == nil, "%q: %v", test.line, err)
+		tassert.Errorf(t, line[invKeyPos] == test.key, "%q: expected %q, got %q", test.line, test.key, line[invKeyPos])
+	}
+}
+
+func TestInvMerge(t *testing.T) {
+	invTestInit(t)
+
+	shards := make(map[string]string) // shard name => content
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, apc.URLPathObjects.Join(invBck.Name)+"/")
+		content, ok := shards[name]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, content)
+	}))
+	defer srv.Close()
+
+	si := &meta.Snode{DataNet: meta.NetInfo{URL: srv.URL}}
+	si.Init("t1", apc.Target)
+	smap := &meta.Smap{Tmap: meta.NodeMap{si.ID(): si}}
+
+	tests := []struct {
+		name    string
+		targets [][]string // per target: shards (in order), each shard a sequence of lines
+		expect  string     // merged (empty when expecting error)
+	}{
+		{
+			name: "ordering",
+			targets: [][]string{
+				{"b,a,1\nb,c,3\n", "b,e,5\n"},
+				{"b,b,2\nb,d,4\nb,f,6\n"},
+				{"b,aa,7\n"},
+			},
+			expect: "b,a,1\nb,aa,7\nb,b,2\nb,c,3\nb,d,4\nb,e,5\nb,f,6\n",
+		},
+		{
+			name: "duplicates",
+			targets: [][]string{
+				{"b,a,1\nb,b,2\n"},
+				{"b,b,2\nb,c,3\n"},
+				{"b,c,3\n"},
+			},
+			expect: "b,a,1\nb,b,2\nb,c,3\n",
+		},
+		{
+			name: "empty",
+			targets: [][]string{
+				{},
+				{"", "b,a,1\n", ""},
+				{"b,\"x,y\",2\n"},
+			},
+			expect: "b,a,1\nb,\"x,y\",2\n",
+		},
+		{
+			name:    "malformed-quote",
+			targets: [][]string{{"b,a,1\n"}, {"b,\"b,2\n"}},
+		},
+		{
+			name:    "malformed-fields",
+			targets: [][]string{{"b,a,1\n"}, {"b,b,2\nb,c\n"}},
+		},
+		{
+			name:    "malformed-key",
+			targets: [][]string{{"b\n"}},
+		},
+		{
+			name:    "missing-shard",
+			targets: [][]string{{"b,a,1\n"}, {"b,b,2\n"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mfs := make([]*invManifest, 0, len(test.targets))
+			for i, files := range test.targets {
+				mf := &invManifest{}
+				for j, content := range files {
+					name := test.name + "/" + cos.GenTie() + "-" + string(rune('a'+i)) + string(rune('0'+j)) + ".csv"
+					if test.name != "missing-shard" || i == 0 {
+						shards[name] = content
+					}
+					mf.Files = append(mf.Files, invFile{Key: name})
+				}
+				mfs = append(mfs, mf)
+			}
+			lom := invTestLom(t, test.name+".csv")
+			defer core.FreeLOM(lom)
+
+			err := invMerge(meta.CloneBck(&invBck), lom, mfs, "xid", smap)
+			if test.expect == "" {
+				tassert.Fatalf(t, err != nil, "expected error")
+				_, errS := os.Stat(lom.FQN)
+				tassert.Errorf(t, os.IsNotExist(errS), "expected no merged inventory, got %v", errS)
+				return
+			}
+			tassert.CheckFatal(t, err)
+			b, err := os.ReadFile(lom.FQN)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, string(b) == test.expect, "expected %q, got %q", test.expect, string(b))
+			tassert.Errorf(t, lom.Lsize() == int64(len(b)), "expected size %d, got %d", len(b), lom.Lsize())
+			xid, _ := lom.GetCustomKey(invXidKey)
+			tassert.Errorf(t, xid == "xid", "expected xid %q, got %q", "xid", xid)
+		})
+	}
+}
+
+func TestListInvAIS(t *testing.T) {
+	invTestInit(t)
+
+	const content = "b,a,1,x\n" +
+		"b,\"b,1\",2,y\n" +
+		"b,bad\"quote,3\n" + // malformed
+		"b\n" + // malformed (no key)
+		"b,dir/c,4\n" +
+		"b,dir/d,5,z,v1\n" +
+		"b,e,6\n"
+	lom := invTestLom(t, "inv.csv")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(t, os.WriteFile(lom.FQN, []byte(content), cos.PermRWR))
+
+	tests := []struct {
+		name   string
+		msg    apc.LsoMsg
+		pages  [][]string // expected names, page by page
+		tokens []string   // expected continuation tokens
+	}{
+		{
+			name:   "all",
+			pages:  [][]string{{"a", "b,1", "dir/c", "dir/d", "e"}},
+			tokens: []string{""},
+		},
+		{
+			name:   "paginated",
+			msg:    apc.LsoMsg{PageSize: 2},
+			pages:  [][]string{{"a", "b,1"}, {"dir/c", "dir/d"}, {"e"}},
+			tokens: []string{"dir/c", "e", ""},
+		},
+		{
+			name:   "prefix",
+			msg:    apc.LsoMsg{Prefix: "dir/"},
+			pages:  [][]string{{"dir/c", "dir/d"}},
+			tokens: []string{""},
+		},
+		{
+			name:   "prefix-paginated",
+			msg:    apc.LsoMsg{Prefix: "dir/", PageSize: 1},
+			pages:  [][]string{{"dir/c"}, {"dir/d"}, {}},
+			tokens: []string{"dir/d", "e", ""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fh, err := os.Open(lom.FQN)
+			tassert.CheckFatal(t, err)
+			defer fh.Close()
+			ctx := &core.LsoInvCtx{
+				Lom:    lom,
+				Lmfh:   fh,
+				Schema: []string{invSchemaBucket, invSchemaKey, "Size", "Checksum", "Version"},
+				SGL:    core.T.PageMM().NewSGL(invPageSGL, memsys.DefaultBuf2Size),
+			}
+			defer ctx.SGL.Free()
+
+			var (
+				msg = test.msg
+				lst = &cmn.LsoRes{}
+			)
+			for i, names := range test.pages {
+				err := _listInvAIS(ctx, &msg, lst)
+				tassert.Fatalf(t, err == nil || err == io.EOF, "page %d: %v", i, err)
+				tassert.Fatalf(t, len(lst.Entries) == len(names), "page %d: expected %v, got %d entries", i, names, len(lst.Entries))
+				for j, en := range lst.Entries {
+					tassert.Errorf(t, en.Name == names[j], "page %d: expected %q, got %q", i, names[j], en.Name)
+				}
+				tassert.Errorf(t, lst.ContinuationToken == test.tokens[i], "page %d: expected token %q, got %q",
+					i, test.tokens[i], lst.ContinuationToken)
+				if last := i == len(test.pages)-1; last != (err == io.EOF) {
+					t.Fatalf("page %d: unexpected %v (last page: %t)", i, err, last)
+				}
+			}
+		})
+	}
+
+	// properties
+	fh, err := os.Open(lom.FQN)
+	tassert.CheckFatal(t, err)
+	defer fh.Close()
+	ctx := &core.LsoInvCtx{
+		Lom:    lom,
+		Lmfh:   fh,
+		Schema: []string{invSchemaBucket, invSchemaKey, "Size", "Checksum", "Version"},
+		SGL:    core.T.PageMM().NewSGL(invPageSGL, memsys.DefaultBuf2Size),
+	}
+	defer ctx.SGL.Free()
+	lst := &cmn.LsoRes{}
+	_listInvAIS(ctx, &apc.LsoMsg{}, lst)
+	tassert.Fatalf(t, len(lst.Entries) == 5, "expected 5 entries, got %d", len(lst.Entries))
+	en := lst.Entries[3]
+	tassert.Errorf(t, en.Size == 5 && en.Checksum == "z" && en.Version == "v1" && en.Flags == apc.EntryIsCached,
+		"unexpected %+v", en)
+	en = lst.Entries[2]
+	tassert.Errorf(t, en.Size == 4 && en.Checksum == "" && en.Version == "", "unexpected %+v", en)
+}
+
+// inventory generated into explicitly specified destination (InvMsg.ToBck)
+func TestGetInvAISToBck(t *testing.T) {
+	invTestInit(t)
+
+	const (
+		shard   = "b,a,1\nb,b,2\n"
+		mfName  = ".inventory/inv-bck/manifest-t1.json"
+		shName  = ".inventory/inv-bck/xid/t1-0.csv"
+		invName = ".inventory/inv-bck.csv"
+	)
+	mf := &invManifest{
+		SrcBucket:  invBck.Cname(""),
+		DstBucket:  invToBck.Cname(""),
+		XID:        "xid",
+		FileSchema: "Bucket, Key, Size",
+		Targets:    []string{"t1"},
+		Files:      []invFile{{Key: shName}},
+	}
+	objs := map[string]string{ // bucket/name => content
+		invBck.Name + "/" + mfName:   string(cos.MustMarshal(mf)), // (copy)
+		invToBck.Name + "/" + mfName: string(cos.MustMarshal(mf)),
+		invToBck.Name + "/" + shName: shard,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := objs[strings.TrimPrefix(r.URL.Path, apc.URLPathObjects.S+"/")]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, content)
+	}))
+	defer srv.Close()
+
+	si := &meta.Snode{DataNet: meta.NetInfo{URL: srv.URL}}
+	si.Init("t1", apc.Target)
+	core.T.(*mock.TargetMock).SO = &invSowner{smap: &meta.Smap{Tmap: meta.NodeMap{si.ID(): si}}}
+
+	ctx := &core.LsoInvCtx{}
+	tassert.CheckFatal(t, getInvAIS(meta.CloneBck(&invBck), ctx))
+	b, err := io.ReadAll(ctx.Lmfh)
+	cos.Close(ctx.Lmfh)
+	ctx.Lom.Unlock(false)
+	core.FreeLOM(ctx.Lom)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, string(b) == shard, "expected %q, got %q", shard, string(b))
+	tassert.Errorf(t, strings.Join(ctx.Schema, ",") == "Bucket,Key,Size", "unexpected schema %v", ctx.Schema)
+
+	// destination no longer recorded (default)
+	mf.DstBucket = ""
+	objs[invBck.Name+"/"+mfName] = string(cos.MustMarshal(mf))
+	lom := invTestLom(t, invName)
+	tassert.CheckFatal(t, lom.RemoveMain())
+	core.FreeLOM(lom)
+	ctx = &core.LsoInvCtx{}
+	err = getInvAIS(meta.CloneBck(&invBck), ctx)
+	tassert.Fatalf(t, err != nil, "expected error (shards not in the default destination)")
+}
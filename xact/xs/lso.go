@@ -59,6 +59,7 @@ type (
 			wor          bool             // wantOnlyRemote
 			dontPopulate bool             // when listing remote obj-s: don't include local MD (in re: LsDonAddRemote)
 			this         bool             // r.msg.SID == core.T.SID(): true when this target does remote paging
+			inv          bool             // listing ais:// bucket via its inventory (see inventory.go)
 		}
 		streamingX
 		lensgl int64
@@ -116,12 +117,14 @@ func (p *lsoFactory) Start() (err error) {
 	r.DemandBase.Init(p.UUID(), apc.ActList, p.Bck, r.config.Timeout.MaxHostBusy.D())
 
 	// NOTE: is set by the first message, never changes
-	r.walk.wor = r.msg.WantOnlyRemoteProps()
+	r.walk.inv = p.Bck.IsAIS() && cos.IsParseBool(p.hdr.Get(apc.HdrInventory))
+	r.walk.wor = r.msg.WantOnlyRemoteProps() || r.walk.inv
 	r.walk.this = r.msg.SID == core.T.SID()
 
 	// true iff the bucket was not added - not initialized
-	r.walk.dontPopulate = r.walk.wor && p.Bck.Props == nil
-	debug.Assert(!r.walk.dontPopulate || p.msg.IsFlagSet(apc.LsDontAddRemote))
+	// (or else, ais:// inventory that contains all the properties)
+	r.walk.dontPopulate = (r.walk.wor && p.Bck.Props == nil) || r.walk.inv
+	debug.Assert(!r.walk.dontPopulate || p.msg.IsFlagSet(apc.LsDontAddRemote) || r.walk.inv)
 
 	if r.listRemote() {
 		// begin streams
@@ -192,7 +195,7 @@ loop:
 
 			// cannot change
 			debug.Assert((r.msg.SID == core.T.SID()) == r.walk.this)
-			debug.Assert(r.walk.wor == r.msg.WantOnlyRemoteProps())
+			debug.Assert(r.walk.wor == (r.msg.WantOnlyRemoteProps() || r.walk.inv))
 
 			r.IncPending()
 			resp := r.doPage()
@@ -306,7 +309,9 @@ func (r *LsoXact) Abort(err error) (ok bool) {
 	return
 }
 
-func (r *LsoXact) listRemote() bool { return r.p.Bck.IsRemote() && !r.msg.IsFlagSet(apc.LsObjCached) }
+func (r *LsoXact) listRemote() bool {
+	return (r.p.Bck.IsRemote() || r.walk.inv) && !r.msg.IsFlagSet(apc.LsObjCached)
+}
 
 // Start `fs.WalkBck`, so that by the time we read the next page `r.pageCh` is already populated.
 func (r *LsoXact) initWalk() {
@@ -625,7 +630,7 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 
 	// ls arch
 	// looking only at the file extension - not reading ("detecting") file magic (TODO: add lsmsg flag)
-	archList, err := archive.List(fqn)
+	archList, err := lsArch(fqn)
 	if err != nil {
 		if archive.IsErrUnknownFileExt(err) {
 			// skip and keep going
@@ -650,6 +655,18 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	return nil
 }
 
+// use shard's index when available and valid (regardless of feat.ArchIndex - see index-shards)
+func lsArch(fqn string) ([]*archive.Entry, error) {
+	if mime, err := archive.Mime("", fqn); err == nil && archive.Indexable(mime) {
+		if ct, err := core.NewCTFromFQN(fqn, nil); err == nil {
+			if idx, _ := core.LoadArchIdx(fqn, ct.Make(fs.ArchIdxType)); idx != nil {
+				return idx.List(), nil
+			}
+		}
+	}
+	return archive.List(fqn)
+}
+
 func (r *LsoXact) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
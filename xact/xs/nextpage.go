@@ -83,14 +83,23 @@ func (npg *npgCtx) cb(fqn string, de fs.DirEntry) error {
 func (npg *npgCtx) nextPageR(nentries cmn.LsoEntries, inclStatusLocalMD bool) (lst *cmn.LsoRes, err error) {
 	debug.Assert(!npg.wi.msg.IsFlagSet(apc.LsObjCached))
 	lst = &cmn.LsoRes{Entries: nentries}
-	if npg.ctx != nil {
+	switch {
+	case npg.ctx != nil && npg.bck.IsAIS():
+		// ais:// bucket inventory (see inventory.go)
+		if npg.ctx.Lom == nil {
+			err = getInvAIS(npg.bck, npg.ctx)
+		}
+		if err == nil {
+			err = listInvAIS(npg.wi.msg, lst, npg.ctx)
+		}
+	case npg.ctx != nil:
 		if npg.ctx.Lom == nil {
 			_, err = core.T.Backend(npg.bck).GetBucketInv(npg.bck, npg.ctx)
 		}
 		if err == nil {
 			err = core.T.Backend(npg.bck).ListObjectsInv(npg.bck, npg.wi.msg, lst, npg.ctx)
 		}
-	} else {
+	default:
 		_, err = core.T.Backend(npg.bck).ListObjects(npg.bck, npg.wi.msg, lst)
 	}
 	if err != nil {
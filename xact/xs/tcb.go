@@ -257,10 +257,16 @@ func (r *XactTCB) qcb(tot time.Duration) core.QuiRes {
 }
 
 func (r *XactTCB) do(lom *core.LOM, buf []byte) (err error) {
-	var (
-		args   = r.p.args // TCBArgs
-		toName = args.Msg.ToName(lom.ObjName)
-	)
+	args := r.p.args // TCBArgs
+	if flt := args.Msg.Filter; flt != nil {
+		if ok, err := selectObj(lom, flt); !ok {
+			if err != nil && !cos.IsNotExist(err, 0) {
+				r.AddErr(err, 5, cos.SmoduleXs)
+			}
+			return nil
+		}
+	}
+	toName := args.Msg.ToName(lom.ObjName)
 	if cmn.Rom.FastV(5, cos.SmoduleXs) {
 		nlog.Infoln(r.Base.Name()+":", lom.Cname(), "=>", args.BckTo.Cname(toName))
 	}
@@ -348,6 +354,20 @@ func (r *XactTCB) _recv(hdr *transport.ObjHdr, objReader io.Reader, lom *core.LO
 
 func (r *XactTCB) Args() *xreg.TCBArgs { return r.p.args }
 
+// select (or skip) source object; expecting loaded LOM
+// (common for x-tcb and x-tco)
+func selectObj(lom *core.LOM, flt *apc.ObjFilter) (bool, error) {
+	var mtime int64
+	if flt.NeedMtime() {
+		_, _, mt, err := lom.Fstat(false /*get atime*/)
+		if err != nil {
+			return false, err
+		}
+		mtime = mt.UnixNano()
+	}
+	return flt.Match(lom.Lsize(), mtime, lom.AtimeUnix(), lom.GetCustomKey), nil
+}
+
 func (r *XactTCB) _str() (s string) {
 	msg := &r.p.args.Msg.CopyBckMsg
 	if msg.Prefix != "" {
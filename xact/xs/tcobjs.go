@@ -308,6 +308,9 @@ func (r *XactTCObjs) _put(hdr *transport.ObjHdr, objReader io.Reader, lom *core.
 ///////////
 
 func (wi *tcowi) do(lom *core.LOM, lrit *lriterator) {
+	if wi.msg.Filter != nil && !wi.selected(lom) {
+		return
+	}
 	var (
 		objNameTo = wi.msg.ToName(lom.ObjName)
 		buf, slab = core.T.PageMM().Alloc()
@@ -343,6 +346,21 @@ func (wi *tcowi) do(lom *core.LOM, lrit *lriterator) {
 	}
 }
 
+// filtering applies to in-cluster objects (remote objects that are not present are skipped)
+func (wi *tcowi) selected(lom *core.LOM) bool {
+	err := lom.Load(true /*cache it*/, false /*locked*/)
+	if err == nil {
+		var ok bool
+		if ok, err = selectObj(lom, wi.msg.Filter); ok {
+			return true
+		}
+	}
+	if err != nil && !cos.IsNotExist(err, 0) {
+		wi.r.AddErr(err, 5, cos.SmoduleXs)
+	}
+	return false
+}
+
 //
 // remove objects not present at the source (when synchronizing bckFrom => bckTo)
 // TODO: probabilistic filtering
@@ -42,6 +42,9 @@ func (wi *walkInfo) setWanted(e *cmn.LsoEnt, lom *core.LOM) {
 		custom  = e.Custom
 		version = e.Version
 	)
+	if _, ok := lom.GetCustomKey(cmn.WritebackObjMD); ok {
+		e.SetPending()
+	}
 	for name, fl := range allmap {
 		if !wi.wanted.IsSet(fl) {
 			continue
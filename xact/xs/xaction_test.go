@@ -297,6 +297,52 @@ func TestXactionQueryFinished(t *testing.T) {
 	}
 }
 
+// finished xactions must survive registry reset (node restart)
+func TestXactionHistory(t *testing.T) {
+	var (
+		bmd    = mock.NewBaseBownerMock()
+		bck    = meta.NewBck("test-hist", apc.AIS, cmn.NsGlobal)
+		config = &cmn.Config{}
+	)
+	config.ConfigDir = t.TempDir()
+	core.T = mock.NewTarget(bmd)
+	bmd.Add(bck)
+	cos.InitShortID(0)
+
+	xreg.TestReset()
+	xreg.InitHist(config)
+	xreg.RegBckXact(&xs.TestBmvFactory{})
+
+	rns := xreg.RenewBckRename(bck, bck, cos.GenUUID(), 123, "phase")
+	tassert.Fatalf(t, rns.Err == nil && rns.Entry.Get() != nil, "Xaction must be created %v", rns.Err)
+	xctn := rns.Entry.Get()
+	xctn.SetOrigin(&apc.ActMsg{Action: apc.ActMoveBck, Name: bck.Name}, "tester")
+	xctn.Abort(errors.New("test-abort"))
+	xctn.Finish()
+	xreg.FlushHist()
+
+	// "restart"
+	xreg.TestReset()
+	xreg.InitHist(config)
+	defer xreg.TestReset()
+
+	snaps, err := xreg.GetSnap(xreg.Flt{Kind: apc.ActMoveBck, Bck: bck})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(snaps) == 1, "expected one finished xaction, got %d", len(snaps))
+	snap := snaps[0]
+	tassert.Errorf(t, snap.ID == xctn.ID(), "expected %q, got %q", xctn.ID(), snap.ID)
+	tassert.Errorf(t, snap.AbortedX && snap.AbortErr != "", "expected aborted, got %+v", snap)
+	tassert.Errorf(t, snap.Msg != nil && snap.Msg.Action == apc.ActMoveBck && snap.User == "tester",
+		"expected origin, got (%v, %q)", snap.Msg, snap.User)
+
+	tassert.Errorf(t, xreg.GetHistSnap(xctn.ID()) != nil, "expected to find %s by ID", xctn.ID())
+
+	time.Sleep(10 * time.Millisecond)
+	snaps, err = xreg.GetSnap(xreg.Flt{Kind: apc.ActMoveBck, Since: time.Millisecond})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(snaps) == 0, "expected nothing finished in the last 1ms, got %d", len(snaps))
+}
+
 func TestBeid(t *testing.T) {
 	const div = uint64(100 * time.Millisecond)
 	num := 100